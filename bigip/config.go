@@ -3,8 +3,9 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/f5devcentral/go-bigip"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 type Config struct {
@@ -12,10 +13,58 @@ type Config struct {
 	Username       string
 	Password       string
 	LoginReference string
+	Token          string
 	ConfigOptions  *bigip.ConfigOptions
+	// ValidateMonitors makes resources check that referenced monitors exist
+	// before sending them to the BigIP
+	ValidateMonitors bool
+	// Transactional makes resources that need several requests to create an
+	// entity send them in a single transaction
+	Transactional bool
+	// DefaultPartition is the partition of resources that do not set one
+	DefaultPartition string
+	// SerializeChanges makes resources hold the change lock of the BigIP
+	// while they create, update or delete entities
+	SerializeChanges bool
+	// LockTimeout is how long to wait for a change lock held by another run
+	LockTimeout time.Duration
 }
 
-func (c *Config) Client() (*bigip.BigIP, error) {
+// bigipClient is the meta of the provider, the client of the BigIP together
+// with the provider configuration it was created with, so resources can look
+// up provider level settings. Copies of the client made with WithTransaction
+// or WithDeadline are wrapped with the same configuration.
+type bigipClient struct {
+	*bigip.BigIP
+	config *Config
+}
+
+// partition returns the partition of resources that do not set one.
+func (c *Config) partition() string {
+	if c.DefaultPartition == "" {
+		return DEFAULT_PARTITION
+	}
+	return c.DefaultPartition
+}
+
+// Client returns the client of the BigIP, validating the connection first.
+func (c *Config) Client() (*bigipClient, error) {
+
+	if c.Token != "" {
+		if c.Address == "" {
+			return nil, fmt.Errorf("BigIP provider requires address")
+		}
+		if c.Password != "" || c.LoginReference != "" {
+			return nil, fmt.Errorf("BigIP provider token can not be combined with password or token_auth, use one authentication method")
+		}
+		log.Println("[INFO] Initializing BigIP connection with token")
+		client := bigip.NewSession(c.Address, c.Username, "", c.ConfigOptions)
+		client.Token = c.Token
+		if err := c.validateConnection(client); err != nil {
+			return nil, err
+		}
+		return &bigipClient{client, c}, nil
+	}
 
 	if c.Address != "" && c.Username != "" && c.Password != "" {
 		log.Println("[INFO] Initializing BigIP connection")
@@ -33,11 +82,11 @@ func (c *Config) Client() (*bigip.BigIP, error) {
 		}
 		err = c.validateConnection(client)
 		if err == nil {
-			return client, nil
+			return &bigipClient{client, c}, nil
 		}
 		return nil, err
 	}
-	return nil, fmt.Errorf("BigIP provider requires address, username and password")
+	return nil, fmt.Errorf("BigIP provider requires address and either username and password or token")
 }
 
 func (c *Config) validateConnection(client *bigip.BigIP) error {
@@ -0,0 +1,278 @@
+package bigip
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func testRetryConfig(url string) *Config {
+	return &Config{
+		Address:  url,
+		Username: "admin",
+		Password: "admin",
+		ConfigOptions: &bigip.ConfigOptions{
+			APICallTimeout: 10 * time.Second,
+			MaxRetries:     2,
+			RetryBackoff:   time.Millisecond,
+		},
+	}
+}
+
+func TestConfigRetriesTransientErrors(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, `{"code":503,"message":"Service Unavailable"}`)
+			return
+		}
+		if calls == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, `{"code":400,"message":"01070711:3: configuration operation in progress"}`)
+			return
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+
+	_, err := testRetryConfig(server.URL).Client()
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestConfigDoesNotRetryClientErrors(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprintf(w, `{"code":401,"message":"Authentication required!"}`)
+	})
+
+	_, err := testRetryConfig(server.URL).Client()
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestConfigDeadlineStopsRetries(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		fmt.Fprintf(w, `{"code":503,"message":"Service Unavailable"}`)
+	})
+
+	config := testRetryConfig(server.URL)
+	config.ConfigOptions.RetryBackoff = time.Minute
+	client, err := config.Client()
+	assert.Nil(t, err)
+	calls = 0
+
+	_, err = client.WithDeadline(time.Now().Add(time.Second)).Nodes()
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+
+	_, err = client.WithDeadline(time.Now().Add(-time.Second)).Nodes()
+	assert.EqualError(t, err, "deadline exceeded before GET ltm/node")
+	assert.Equal(t, 1, calls)
+}
+
+func TestConfigClientCopies(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+
+	config := testRetryConfig(server.URL)
+	config.DefaultPartition = "Tenant"
+	client, err := config.Client()
+	assert.Nil(t, err)
+	d := schema.TestResourceDataRaw(t, map[string]*schema.Schema{}, map[string]interface{}{})
+	copy, _ := clientForOperation(client, d, schema.TimeoutRead)
+	assert.Equal(t, "Tenant", copy.config.partition())
+	assert.Equal(t, DEFAULT_PARTITION, (&Config{}).partition())
+}
+
+func TestConfigRefreshesExpiredToken(t *testing.T) {
+	setup()
+	defer teardown()
+	logins := 0
+	mux.HandleFunc("/mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		logins++
+		fmt.Fprintf(w, `{"token":{"token":"token-%d"}}`, logins)
+	})
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("X-F5-Auth-Token") != "token-2" {
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `{"code":401,"message":"X-F5-Auth-Token has expired."}`)
+			return
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+
+	config := testRetryConfig(server.URL)
+	config.LoginReference = "tmos"
+	client, err := config.Client()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, logins)
+	assert.Equal(t, "token-2", client.AuthToken())
+}
+
+func TestConfigSharesRefreshedToken(t *testing.T) {
+	setup()
+	defer teardown()
+	var mu sync.Mutex
+	logins, rejected := 0, 0
+	valid := ""
+	mux.HandleFunc("/mgmt/shared/authn/login", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		logins++
+		valid = fmt.Sprintf("token-%d", logins)
+		fmt.Fprintf(w, `{"token":{"token":"%s"}}`, valid)
+	})
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Header.Get("X-F5-Auth-Token") != valid {
+			rejected++
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `{"code":401,"message":"X-F5-Auth-Token has expired."}`)
+			return
+		}
+		fmt.Fprintf(w, `{}`)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", handler)
+	mux.HandleFunc("/mgmt/tm/ltm/node", handler)
+	expire := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		valid = "expired"
+	}
+
+	config := testRetryConfig(server.URL)
+	config.LoginReference = "tmos"
+	client, err := config.Client()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, logins)
+
+	// The token renewed by a copy of the client is used by the client
+	expire()
+	_, err = client.WithDeadline(time.Now().Add(time.Minute)).Nodes()
+	assert.Nil(t, err)
+	_, err = client.Nodes()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, logins)
+	assert.Equal(t, 1, rejected)
+
+	// Concurrent requests renew an expired token once
+	expire()
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.WithTransaction(0).Nodes()
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 3, logins)
+	assert.Equal(t, "token-3", client.AuthToken())
+}
+
+func TestConfigTokenConflictsWithPassword(t *testing.T) {
+	config := testRetryConfig("10.10.10.1")
+	config.Token = "token"
+	_, err := config.Client()
+	assert.NotNil(t, err)
+}
+
+func TestConfigReusesConnections(t *testing.T) {
+	for _, keepAlive := range []bool{true, false} {
+		var connections int32
+		ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{}`)
+		}))
+		ts.Config.ConnState = func(c net.Conn, state http.ConnState) {
+			if state == http.StateNew {
+				atomic.AddInt32(&connections, 1)
+			}
+		}
+		ts.Start()
+
+		config := testRetryConfig(ts.URL)
+		config.ConfigOptions.MaxIdleConnsPerHost = 10
+		config.ConfigOptions.DisableKeepAlives = !keepAlive
+		client, err := config.Client()
+		assert.Nil(t, err)
+		for i := 0; i < 5; i++ {
+			_, err := client.GetNode("test-node")
+			assert.Nil(t, err)
+		}
+		ts.Close()
+
+		if keepAlive {
+			assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+		} else {
+			assert.Equal(t, int32(6), atomic.LoadInt32(&connections))
+		}
+	}
+}
+
+func TestConfigPort(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	_, port, _ := net.SplitHostPort(server.Listener.Addr().String())
+
+	config := testRetryConfig("http://127.0.0.1")
+	config.ConfigOptions.Port, _ = strconv.Atoi(port)
+	client, err := config.Client()
+	assert.Nil(t, err)
+	assert.Equal(t, "http://127.0.0.1:"+port, client.Host)
+
+	// A port in the address takes precedence
+	config = testRetryConfig(server.URL)
+	config.ConfigOptions.Port = 8443
+	client, err = config.Client()
+	assert.Nil(t, err)
+	assert.Equal(t, server.URL, client.Host)
+
+	config = testRetryConfig("10.10.10.1")
+	config.ConfigOptions.Port = 8443
+	assert.Equal(t, "https://10.10.10.1:8443", bigip.NewSession(config.Address, "admin", "admin", config.ConfigOptions).Host)
+}
+
+func TestConfigPortDefaultsToScheme(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, Provider().(*schema.Provider).Schema, map[string]interface{}{})
+	options := &bigip.ConfigOptions{Port: d.Get("port").(int)}
+	assert.Equal(t, "http://127.0.0.1", bigip.NewSession("http://127.0.0.1", "admin", "admin", options).Host)
+	assert.Equal(t, "https://10.10.10.1", bigip.NewSession("10.10.10.1", "admin", "admin", options).Host)
+}
@@ -0,0 +1,160 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func dataSourceBigipLtmNode() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBigipLtmNodeRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the node",
+				ValidateFunc: validateF5Name,
+			},
+			"address": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Address of the node",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "User defined description of the node",
+			},
+			"route_domain": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Route domain of the node address",
+			},
+			"connection_limit": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum number of connections allowed for the node",
+			},
+			"dynamic_ratio": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Dynamic ratio number of the node",
+			},
+			"ratio": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Ratio weight of the node",
+			},
+			"monitor": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Monitor or monitor rule associated with the node",
+			},
+			"rate_limit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Maximum number of connections per second allowed for the node",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the node as reported by the BigIP",
+			},
+			"session": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Session status of the node",
+			},
+			"fqdn": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"address_family": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"interval": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"downinterval": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"autopopulate": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBigipLtmNodeRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Get("name").(string)
+	log.Println("[INFO] Fetching node " + name)
+
+	node, err := client.GetNode(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving node %s: %v", name, err)
+	}
+	if node == nil {
+		return fmt.Errorf("Node %s not found", name)
+	}
+
+	d.SetId(name)
+
+	fqdn := []interface{}{}
+	if node.FQDN.Name != "" {
+		d.Set("address", node.FQDN.Name)
+		fqdn = append(fqdn, map[string]interface{}{
+			"name":           node.FQDN.Name,
+			"interval":       node.FQDN.Interval,
+			"downinterval":   node.FQDN.DownInterval,
+			"autopopulate":   node.FQDN.AutoPopulate,
+			"address_family": node.FQDN.AddressFamily,
+		})
+	} else {
+		address, routeDomain := reportedNodeAddress(node)
+		d.Set("address", address)
+		d.Set("route_domain", routeDomain)
+	}
+	if err := d.Set("fqdn", fqdn); err != nil {
+		return fmt.Errorf("Error saving fqdn to state for Node (%s): %s", name, err)
+	}
+
+	d.Set("description", reportedNodeDescription(node))
+	d.Set("connection_limit", reportedConnectionLimit(node))
+	d.Set("dynamic_ratio", node.DynamicRatio)
+	d.Set("ratio", node.Ratio)
+	d.Set("monitor", normalizeMonitorRule(node.Monitor, ""))
+	d.Set("rate_limit", node.RateLimit)
+	d.Set("state", node.State)
+	d.Set("session", node.Session)
+
+	return nil
+}
+
+// reportedNodeAddress returns the address of an IP node and its route domain,
+// the address is kept as reported by the BigIP when it cannot be parsed.
+func reportedNodeAddress(node *bigip.Node) (string, int) {
+	address, routeDomain, err := parseNodeAddress(node.Address)
+	if err != nil {
+		log.Printf("[WARN] Keeping the address of node %s as reported by the BigIP: %s", node.FullPath, err)
+		return node.Address, 0
+	}
+	return address, routeDomain
+}
@@ -0,0 +1,86 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// dataSourceBigipLtmNodeStats reads the statistics of a node, the values are
+// those at the time of the refresh and change between two runs.
+func dataSourceBigipLtmNodeStats() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBigipLtmNodeStatsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the node",
+				ValidateFunc: validateF5Name,
+			},
+			"cur_conns": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current number of server side connections to the node",
+			},
+			"max_conns": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Maximum number of concurrent server side connections to the node",
+			},
+			"tot_conns": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of server side connections to the node",
+			},
+			"tot_requests": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of requests sent to the node",
+			},
+			"cur_sessions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current number of sessions of the node",
+			},
+			"availability_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Availability of the node as reported by its monitor",
+			},
+			"enabled_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Whether the node is enabled",
+			},
+		},
+	}
+}
+
+func dataSourceBigipLtmNodeStatsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Get("name").(string)
+	log.Println("[INFO] Fetching statistics of node " + name)
+
+	stats, err := client.GetNodeStats(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving statistics of node %s: %v", name, err)
+	}
+	if stats == nil {
+		return fmt.Errorf("Node %s not found", name)
+	}
+
+	d.SetId(name)
+	d.Set("cur_conns", stats.CurConns)
+	d.Set("max_conns", stats.MaxConns)
+	d.Set("tot_conns", stats.TotConns)
+	d.Set("tot_requests", stats.TotRequests)
+	d.Set("cur_sessions", stats.CurSessions)
+	d.Set("availability_state", stats.AvailabilityState)
+	d.Set("enabled_state", stats.EnabledState)
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package bigip
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func testBigipLtmNodeDataSource(url string, name string) string {
+	return fmt.Sprintf(`
+		data "bigip_ltm_node" "test-node" {
+			name = "%s"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, name, url)
+}
+
+func TestAccBigipLtmNodeDataSource(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10%%2","monitor":"default","connectionLimit":10,"rateLimit":"disabled","state":"up","session":"monitor-enabled"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~missing-node", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node (/Common/missing-node) was not found."}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeDataSource(server.URL, "/Common/test-node"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "address", "10.10.10.10"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "route_domain", "2"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "monitor", "default"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "connection_limit", "10"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "session", "monitor-enabled"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node.test-node", "fqdn.#", "0"),
+				),
+			},
+			{
+				Config:      testBigipLtmNodeDataSource(server.URL, "/Common/missing-node"),
+				ExpectError: regexp.MustCompile("Node /Common/missing-node not found"),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmNodeStatsDataSource(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node/stats", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"entries":{"https://localhost/mgmt/tm/ltm/node/~Common~test-node/stats":{"nestedStats":{"entries":{"serverside.curConns":{"value":12},"serverside.maxConns":{"value":40},"serverside.totConns":{"value":1500},"totRequests":{"value":9000},"curSessions":{"value":3},"status.availabilityState":{"description":"available"},"status.enabledState":{"description":"enabled"}}}}}}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~missing-node/stats", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node (/Common/missing-node) was not found."}`)
+	})
+	defer teardown()
+	config := func(name string) string {
+		return fmt.Sprintf(`
+			data "bigip_ltm_node_stats" "test-node" {
+				name = "%s"
+			}
+			provider "bigip" {
+				address = "%s"
+				username = "admin"
+				password = "admin"
+			}
+		`, name, server.URL)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config("/Common/test-node"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_node_stats.test-node", "cur_conns", "12"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node_stats.test-node", "max_conns", "40"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node_stats.test-node", "tot_conns", "1500"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node_stats.test-node", "tot_requests", "9000"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node_stats.test-node", "cur_sessions", "3"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node_stats.test-node", "availability_state", "available"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_node_stats.test-node", "enabled_state", "enabled"),
+				),
+			},
+			{
+				Config:      config("/Common/missing-node"),
+				ExpectError: regexp.MustCompile("Node /Common/missing-node not found"),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmNodesDataSource(t *testing.T) {
+	setup()
+	defer func(size int) { nodesPageSize = size }(nodesPageSize)
+	nodesPageSize = 2
+	var requests []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		requests = append(requests, fmt.Sprintf("%s top %s skip %s", query.Get("$filter"), query.Get("$top"), query.Get("$skip")))
+		items := []string{
+			`{"name":"web-1","partition":"Tenant1","fullPath":"/Tenant1/web-1","address":"10.0.0.1%2","monitor":"/Common/icmp ","state":"up","session":"monitor-enabled"}`,
+			`{"name":"web-2","partition":"Tenant1","fullPath":"/Tenant1/web-2","address":"10.0.0.2","state":"user-down","session":"user-disabled","description":"maintenance"}`,
+			`{"name":"api","partition":"Tenant1","fullPath":"/Tenant1/api","address":"any6","fqdn":{"tmName":"api.example.com"},"state":"fqdn-up","session":"user-enabled"}`,
+		}
+		skip, _ := strconv.Atoi(query.Get("$skip"))
+		top, _ := strconv.Atoi(query.Get("$top"))
+		if skip+top < len(items) {
+			fmt.Fprintf(w, `{"items":[%s],"nextLink":"https://localhost/mgmt/tm/ltm/node?$skip=%d"}`, strings.Join(items[skip:skip+top], ","), skip+top)
+			return
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items[skip:], ","))
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "bigip_ltm_nodes" "tenant1" {
+						partition = "Tenant1"
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.#", "3"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.0.full_path", "/Tenant1/web-1"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.0.address", "10.0.0.1"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.0.route_domain", "2"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.0.monitor", "/Common/icmp"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.1.name", "web-2"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.1.state", "user-down"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.1.description", "maintenance"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_nodes.tenant1", "nodes.2.address", "api.example.com"),
+				),
+			},
+		},
+	})
+	// Each read of the data source requests the two pages once
+	assert.Equal(t, []string{
+		"partition eq Tenant1 top 2 skip 0",
+		"partition eq Tenant1 top 2 skip 2",
+	}, requests[:2])
+	assert.Len(t, requests, 2*strings.Count(strings.Join(requests, "\n"), "skip 0"))
+}
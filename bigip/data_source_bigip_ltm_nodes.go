@@ -0,0 +1,118 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// nodesPageSize is the number of nodes requested at once when listing the
+// nodes of a partition.
+var nodesPageSize = 500
+
+// dataSourceBigipLtmNodes lists the nodes of a partition, e.g. to find the
+// nodes that are not managed by Terraform.
+func dataSourceBigipLtmNodes() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceBigipLtmNodesRead,
+
+		Schema: map[string]*schema.Schema{
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Partition to list the nodes of, defaults to the default_partition of the provider",
+			},
+			"nodes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Nodes of the partition",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"full_path": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"address": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"route_domain": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"session": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"monitor": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"description": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceBigipLtmNodesRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	partition := d.Get("partition").(string)
+	if partition == "" {
+		partition = client.config.partition()
+	}
+	log.Println("[INFO] Fetching the nodes of partition " + partition)
+
+	nodes := []interface{}{}
+	for skip := 0; ; skip += nodesPageSize {
+		page, err := client.PartitionNodes(partition, nodesPageSize, skip)
+		if err != nil {
+			return fmt.Errorf("Error retrieving the nodes of partition %s: %v", partition, err)
+		}
+		for i := range page.Nodes {
+			nodes = append(nodes, flattenListedNode(&page.Nodes[i]))
+		}
+		if page.NextLink == "" || len(page.Nodes) == 0 {
+			break
+		}
+	}
+
+	d.SetId(partition)
+	d.Set("partition", partition)
+	if err := d.Set("nodes", nodes); err != nil {
+		return fmt.Errorf("Error saving nodes to state for partition (%s): %s", partition, err)
+	}
+	return nil
+}
+
+func flattenListedNode(node *bigip.Node) map[string]interface{} {
+	address, routeDomain := node.FQDN.Name, 0
+	if address == "" {
+		address, routeDomain = reportedNodeAddress(node)
+	}
+	return map[string]interface{}{
+		"name":         node.Name,
+		"full_path":    node.FullPath,
+		"address":      address,
+		"route_domain": routeDomain,
+		"state":        node.State,
+		"session":      node.Session,
+		"monitor":      normalizeMonitorRule(node.Monitor, ""),
+		"description":  reportedNodeDescription(node),
+	}
+}
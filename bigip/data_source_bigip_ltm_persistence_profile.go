@@ -0,0 +1,174 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// persistenceProfileTypes are the persistence profile types read by the
+// bigip_ltm_persistence_profile data source, as named by the BigIP.
+var persistenceProfileTypes = []string{"cookie", "dest-addr", "hash", "host", "msrdp", "sip", "source-addr", "ssl", "universal"}
+
+func dataSourceBigipLtmPersistenceProfile() *schema.Resource {
+	computed := func(t schema.ValueType, description string) *schema.Schema {
+		return &schema.Schema{Type: t, Computed: true, Description: description}
+	}
+	return &schema.Resource{
+		Read: dataSourceBigipLtmPersistenceProfileRead,
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the persistence profile",
+				ValidateFunc: validateF5Name,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Type of the persistence profile, e.g. cookie or source-addr",
+				ValidateFunc: validateStringValue(persistenceProfileTypes),
+			},
+
+			"app_service":           computed(schema.TypeString, "Application service the profile belongs to"),
+			"defaults_from":         computed(schema.TypeString, "Parent profile the profile inherits from"),
+			"match_across_pools":    computed(schema.TypeString, "Whether persistence records match across pools"),
+			"match_across_services": computed(schema.TypeString, "Whether persistence records match across services"),
+			"match_across_virtuals": computed(schema.TypeString, "Whether persistence records match across virtual servers"),
+			"mirror":                computed(schema.TypeString, "Whether persistence records are mirrored to the peer device"),
+			"timeout":               computed(schema.TypeInt, "Timeout for persistence of the session"),
+			"override_conn_limit":   computed(schema.TypeString, "Whether pool member connection limits are overridden for persisted clients"),
+
+			// cookie, hash_length and hash_offset also for hash
+			"method":            computed(schema.TypeString, "Cookie persistence method"),
+			"always_send":       computed(schema.TypeString, "Whether the cookie is sent in every response"),
+			"cookie_encryption": computed(schema.TypeString, "Cookie encryption, disabled, preferred or required"),
+			"cookie_name":       computed(schema.TypeString, "Name of the cookie"),
+			"expiration":        computed(schema.TypeString, "Expiration of the cookie"),
+			"hash_length":       computed(schema.TypeInt, "Length of the hash"),
+			"hash_offset":       computed(schema.TypeInt, "Offset of the hash"),
+			"httponly":          computed(schema.TypeString, "Whether the cookie is sent with the HttpOnly attribute"),
+
+			// dest-addr, hash and source-addr
+			"hash_algorithm": computed(schema.TypeString, "Algorithm the address is hashed with"),
+			"mask":           computed(schema.TypeString, "Mask applied to the address"),
+			"map_proxies":    computed(schema.TypeString, "Whether the addresses of known proxies map to the same persistence record"),
+
+			// universal
+			"rule": computed(schema.TypeString, "iRule whose persistence key the profile uses"),
+		},
+	}
+}
+
+func dataSourceBigipLtmPersistenceProfileRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Get("name").(string)
+	profileType := d.Get("type").(string)
+	log.Printf("[INFO] Fetching %s persistence profile %s", profileType, name)
+
+	pp, specific, err := getPersistenceProfile(client, profileType, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving %s persistence profile %s: %v", profileType, name, apiErrorDetails(err))
+	}
+	if pp == nil {
+		return fmt.Errorf("%s persistence profile %s not found", profileType, name)
+	}
+
+	d.SetId(name)
+	if err := persistenceProfileToData(pp, d); err != nil {
+		return err
+	}
+	for k, v := range specific {
+		d.Set(k, v)
+	}
+	return nil
+}
+
+// getPersistenceProfile returns the persistence profile of the type, along
+// with the attributes specific to its type. The profile is nil when it does
+// not exist.
+func getPersistenceProfile(client *bigipClient, profileType, name string) (*bigip.PersistenceProfile, map[string]interface{}, error) {
+	switch profileType {
+	case "cookie":
+		p, err := client.GetCookiePersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, map[string]interface{}{
+			"method":            p.Method,
+			"always_send":       p.AlwaysSend,
+			"cookie_encryption": p.CookieEncryption,
+			"cookie_name":       p.CookieName,
+			"expiration":        p.Expiration,
+			"hash_length":       p.HashLength,
+			"hash_offset":       p.HashOffset,
+			"httponly":          p.HTTPOnly,
+		}, nil
+	case "dest-addr":
+		p, err := client.GetDestAddrPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, map[string]interface{}{
+			"hash_algorithm": p.HashAlgorithm,
+			"mask":           p.Mask,
+		}, nil
+	case "hash":
+		p, err := client.GetHashPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, map[string]interface{}{
+			"hash_algorithm": p.HashAlgorithm,
+			"hash_length":    p.HashLength,
+			"hash_offset":    p.HashOffset,
+		}, nil
+	case "host":
+		p, err := client.GetHostPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, nil, nil
+	case "msrdp":
+		p, err := client.GetMSRDPPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, nil, nil
+	case "sip":
+		p, err := client.GetSIPPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, nil, nil
+	case "source-addr":
+		p, err := client.GetSourceAddrPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, map[string]interface{}{
+			"hash_algorithm": p.HashAlgorithm,
+			"mask":           p.Mask,
+			"map_proxies":    p.MapProxies,
+		}, nil
+	case "ssl":
+		p, err := client.GetSSLPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, nil, nil
+	case "universal":
+		p, err := client.GetUniversalPersistenceProfile(name)
+		if p == nil || err != nil {
+			return nil, nil, err
+		}
+		return &p.PersistenceProfile, map[string]interface{}{
+			"rule": p.Rule,
+		}, nil
+	}
+	return nil, nil, fmt.Errorf("unsupported type %q", profileType)
+}
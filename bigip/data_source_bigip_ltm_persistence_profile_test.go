@@ -0,0 +1,101 @@
+package bigip
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+func testBigipLtmPersistenceProfileDataSource(url, profileType, name string) string {
+	return fmt.Sprintf(`
+		data "bigip_ltm_persistence_profile" "test" {
+			type = "%s"
+			name = "%s"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, profileType, name, url)
+}
+
+func TestAccBigipLtmPersistenceProfileDataSource(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/persistence/cookie/~Common~app-cookie", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"app-cookie","partition":"Common","fullPath":"/Common/app-cookie","defaultsFrom":"/Common/cookie","method":"insert","cookieName":"APP","expiration":"0","httponly":"enabled","matchAcrossServices":"enabled","timeout":"180"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/persistence/source-addr/~Common~app-srcaddr", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"app-srcaddr","partition":"Common","fullPath":"/Common/app-srcaddr","defaultsFrom":"/Common/source_addr","mask":"255.255.255.0","mapProxies":"enabled","timeout":"300"}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPersistenceProfileDataSource(server.URL, "cookie", "/Common/app-cookie"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "id", "/Common/app-cookie"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "defaults_from", "/Common/cookie"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "match_across_services", "enabled"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "timeout", "180"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "method", "insert"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "cookie_name", "APP"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "httponly", "enabled"),
+				),
+			},
+			{
+				Config: testBigipLtmPersistenceProfileDataSource(server.URL, "source-addr", "/Common/app-srcaddr"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "defaults_from", "/Common/source_addr"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "mask", "255.255.255.0"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "map_proxies", "enabled"),
+					resource.TestCheckResourceAttr("data.bigip_ltm_persistence_profile.test", "timeout", "300"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPersistenceProfileDataSourceNotFound(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/persistence/cookie/~Common~missing", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Cookie Persistence Profile (/Common/missing) was not found."}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmPersistenceProfileDataSource(server.URL, "cookie", "/Common/missing"),
+				ExpectError: regexp.MustCompile("cookie persistence profile /Common/missing not found"),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPersistenceProfileDataSourceInvalidType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmPersistenceProfileDataSource("http://localhost", "srcaddr", "/Common/app-srcaddr"),
+				ExpectError: regexp.MustCompile(`"type"`),
+			},
+		},
+	})
+}
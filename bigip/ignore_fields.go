@@ -0,0 +1,42 @@
+package bigip
+
+import (
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// withIgnoreFields adds the ignore_fields attribute to the resource r, which
+// lists attributes among fields that are managed outside Terraform, e.g. a
+// description stamped by an iApp. Once the resource exists, changes of an
+// ignored attribute on either side do not show up as a diff, and the update
+// of the resource has to leave it out of its requests, see isFieldIgnored.
+// Only attributes of a single value can be ignored.
+func withIgnoreFields(r *schema.Resource, fields ...string) *schema.Resource {
+	for _, field := range fields {
+		s := r.Schema[field]
+		field, suppress := field, s.DiffSuppressFunc
+		s.DiffSuppressFunc = func(k, old, new string, d *schema.ResourceData) bool {
+			if d.Id() != "" && isFieldIgnored(d, field) {
+				return true
+			}
+			return suppress != nil && suppress(k, old, new, d)
+		}
+	}
+	r.Schema["ignore_fields"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Attributes managed outside Terraform, whose changes are neither shown as a diff nor sent to the BigIP once the resource exists",
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validateStringValue(fields),
+		},
+		Set: schema.HashString,
+	}
+	return r
+}
+
+// isFieldIgnored tells whether field is listed in the ignore_fields of the
+// resource d.
+func isFieldIgnored(d *schema.ResourceData, field string) bool {
+	ignored, ok := d.Get("ignore_fields").(*schema.Set)
+	return ok && ignored.Contains(field)
+}
@@ -0,0 +1,40 @@
+package bigip
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithIgnoreFields(t *testing.T) {
+	r := withIgnoreFields(&schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"description": {Type: schema.TypeString, Optional: true},
+			"monitor": {
+				Type:     schema.TypeString,
+				Optional: true,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return old == "none" && new == ""
+				},
+			},
+		},
+	}, "description", "monitor")
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"ignore_fields": []interface{}{"description"},
+	})
+	suppress := func(field, old, new string) bool {
+		return r.Schema[field].DiffSuppressFunc(field, old, new, d)
+	}
+
+	// Nothing is ignored before the resource exists
+	assert.False(t, suppress("description", "", "terraform"))
+	d.SetId("test")
+	assert.True(t, suppress("description", "iApp", "terraform"))
+	assert.True(t, isFieldIgnored(d, "description"))
+	// Fields that are not ignored keep their own DiffSuppressFunc
+	assert.False(t, isFieldIgnored(d, "monitor"))
+	assert.True(t, suppress("monitor", "none", ""))
+	assert.False(t, suppress("monitor", "none", "/Common/icmp"))
+}
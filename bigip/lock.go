@@ -0,0 +1,119 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// changeLockName is the internal data group that Terraform runs with
+// serialize_changes create on the BigIP while they change its configuration.
+// Creating an entity that already exists fails, which makes it a lock shared
+// by all processes.
+const changeLockName = "/Common/terraform_change_lock"
+
+// changeLockInterval is the interval at which a held lock is checked again.
+const changeLockInterval = time.Second
+
+// hostLocks serializes the changes of the clients of a BigIP within this
+// process, keyed by host.
+var hostLocks = struct {
+	sync.Mutex
+	m map[string]*sync.Mutex
+}{m: make(map[string]*sync.Mutex)}
+
+func hostLock(host string) *sync.Mutex {
+	hostLocks.Lock()
+	defer hostLocks.Unlock()
+	if _, ok := hostLocks.m[host]; !ok {
+		hostLocks.m[host] = &sync.Mutex{}
+	}
+	return hostLocks.m[host]
+}
+
+// lockChanges acquires the change lock of the BigIP, waiting up to the
+// lock_timeout of the provider while another run holds it. The returned
+// function releases the lock.
+func lockChanges(client *bigipClient) (func(), error) {
+	config := client.config
+	mu := hostLock(client.Host)
+	mu.Lock()
+
+	hostname, _ := os.Hostname()
+	owner := fmt.Sprintf("%s pid %d since %s", hostname, os.Getpid(), time.Now().UTC().Format(time.RFC3339))
+	lock := &bigip.DataGroup{
+		Name:    changeLockName,
+		Type:    "string",
+		Records: []bigip.DataGroupRecord{{Name: "owner", Data: owner}},
+	}
+
+	err := waitForCondition(changeLockInterval, config.LockTimeout, func() (bool, error) {
+		err := client.AddInternalDataGroup(lock)
+		if apiErr, ok := err.(*bigip.APIError); ok && apiErr.StatusCode == http.StatusConflict {
+			log.Printf("[INFO] Waiting for the change lock %s of %s", changeLockName, client.Host)
+			return false, nil
+		}
+		return err == nil, err
+	})
+	if err == errWaitTimeout {
+		err = fmt.Errorf("Timeout after %s waiting for the change lock %s held by %s, "+
+			"delete the data group if no other Terraform run is changing the BigIP", config.LockTimeout, changeLockName, changeLockOwner(client))
+	}
+	if err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("Error acquiring the change lock of %s: %v", client.Host, err)
+	}
+	log.Printf("[INFO] Acquired the change lock %s of %s", changeLockName, client.Host)
+
+	return func() {
+		if err := client.DeleteInternalDataGroup(changeLockName); err != nil {
+			log.Printf("[ERROR] Unable to release the change lock %s of %s, delete the data group: %v", changeLockName, client.Host, err)
+		}
+		mu.Unlock()
+	}, nil
+}
+
+// changeLockOwner describes the run that holds the change lock.
+func changeLockOwner(client *bigipClient) string {
+	lock, err := client.GetInternalDataGroup(changeLockName)
+	if err != nil || lock == nil || len(lock.Records) == 0 {
+		return "another run"
+	}
+	return lock.Records[0].Data
+}
+
+// withChangeLock wraps the create, update or delete function of a resource
+// so that it holds the change lock when the provider sets serialize_changes.
+func withChangeLock(f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		client, ok := meta.(*bigipClient)
+		if !ok || !client.config.SerializeChanges {
+			return f(d, meta)
+		}
+		unlock, err := lockChanges(client)
+		if err != nil {
+			return err
+		}
+		defer unlock()
+		return f(d, meta)
+	}
+}
+
+// serializeChanges wraps the resources with withChangeLock.
+func serializeChanges(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for _, r := range resources {
+		r.Create = withChangeLock(r.Create)
+		r.Update = withChangeLock(r.Update)
+		r.Delete = withChangeLock(r.Delete)
+	}
+	return resources
+}
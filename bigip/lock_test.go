@@ -0,0 +1,113 @@
+package bigip
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipChangeLockServer mocks a BigIP whose change lock is held by
+// another run for the first conflicts attempts, requests are recorded in
+// calls.
+func testBigipChangeLockServer(calls *[]string, conflicts int) {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/data-group/internal", func(w http.ResponseWriter, r *http.Request) {
+		if conflicts > 0 {
+			conflicts--
+			*calls = append(*calls, "lock held")
+			w.WriteHeader(http.StatusConflict)
+			fmt.Fprintf(w, `{"code":409,"message":"01020066:3: The requested internal data group (/Common/terraform_change_lock) already exists in partition Common."}`)
+			return
+		}
+		*calls = append(*calls, "lock")
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/data-group/internal/~Common~terraform_change_lock", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			*calls = append(*calls, "unlock")
+		}
+		fmt.Fprintf(w, `{"name":"terraform_change_lock","type":"string","records":[{"name":"owner","data":"other-host pid 42"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, "create node")
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			*calls = append(*calls, "delete node")
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10"}`)
+	})
+}
+
+func testBigipChangeLockConfig(url string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+			serialize_changes = true
+			lock_timeout = 10
+		}
+	`, url)
+}
+
+func TestAccBigipSerializeChanges(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipChangeLockServer(&calls, 2)
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testBigipChangeLockConfig(server.URL),
+				},
+			},
+		})
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, c.sleeps)
+	})
+	assert.Equal(t, []string{
+		"lock held",
+		"lock held",
+		"lock",
+		"create node",
+		"unlock",
+		"lock",
+		"delete node",
+		"unlock",
+	}, calls)
+}
+
+func TestAccBigipSerializeChangesTimeout(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipChangeLockServer(&calls, 100)
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipChangeLockConfig(server.URL),
+					ExpectError: regexp.MustCompile("Timeout after 10s waiting for the change lock /Common/terraform_change_lock held by other-host pid 42"),
+				},
+			},
+		})
+	})
+	assert.NotContains(t, calls, "create node")
+}
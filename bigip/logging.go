@@ -0,0 +1,91 @@
+package bigip
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/logutils"
+	"github.com/hashicorp/terraform/helper/logging"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// logLevelFilter returns a writer passing the log lines of level and above on
+// to w. Terraform only shows the lines of the level of TF_LOG and above, the
+// filter drops the more verbose lines of the provider before they get there.
+func logLevelFilter(level string, w io.Writer) io.Writer {
+	return &logutils.LevelFilter{
+		Levels:   logging.ValidLevels,
+		MinLevel: logutils.LogLevel(strings.ToUpper(level)),
+		Writer:   w,
+	}
+}
+
+// setLogLevel filters the log of the provider by the log_level of the
+// provider, an empty level keeps all lines.
+func setLogLevel(level string) {
+	if level == "" {
+		return
+	}
+	log.SetOutput(logLevelFilter(level, os.Stderr))
+}
+
+func validateLogLevel(value interface{}, field string) ([]string, []error) {
+	if value.(string) == "" {
+		return nil, nil
+	}
+	levels := make([]string, 0, len(logging.ValidLevels))
+	for _, l := range logging.ValidLevels {
+		levels = append(levels, string(l))
+	}
+	return validateStringValue(levels)(strings.ToUpper(value.(string)), field)
+}
+
+// logResourcef logs a message about an operation on a resource with the
+// resource type, operation and ID as key=value pairs, so the lines of a
+// resource or an operation can be found with grep, e.g.
+//
+//	[INFO] resource=bigip_ltm_node operation=create id=/Common/node1: Creating node with address 10.0.0.1
+func logResourcef(level, resourceType, operation, id, format string, args ...interface{}) {
+	log.Printf("[%s] resource=%s operation=%s id=%s: %s", level, resourceType, operation, id, fmt.Sprintf(format, args...))
+}
+
+// withOperationLog wraps a create, read, update or delete function of a
+// resource to log its start at DEBUG and its outcome and duration at INFO, or
+// ERROR when it fails. Resources without an ID yet are logged by their name
+// when named is set.
+func withOperationLog(resourceType, operation string, named bool, f func(*schema.ResourceData, interface{}) error) func(*schema.ResourceData, interface{}) error {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		id := d.Id()
+		if id == "" && named {
+			id = fmt.Sprint(d.Get("name"))
+		}
+		logResourcef("DEBUG", resourceType, operation, id, "Started")
+		start := time.Now()
+		err := f(d, meta)
+		if err != nil {
+			logResourcef("ERROR", resourceType, operation, id, "Failed after %s: %v", time.Since(start), err)
+			return err
+		}
+		logResourcef("INFO", resourceType, operation, id, "Done in %s", time.Since(start))
+		return nil
+	}
+}
+
+// logOperations wraps the resources with withOperationLog.
+func logOperations(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	for resourceType, r := range resources {
+		named := r.Schema["name"] != nil
+		r.Create = withOperationLog(resourceType, "create", named, r.Create)
+		r.Read = withOperationLog(resourceType, "read", named, r.Read)
+		r.Update = withOperationLog(resourceType, "update", named, r.Update)
+		r.Delete = withOperationLog(resourceType, "delete", named, r.Delete)
+	}
+	return resources
+}
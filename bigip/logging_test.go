@@ -0,0 +1,66 @@
+package bigip
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLogLevelFilter(t *testing.T) {
+	var buf bytes.Buffer
+	logger := log.New(logLevelFilter("warn", &buf), "", 0)
+	logger.Print("[DEBUG] dropped")
+	logger.Print("[INFO] dropped")
+	logger.Print("[WARN] kept")
+	logger.Print("[ERROR] kept")
+	assert.Equal(t, "[WARN] kept\n[ERROR] kept\n", buf.String())
+}
+
+func TestValidateLogLevel(t *testing.T) {
+	for _, level := range []string{"", "TRACE", "debug", "Info"} {
+		_, errs := validateLogLevel(level, "log_level")
+		assert.Empty(t, errs, level)
+	}
+	_, errs := validateLogLevel("verbose", "log_level")
+	assert.Len(t, errs, 1)
+}
+
+func TestWithOperationLog(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	log.SetFlags(0)
+	defer func() {
+		log.SetOutput(os.Stderr)
+		log.SetFlags(log.LstdFlags)
+	}()
+
+	r := &schema.Resource{Schema: map[string]*schema.Schema{
+		"name": {Type: schema.TypeString, Optional: true},
+	}}
+	d := r.Data(nil)
+	d.Set("name", "/Common/test-node")
+
+	create := withOperationLog("bigip_ltm_node", "create", true, func(d *schema.ResourceData, meta interface{}) error {
+		logResourcef("INFO", "bigip_ltm_node", "create", d.Get("name").(string), "Creating node with address %s", "10.10.10.10")
+		return nil
+	})
+	assert.NoError(t, create(d, nil))
+	assert.Regexp(t, `^\[DEBUG\] resource=bigip_ltm_node operation=create id=/Common/test-node: Started
+\[INFO\] resource=bigip_ltm_node operation=create id=/Common/test-node: Creating node with address 10.10.10.10
+\[INFO\] resource=bigip_ltm_node operation=create id=/Common/test-node: Done in \S+
+$`, buf.String())
+
+	buf.Reset()
+	d.SetId("/Common/test-node")
+	del := withOperationLog("bigip_ltm_node", "delete", true, func(d *schema.ResourceData, meta interface{}) error {
+		return errors.New("Error deleting node /Common/test-node: in use")
+	})
+	assert.EqualError(t, del(d, nil), "Error deleting node /Common/test-node: in use")
+	assert.Contains(t, buf.String(), "[ERROR] resource=bigip_ltm_node operation=delete id=/Common/test-node: Failed after")
+	assert.Contains(t, buf.String(), ": Error deleting node /Common/test-node: in use\n")
+}
@@ -1,12 +1,17 @@
 package bigip
 
 import (
+	"fmt"
 	"log"
+	"net/http"
+	"os"
 	"reflect"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 const DEFAULT_PARTITION = "Common"
@@ -20,53 +25,164 @@ func Provider() terraform.ResourceProvider {
 				Description: "Domain name/IP of the BigIP",
 				DefaultFunc: schema.EnvDefaultFunc("BIGIP_HOST", nil),
 			},
+			"port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Port of the BigIP management interface, used when address does not include one. Defaults to the port of the scheme of address, 443 for https and 80 for http",
+				ValidateFunc: validateIntRange(1, 65535),
+			},
 			"username": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Description: "Username with API access to the BigIP",
 				DefaultFunc: schema.EnvDefaultFunc("BIGIP_USER", nil),
 			},
 			"password": {
 				Type:        schema.TypeString,
-				Required:    true,
+				Optional:    true,
 				Description: "The user's password",
-				DefaultFunc: schema.EnvDefaultFunc("BIGIP_PASSWORD", nil),
+			},
+			"token": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				Description:   "Authentication token obtained from /mgmt/shared/authn/login, used instead of username and password",
+				ConflictsWith: []string{"password", "token_auth"},
 			},
 			"token_auth": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Default:     false,
 				Description: "Enable to use an external authentication source (LDAP, TACACS, etc)",
-				DefaultFunc: schema.EnvDefaultFunc("BIGIP_TOKEN_AUTH", nil),
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_TOKEN_AUTH", false),
 			},
 			"login_ref": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "tmos",
 				Description: "Login reference for token authentication (see BIG-IP REST docs for details)",
-				DefaultFunc: schema.EnvDefaultFunc("BIGIP_LOGIN_REF", nil),
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_LOGIN_REF", "tmos"),
+			},
+			"request_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     60,
+				Description: "Timeout in seconds of a single request to the BigIP",
+			},
+			"insecure_tls": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Skip verification of the BigIP TLS certificate",
+			},
+			"validate_monitors": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Check that monitors referenced by resources exist before applying them, at the cost of extra API calls",
 			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Number of times a request failing with a transient error (HTTP 5xx, configuration operation in progress) is retried",
+			},
+			"retry_backoff": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Seconds to wait before the first retry of a failed request, doubled on every subsequent retry",
+			},
+			"transactional": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Create bigip_ltm_monitor and bigip_ltm_pool_attachment resources in a single transaction, so a failure leaves nothing behind. Other resources and updates and deletes are not covered",
+			},
+			"keep_alive": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Reuse connections to the BigIP between requests instead of opening a new one for every request",
+			},
+			"max_idle_connections": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     10,
+				Description: "Number of idle connections kept open for reuse, should be at least the -parallelism of terraform apply",
+			},
+			"default_partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     DEFAULT_PARTITION,
+				Description: "Partition of resources that set neither a partition nor a full path as name, surrounding slashes are removed",
+			},
+			"serialize_changes": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Hold a lock on the BigIP while creating, updating or deleting a resource, so parallel Terraform runs change the BigIP one at a time",
+			},
+			"lock_timeout": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      300,
+				Description:  "Seconds to wait for the lock of serialize_changes held by another run",
+				ValidateFunc: validateIntRange(1, 86400),
+			},
+			"log_level": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Least severe level of the log lines of the provider passed on to Terraform: TRACE, DEBUG, INFO, WARN or ERROR. All lines are passed on unless set, TF_LOG still has to be set to see them.",
+				DefaultFunc:  schema.EnvDefaultFunc("BIGIP_LOG_LEVEL", ""),
+				ValidateFunc: validateLogLevel,
+			},
+			"dry_run": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Description: "Log the create, modify and delete requests to the BigIP instead of sending them, reads are sent",
+				DefaultFunc: schema.EnvDefaultFunc("BIGIP_DRY_RUN", false),
+			},
+		},
+
+		DataSourcesMap: map[string]*schema.Resource{
+			"bigip_ltm_node":                dataSourceBigipLtmNode(),
+			"bigip_ltm_node_stats":          dataSourceBigipLtmNodeStats(),
+			"bigip_ltm_nodes":               dataSourceBigipLtmNodes(),
+			"bigip_ltm_persistence_profile": dataSourceBigipLtmPersistenceProfile(),
 		},
 
-		ResourcesMap: map[string]*schema.Resource{
+		ResourcesMap: logOperations(serializeChanges(map[string]*schema.Resource{
+			"bigip_command":                         resourceBigipCommand(),
+			"bigip_cm_config_sync":                  resourceBigipCmConfigSync(),
 			"bigip_cm_device":                       resourceBigipCmDevice(),
-			"bigip_cm_devicegroup":                  resourceBigipCmDevicegroup(),
+			"bigip_cm_device_group":                 resourceBigipCmDevicegroup(),
+			"bigip_cm_devicegroup":                  deprecatedResource(resourceBigipCmDevicegroup(), "bigip_cm_device_group"),
+			"bigip_gtm_pool":                        resourceBigipGtmPool(),
+			"bigip_gtm_server":                      resourceBigipGtmServer(),
+			"bigip_gtm_wideip":                      resourceBigipGtmWideip(),
 			"bigip_net_route":                       resourceBigipNetRoute(),
 			"bigip_net_selfip":                      resourceBigipNetSelfIP(),
 			"bigip_net_vlan":                        resourceBigipNetVlan(),
 			"bigip_ltm_irule":                       resourceBigipLtmIRule(),
-			"bigip_ltm_datagroup":                   resourceBigipLtmDataGroup(),
+			"bigip_ltm_data_group":                  resourceBigipLtmDataGroup(),
+			"bigip_ltm_datagroup":                   deprecatedResource(resourceBigipLtmDataGroup(), "bigip_ltm_data_group"),
 			"bigip_ltm_monitor":                     resourceBigipLtmMonitor(),
 			"bigip_ltm_node":                        resourceBigipLtmNode(),
+			"bigip_ltm_node_state":                  resourceBigipLtmNodeState(),
 			"bigip_ltm_pool":                        resourceBigipLtmPool(),
 			"bigip_ltm_pool_attachment":             resourceBigipLtmPoolAttachment(),
 			"bigip_ltm_policy":                      resourceBigipLtmPolicy(),
+			"bigip_ltm_profile_client_ssl":          resourceBigipLtmProfileClientSsl(),
+			"bigip_ltm_profile_server_ssl":          resourceBigipLtmProfileServerSsl(),
+			"bigip_ltm_profile_dns":                 resourceBigipLtmProfileDns(),
 			"bigip_ltm_profile_fasthttp":            resourceBigipLtmProfileFasthttp(),
 			"bigip_ltm_profile_fastl4":              resourceBigipLtmProfileFastl4(),
+			"bigip_ltm_profile_ftp":                 resourceBigipLtmProfileFtp(),
 			"bigip_ltm_profile_http2":               resourceBigipLtmProfileHttp2(),
-			"bigip_ltm_profile_httpcompress":        resourceBigipLtmProfileHttpcompress(),
+			"bigip_ltm_profile_http_compression":    resourceBigipLtmProfileHttpcompress(),
+			"bigip_ltm_profile_httpcompress":        deprecatedResource(resourceBigipLtmProfileHttpcompress(), "bigip_ltm_profile_http_compression"),
 			"bigip_ltm_profile_oneconnect":          resourceBigipLtmProfileOneconnect(),
 			"bigip_ltm_profile_tcp":                 resourceBigipLtmProfileTcp(),
+			"bigip_ltm_profile_udp":                 resourceBigipLtmProfileUdp(),
 			"bigip_ltm_persistence_profile_srcaddr": resourceBigipLtmPersistenceProfileSrcAddr(),
 			"bigip_ltm_persistence_profile_dstaddr": resourceBigipLtmPersistenceProfileDstAddr(),
 			"bigip_ltm_persistence_profile_ssl":     resourceBigipLtmPersistenceProfileSSL(),
@@ -75,6 +191,8 @@ func Provider() terraform.ResourceProvider {
 			"bigip_ltm_snatpool":                    resourceBigipLtmSnatpool(),
 			"bigip_ltm_virtual_address":             resourceBigipLtmVirtualAddress(),
 			"bigip_ltm_virtual_server":              resourceBigipLtmVirtualServer(),
+			"bigip_ssl_certificate":                 resourceBigipSslCertificate(),
+			"bigip_ssl_key":                         resourceBigipSslKey(),
 			"bigip_sys_dns":                         resourceBigipSysDns(),
 			"bigip_sys_iapp":                        resourceBigipSysIapp(),
 			"bigip_sys_ntp":                         resourceBigipSysNtp(),
@@ -82,26 +200,79 @@ func Provider() terraform.ResourceProvider {
 			"bigip_sys_snmp":                        resourceBigipSysSnmp(),
 			"bigip_sys_snmp_traps":                  resourceBigipSysSnmpTraps(),
 			"bigip_sys_bigiplicense":                resourceBigipSysBigiplicense(),
-		},
+		})),
 
 		ConfigureFunc: providerConfigure,
 	}
 }
 
 func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+	setLogLevel(d.Get("log_level").(string))
 	config := Config{
 		Address:  d.Get("address").(string),
 		Username: d.Get("username").(string),
-		Password: d.Get("password").(string),
+		ConfigOptions: &bigip.ConfigOptions{
+			APICallTimeout: time.Duration(d.Get("request_timeout").(int)) * time.Second,
+			MaxRetries:     d.Get("max_retries").(int),
+			RetryBackoff:   time.Duration(d.Get("retry_backoff").(int)) * time.Second,
+			VerifyTLS:      !d.Get("insecure_tls").(bool),
+
+			MaxIdleConnsPerHost: d.Get("max_idle_connections").(int),
+			DisableKeepAlives:   !d.Get("keep_alive").(bool),
+			Port:                d.Get("port").(int),
+			DryRun:              d.Get("dry_run").(bool),
+		},
 	}
+	if !config.ConfigOptions.VerifyTLS {
+		log.Printf("[WARN] TLS certificate verification of the BigIP is disabled, set insecure_tls = false to enable it")
+	}
+	if config.ConfigOptions.DryRun {
+		log.Printf("[WARN] Dry run, changes to the BigIP are logged and not sent")
+	}
+	config.ValidateMonitors = d.Get("validate_monitors").(bool)
+	config.Transactional = d.Get("transactional").(bool)
+	config.SerializeChanges = d.Get("serialize_changes").(bool)
+	config.LockTimeout = time.Duration(d.Get("lock_timeout").(int)) * time.Second
+	config.DefaultPartition = strings.Trim(d.Get("default_partition").(string), "/")
 	if d.Get("token_auth").(bool) {
 		config.LoginReference = d.Get("login_ref").(string)
 	}
+	token, _ := d.GetOk("token")
+	password, _ := d.GetOk("password")
+	preferExplicitCredentials(&config, token.(string), password.(string))
 
 	return config.Client()
 }
 
-//Convert slice of strings to schema.TypeSet
+// preferExplicitCredentials sets the token and the password of config from
+// the ones set in the provider block, falling back to BIGIP_TOKEN and
+// BIGIP_PASSWORD only when neither is set there, so that the provider block
+// takes precedence over the environment. Both set in the environment still
+// conflict. password and token have no DefaultFunc in the schema for this
+// reason, as GetOk could not tell a value from the environment from one set
+// in the provider block.
+func preferExplicitCredentials(config *Config, token, password string) {
+	switch {
+	case token == "" && password == "":
+		config.Token = os.Getenv("BIGIP_TOKEN")
+		config.Password = os.Getenv("BIGIP_PASSWORD")
+	case token != "":
+		if os.Getenv("BIGIP_PASSWORD") != "" {
+			log.Printf("[INFO] Using the token of the provider block instead of BIGIP_PASSWORD")
+		}
+		// token_auth conflicts with token in the block, it comes from
+		// BIGIP_TOKEN_AUTH
+		config.Token = token
+		config.LoginReference = ""
+	default:
+		if os.Getenv("BIGIP_TOKEN") != "" {
+			log.Printf("[INFO] Using the password of the provider block instead of BIGIP_TOKEN")
+		}
+		config.Password = password
+	}
+}
+
+// Convert slice of strings to schema.TypeSet
 func makeStringList(list *[]string) []interface{} {
 	ilist := make([]interface{}, len(*list))
 	for i, v := range *list {
@@ -110,7 +281,7 @@ func makeStringList(list *[]string) []interface{} {
 	return ilist
 }
 
-//Convert slice of strings to schema.Set
+// Convert slice of strings to schema.Set
 func makeStringSet(list *[]string) *schema.Set {
 	ilist := make([]interface{}, len(*list))
 	for i, v := range *list {
@@ -119,7 +290,7 @@ func makeStringSet(list *[]string) *schema.Set {
 	return schema.NewSet(schema.HashString, ilist)
 }
 
-//Convert schema.TypeList to a slice of strings
+// Convert schema.TypeList to a slice of strings
 func listToStringSlice(s []interface{}) []string {
 	list := make([]string, len(s))
 	for i, v := range s {
@@ -128,7 +299,7 @@ func listToStringSlice(s []interface{}) []string {
 	return list
 }
 
-//Convert schema.Set to a slice of strings
+// Convert schema.Set to a slice of strings
 func setToStringSlice(s *schema.Set) []string {
 	list := make([]string, s.Len())
 	for i, v := range s.List() {
@@ -137,7 +308,7 @@ func setToStringSlice(s *schema.Set) []string {
 	return list
 }
 
-//Copy map values into an object where map key == object field name (e.g. map[foo] == &{Foo: ...}
+// Copy map values into an object where map key == object field name (e.g. map[foo] == &{Foo: ...}
 func mapEntity(d map[string]interface{}, obj interface{}) {
 	val := reflect.ValueOf(obj).Elem()
 	for field := range d {
@@ -159,7 +330,33 @@ func mapEntity(d map[string]interface{}, obj interface{}) {
 	}
 }
 
-//Break a string in the format /Partition/name into a Partition / Name object
+// apiErrorDetails adds the status code and response body of a request the
+// BigIP rejected to err, they often explain why the request failed. Errors
+// without a BigIP message already contain the body.
+func apiErrorDetails(err error) error {
+	if apiErr, ok := err.(*bigip.APIError); ok && apiErr.Message != "" {
+		return fmt.Errorf("%s (HTTP %d: %s)", apiErr.Error(), apiErr.StatusCode, strings.TrimSpace(apiErr.Body))
+	}
+	return err
+}
+
+// isNotFound reports whether err is the BigIP answering that the requested
+// object does not exist, e.g. because it was deleted out of band. Answers
+// refusing access are never taken for missing objects, even when their body
+// carries a 404 code.
+func isNotFound(err error) bool {
+	apiErr, ok := err.(*bigip.APIError)
+	return ok && !bigip.IsPermissionDenied(err) && (apiErr.StatusCode == http.StatusNotFound || apiErr.Code == http.StatusNotFound)
+}
+
+// permissionDeniedError returns the error for the BigIP refusing to let the
+// user read the object of kind name in partition, which points at the roles
+// of the user rather than at the object.
+func permissionDeniedError(err error, kind, name, partition string) error {
+	return fmt.Errorf("Insufficient permissions for partition %s to read %s %s, check the partition access of the user: %v", partition, kind, name, apiErrorDetails(err))
+}
+
+// Break a string in the format /Partition/name into a Partition / Name object
 func parseF5Identifier(str string) (partition, name string) {
 	if strings.HasPrefix(str, "/") {
 		ary := strings.SplitN(strings.TrimPrefix(str, "/"), "/", 2)
@@ -167,3 +364,100 @@ func parseF5Identifier(str string) (partition, name string) {
 	}
 	return "", str
 }
+
+// resourceFullPath returns the /partition/name path of a resource with name
+// and partition arguments. A name that is already a full path is used as is,
+// otherwise it is placed in the configured partition, falling back to the
+// default_partition of the provider.
+func resourceFullPath(d *schema.ResourceData, client *bigipClient, kind string) (string, error) {
+	name := d.Get("name").(string)
+	partition := d.Get("partition").(string)
+	if strings.HasPrefix(name, "/") {
+		namePartition, _ := parseF5Identifier(name)
+		if partition != "" && partition != namePartition {
+			return "", fmt.Errorf("%s name %s is not in partition %s", kind, name, partition)
+		}
+		return name, nil
+	}
+	if partition == "" {
+		partition = client.config.partition()
+	}
+	return fmt.Sprintf("/%s/%s", partition, name), nil
+}
+
+// partitionSchema returns the partition argument of a resource whose name may
+// be a full path, kind names the object in the description, e.g. "pool".
+func partitionSchema(kind string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Computed:    true,
+		ForceNew:    true,
+		Description: fmt.Sprintf("Partition the %s is created in when name is not a full path. Defaults to the default_partition of the provider, Common unless set.", kind),
+	}
+}
+
+// partitionAndName splits a /partition/name path, placing a bare name in the
+// default_partition of the provider.
+func partitionAndName(client *bigipClient, fullName string) (partition, name string) {
+	partition, name = parseF5Identifier(fullName)
+	if partition == "" {
+		partition = client.config.partition()
+	}
+	return partition, name
+}
+
+// setNameAndPartition saves the partition, full_path and name of a resource
+// read by fullName. The name is kept in the form it was configured (bare or
+// /partition/name); imports get the full path.
+func setNameAndPartition(d *schema.ResourceData, client *bigipClient, fullName string) error {
+	partition, name := partitionAndName(client, fullName)
+	fullPath := fmt.Sprintf("/%s/%s", partition, name)
+	if err := d.Set("partition", partition); err != nil {
+		return fmt.Errorf("Error saving partition of %s to state: %v", fullPath, err)
+	}
+	d.Set("full_path", fullPath)
+	if configured := d.Get("name").(string); configured != "" && !strings.HasPrefix(configured, "/") {
+		d.Set("name", name)
+	} else {
+		d.Set("name", fullPath)
+	}
+	return nil
+}
+
+// checkTrafficGroup fails unless the traffic group name, a name or full path,
+// exists on the BigIP. Floating objects assigned to a missing traffic group
+// are rejected by the BigIP with a less helpful message.
+func checkTrafficGroup(client *bigipClient, name string) error {
+	path := name
+	if !strings.HasPrefix(path, "/") {
+		path = "/Common/" + path
+	}
+	trafficGroup, err := client.GetTrafficGroup(path)
+	if err != nil {
+		return fmt.Errorf("Error retrieving traffic group %s: %v", name, err)
+	}
+	if trafficGroup == nil {
+		return fmt.Errorf("Traffic group %s does not exist on the BigIP", path)
+	}
+	return nil
+}
+
+// deprecatedResource marks r as the deprecated name of the resource
+// replacement, both are managed the same way.
+func deprecatedResource(r *schema.Resource, replacement string) *schema.Resource {
+	r.DeprecationMessage = fmt.Sprintf("This resource is deprecated, use %s instead", replacement)
+	return r
+}
+
+// importSingleton returns the import function of a resource managing a
+// system wide setting that exists once per BigIP, imported with the fixed
+// id.
+func importSingleton(id string) schema.StateFunc {
+	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+		if d.Id() != id {
+			return nil, fmt.Errorf("There is a single %s configuration per BigIP, import it with the ID %q instead of %q", id, id, d.Id())
+		}
+		return []*schema.ResourceData{d}, nil
+	}
+}
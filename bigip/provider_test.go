@@ -1,10 +1,18 @@
 package bigip
 
 import (
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
 	"os"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 var TEST_PARTITION = "Common"
@@ -36,6 +44,9 @@ func testAcctPreCheck(t *testing.T) {
 	if os.Getenv("BIGIP_TOKEN_AUTH") != "" && os.Getenv("BIGIP_LOGIN_REF") != "" {
 		return
 	}
+	if os.Getenv("BIGIP_HOST") != "" && os.Getenv("BIGIP_TOKEN") != "" {
+		return
+	}
 	for _, s := range [...]string{"BIGIP_HOST", "BIGIP_USER", "BIGIP_PASSWORD"} {
 		if os.Getenv(s) == "" {
 			t.Fatal("Either BIGIP_TOKEN_AUTH + BIGIP_LOGIN_REF or BIGIP_USER, BIGIP_PASSWORD and BIGIP_HOST are required for tests.")
@@ -43,3 +54,205 @@ func testAcctPreCheck(t *testing.T) {
 		}
 	}
 }
+
+func TestProviderEnvironmentDefaults(t *testing.T) {
+	os.Unsetenv("BIGIP_LOGIN_REF")
+	os.Unsetenv("BIGIP_TOKEN_AUTH")
+	provider := Provider().(*schema.Provider)
+	loginRef, _ := provider.Schema["login_ref"].DefaultValue()
+	assert.Equal(t, "tmos", loginRef)
+	tokenAuth, _ := provider.Schema["token_auth"].DefaultValue()
+	assert.Equal(t, false, tokenAuth)
+
+	os.Setenv("BIGIP_LOGIN_REF", "ldap")
+	defer os.Unsetenv("BIGIP_LOGIN_REF")
+	loginRef, _ = provider.Schema["login_ref"].DefaultValue()
+	assert.Equal(t, "ldap", loginRef)
+}
+
+func TestPreferExplicitCredentials(t *testing.T) {
+	defer os.Setenv("BIGIP_TOKEN", os.Getenv("BIGIP_TOKEN"))
+	defer os.Setenv("BIGIP_PASSWORD", os.Getenv("BIGIP_PASSWORD"))
+	os.Setenv("BIGIP_TOKEN", "env-token")
+	os.Setenv("BIGIP_PASSWORD", "env-password")
+
+	// A password in the provider block takes precedence over BIGIP_TOKEN
+	config := &Config{}
+	preferExplicitCredentials(config, "", "password")
+	assert.Equal(t, "", config.Token)
+	assert.Equal(t, "password", config.Password)
+
+	// A token in the provider block takes precedence over BIGIP_PASSWORD
+	config = &Config{LoginReference: "tmos"}
+	preferExplicitCredentials(config, "token", "")
+	assert.Equal(t, "token", config.Token)
+	assert.Equal(t, "", config.Password)
+	assert.Equal(t, "", config.LoginReference)
+
+	// Values equal to the environment are still the ones of the block
+	config = &Config{}
+	preferExplicitCredentials(config, "env-token", "")
+	assert.Equal(t, "env-token", config.Token)
+	assert.Equal(t, "", config.Password)
+
+	// Both set in the environment still conflict
+	config = &Config{}
+	preferExplicitCredentials(config, "", "")
+	assert.Equal(t, "env-token", config.Token)
+	assert.Equal(t, "env-password", config.Password)
+}
+
+func TestDryRunLogsChanges(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests []string
+	mux.HandleFunc("/mgmt/tm/ltm/node/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		fmt.Fprintf(w, `{"name":"node1","address":"10.0.0.1"}`)
+	})
+
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	client := bigip.NewSession(server.URL, "admin", "admin", &bigip.ConfigOptions{DryRun: true})
+	assert.Nil(t, client.AddNode(&bigip.Node{Name: "/Common/node1", Address: "10.0.0.1"}))
+	assert.Nil(t, client.DeleteNode("/Common/node1"))
+	node, err := client.GetNode("/Common/node1")
+	assert.Nil(t, err)
+	assert.Equal(t, "10.0.0.1", node.Address)
+
+	// Only the read is sent
+	assert.Equal(t, []string{"GET"}, requests)
+	assert.Contains(t, buf.String(), fmt.Sprintf(`[INFO] Dry run, not sent: POST %s/mgmt/tm/ltm/node {"name":"/Common/node1","address":"10.0.0.1"`, server.URL))
+	assert.Contains(t, buf.String(), fmt.Sprintf(`[INFO] Dry run, not sent: DELETE %s/mgmt/tm/ltm/node/~Common~node1`, server.URL))
+}
+
+func TestAccDryRunSendsNoChanges(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node (/Common/node1) was not found."}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method)
+		fmt.Fprintf(w, `{}`)
+	})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_node" "test-node" {
+						name = "/Common/node1"
+						address = "10.0.0.1"
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+						dry_run = true
+					}
+				`, server.URL),
+				// The node is read back as planned, the next run plans to
+				// create it again
+				Check:              resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "address", "10.0.0.1"),
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+	assert.NotEmpty(t, requests)
+	for _, method := range requests {
+		assert.Equal(t, "GET", method)
+	}
+}
+
+func TestDryRunReadsCreatedObjects(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests []string
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~pool1/members/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Pool Member was not found."}`)
+	})
+
+	client := bigip.NewSession(server.URL, "admin", "admin", &bigip.ConfigOptions{DryRun: true})
+	assert.Nil(t, client.AddPoolMember("/Common/pool1", "/Common/node1:80"))
+
+	// Other objects of the collection are still read from the BIG-IP
+	member, err := client.GetPoolMember("/Common/pool1", "/Common/node2:80")
+	assert.Nil(t, err)
+	assert.Nil(t, member)
+	assert.Equal(t, []string{"GET /mgmt/tm/ltm/pool/~Common~pool1/members/~Common~node2:80"}, requests)
+
+	// The created object is read as planned, with its modifications
+	assert.Nil(t, client.PatchPoolMember("/Common/pool1", &bigip.PoolMember{FullPath: "/Common/node1:80", Ratio: 3}))
+	member, err = client.GetPoolMember("/Common/pool1", "/Common/node1:80")
+	assert.Nil(t, err)
+	if assert.NotNil(t, member) {
+		assert.Equal(t, "/Common/node1:80", member.FullPath)
+		assert.Equal(t, 3, member.Ratio)
+	}
+	assert.Len(t, requests, 1)
+
+	// Once deleted, it is read from the BIG-IP again
+	assert.Nil(t, client.DeletePoolMember("/Common/pool1", "/Common/node1:80"))
+	member, err = client.GetPoolMember("/Common/pool1", "/Common/node1:80")
+	assert.Nil(t, err)
+	assert.Nil(t, member)
+	assert.Len(t, requests, 2)
+}
+
+func TestDryRunRefusesResponses(t *testing.T) {
+	setup()
+	defer teardown()
+	var requests []string
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		fmt.Fprintf(w, `{}`)
+	})
+
+	client := bigip.NewSession(server.URL, "admin", "admin", &bigip.ConfigOptions{DryRun: true})
+	_, err := client.RunBashCommand("tmsh list ltm node")
+	assert.EqualError(t, err, fmt.Sprintf("Dry run, not sent: POST %s/mgmt/tm/util/bash returns a response a dry run can not fake", server.URL))
+	_, err = client.BeginTransaction()
+	assert.EqualError(t, err, fmt.Sprintf("Dry run, not sent: POST %s/mgmt/tm/transaction returns a response a dry run can not fake", server.URL))
+
+	// Transactions are skipped, their requests are logged one by one
+	assert.Nil(t, withTransaction(&bigipClient{client, &Config{Transactional: true}}, func(client *bigipClient) error {
+		return client.AddMonitor(&bigip.Monitor{Name: "test-monitor", ParentMonitor: "http"})
+	}))
+	assert.Empty(t, requests)
+}
+
+func TestSetNameAndPartition(t *testing.T) {
+	client := &bigipClient{bigip.NewSession("http://localhost", "admin", "admin", nil), &Config{DefaultPartition: "Tenant"}}
+	schemaMap := map[string]*schema.Schema{
+		"name":      {Type: schema.TypeString, Required: true},
+		"partition": partitionSchema("pool"),
+		"full_path": {Type: schema.TypeString, Computed: true},
+	}
+
+	// A bare name is kept bare
+	d := schema.TestResourceDataRaw(t, schemaMap, map[string]interface{}{"name": "web"})
+	assert.Nil(t, setNameAndPartition(d, client, "/Other/web"))
+	assert.Equal(t, "web", d.Get("name"))
+	assert.Equal(t, "Other", d.Get("partition"))
+	assert.Equal(t, "/Other/web", d.Get("full_path"))
+
+	// Imports and full path names get the full path, bare IDs the default partition
+	d = schema.TestResourceDataRaw(t, schemaMap, map[string]interface{}{})
+	assert.Nil(t, setNameAndPartition(d, client, "web"))
+	assert.Equal(t, "/Tenant/web", d.Get("name"))
+	assert.Equal(t, "Tenant", d.Get("partition"))
+	assert.Equal(t, "/Tenant/web", d.Get("full_path"))
+}
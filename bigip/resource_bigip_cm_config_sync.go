@@ -0,0 +1,113 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceBigipCmConfigSync syncs the configuration of the BigIP to the other
+// devices of a device group when it is created. A sync is a one-off action
+// with no object left on the BigIP to refresh, so changing triggers, e.g. to
+// the ids of the resources just changed, is what syncs again.
+func resourceBigipCmConfigSync() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipCmConfigSyncCreate,
+		Read:   resourceBigipCmConfigSyncRead,
+		Delete: resourceBigipCmConfigSyncDelete,
+
+		Schema: map[string]*schema.Schema{
+			"device_group": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Device group the configuration is synced to",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that sync the configuration again when they change",
+			},
+			"wait_for_sync": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Wait until the devices of the device group are in sync",
+			},
+			"wait_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     300,
+				Description: "Seconds to wait for the devices to be in sync when wait_for_sync is set, the sync fails once it elapses",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Sync status of the device group after the sync, e.g. In Sync, when wait_for_sync is set",
+			},
+		},
+	}
+}
+
+func resourceBigipCmConfigSyncCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	group := d.Get("device_group").(string)
+	log.Printf("[INFO] Syncing the configuration to device group %s", group)
+
+	if err := client.ConfigSync(group); err != nil {
+		return fmt.Errorf("Error syncing the configuration to device group %s: %v", group, apiErrorDetails(err))
+	}
+
+	if d.Get("wait_for_sync").(bool) {
+		status, err := waitForConfigSync(client, group, time.Duration(d.Get("wait_timeout").(int))*time.Second)
+		if err != nil {
+			return fmt.Errorf("Error syncing the configuration to device group %s: %v", group, err)
+		}
+		d.Set("status", status)
+	}
+
+	d.SetId(resource.PrefixedUniqueId("config-sync-"))
+	return resourceBigipCmConfigSyncRead(d, meta)
+}
+
+func resourceBigipCmConfigSyncRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceBigipCmConfigSyncDelete(d *schema.ResourceData, meta interface{}) error {
+	// A sync cannot be undone, forget it
+	d.SetId("")
+	return nil
+}
+
+// waitForConfigSync waits until the device group is in sync and returns its
+// status, or returns an error once the timeout elapses. The status of the
+// device group is taken from the details of the sync status of the BigIP,
+// its overall status when the details do not mention the device group.
+func waitForConfigSync(client *bigipClient, group string, timeout time.Duration) (string, error) {
+	_, name := parseF5Identifier(group)
+	var status string
+	err := waitForCondition(2*time.Second, timeout, func() (bool, error) {
+		s, err := client.GetSyncStatus()
+		if err != nil {
+			return false, fmt.Errorf("Error retrieving the sync status: %v", apiErrorDetails(err))
+		}
+		status = s.DeviceGroupStatus(name)
+		if status == "" {
+			status = s.Status
+		}
+		log.Printf("[DEBUG] Sync status of device group %s: %s", group, status)
+		return status == "In Sync", nil
+	})
+	if err == errWaitTimeout {
+		return status, fmt.Errorf("devices not in sync within %s, status %q", timeout, status)
+	}
+	return status, err
+}
@@ -0,0 +1,126 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipCmSyncStatus returns the sync status of a BigIP whose device group
+// failover has the status, and whose device group sync-only is never in sync.
+func testBigipCmSyncStatus(status string) string {
+	return fmt.Sprintf(`{"entries":{"https://localhost/mgmt/tm/cm/sync-status/0":{"nestedStats":{"entries":{
+		"color":{"description":"blue"},
+		"mode":{"description":"high-availability"},
+		"status":{"description":"Changes Pending"},
+		"summary":{"description":"There is a possible change conflict"},
+		"https://localhost/mgmt/tm/cm/syncStatus/0/details":{"nestedStats":{"entries":{
+			"https://localhost/mgmt/tm/cm/syncStatus/0/details/0":{"nestedStats":{"entries":{"details":{"description":"bigip2.example.com: connected (for 3600 seconds)"}}}},
+			"https://localhost/mgmt/tm/cm/syncStatus/0/details/1":{"nestedStats":{"entries":{"details":{"description":"failover (%s): All devices in the device group are in sync"}}}},
+			"https://localhost/mgmt/tm/cm/syncStatus/0/details/2":{"nestedStats":{"entries":{"details":{"description":"sync-only (Changes Pending): There is a possible change conflict"}}}}
+		}}}
+	}}}}}`, status)
+}
+
+func testBigipCmConfigSyncConfig(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_cm_config_sync" "failover" {
+			device_group = "failover"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+func TestAccBigipCmConfigSync(t *testing.T) {
+	setup()
+	var commands []string
+	statuses := []string{"Syncing", "Syncing", "In Sync"}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/cm", func(w http.ResponseWriter, r *http.Request) {
+		command := map[string]string{}
+		json.NewDecoder(r.Body).Decode(&command)
+		commands = append(commands, command["command"]+" "+command["utilCmdArgs"])
+		fmt.Fprintf(w, `{"kind":"tm:cm:runstate","command":"run","utilCmdArgs":"%s"}`, command["utilCmdArgs"])
+	})
+	mux.HandleFunc("/mgmt/tm/cm/sync-status", func(w http.ResponseWriter, r *http.Request) {
+		status := statuses[0]
+		if len(statuses) > 1 {
+			statuses = statuses[1:]
+		}
+		fmt.Fprint(w, testBigipCmSyncStatus(status))
+	})
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testBigipCmConfigSyncConfig(server.URL, `
+						wait_for_sync = true
+						triggers = { run = "1" }
+					`),
+					Check: resource.TestCheckResourceAttr("bigip_cm_config_sync.failover", "status", "In Sync"),
+				},
+				{
+					Config: testBigipCmConfigSyncConfig(server.URL, `
+						wait_for_sync = true
+						triggers = { run = "1" }
+					`),
+					PlanOnly: true,
+				},
+				{
+					Config: testBigipCmConfigSyncConfig(server.URL, `
+						wait_for_sync = true
+						triggers = { run = "2" }
+					`),
+				},
+			},
+		})
+		assert.Equal(t, []time.Duration{2 * time.Second, 4 * time.Second}, c.sleeps)
+	})
+	command := "run config-sync to-group failover"
+	assert.Equal(t, []string{command, command}, commands)
+}
+
+func TestAccBigipCmConfigSyncTimeout(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/cm", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/sync-status", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testBigipCmSyncStatus("Changes Pending"))
+	})
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testBigipCmConfigSyncConfig(server.URL, `
+						wait_for_sync = true
+						wait_timeout = 60
+					`),
+					ExpectError: regexp.MustCompile(`devices not in sync within 1m0s, status "Changes Pending"`),
+				},
+			},
+		})
+	})
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipCmDevice() *schema.Resource {
@@ -28,44 +28,93 @@ func resourceBigipCmDevice() *schema.Resource {
 			"name": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Address of the Device which needs to be Deviceensed",
+				ForceNew:    true,
+				Description: "Name of the device, e.g. bigip1.example.com",
 			},
 
 			"mirror_ip": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "IP address used for state mirroring",
 			},
 			"mirror_secondary_ip": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "Secondary IP address used for state mirroring",
 			},
+			"unicast_address": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "Addresses the device exchanges network failover heartbeats with the other devices of its device groups on",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Address of a self IP of the device",
+						},
+						"port": {
+							Type:         schema.TypeInt,
+							Optional:     true,
+							Default:      1026,
+							Description:  "UDP port of the heartbeats",
+							ValidateFunc: validateIntRange(1, 65535),
+						},
+					},
+				},
+			},
 		},
 	}
 
 }
 
-func resourceBigipCmDeviceCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+func dataToDevice(name string, d *schema.ResourceData) *bigip.Device {
+	r := &bigip.Device{
+		Name:              name,
+		ConfigsyncIp:      d.Get("configsync_ip").(string),
+		MirrorIp:          d.Get("mirror_ip").(string),
+		MirrorSecondaryIp: d.Get("mirror_secondary_ip").(string),
+	}
+	for _, a := range d.Get("unicast_address").([]interface{}) {
+		address := a.(map[string]interface{})
+		r.UnicastAddress = append(r.UnicastAddress, bigip.DeviceUnicastAddress{
+			Ip:   address["ip"].(string),
+			Port: address["port"].(int),
+		})
+	}
+	return r
+}
 
-	configsyncIp := d.Get("configsync_ip").(string)
+// resourceBigipCmDeviceCreate configures the device when it exists, like
+// the device of the BigIP itself, and creates it otherwise.
+func resourceBigipCmDeviceCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
 	name := d.Get("name").(string)
-	mirrorIp := d.Get("mirror_ip").(string)
-	mirrorSecondaryIp := d.Get("mirror_secondary_ip").(string)
-
-	log.Println("[INFO] Creating Device ")
-
-	err := client.CreateDevice(
-		name,
-		configsyncIp,
-		mirrorIp,
-		mirrorSecondaryIp,
-	)
 
+	existing, err := client.Devices(name)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Create Device %s %v ", name, err)
-		return err
+		return fmt.Errorf("Error retrieving device (%s): %v", name, apiErrorDetails(err))
+	}
+	if existing != nil {
+		log.Println("[INFO] Configuring existing Device " + name)
+		err = client.ModifyDevice(dataToDevice(name, d))
+	} else {
+		log.Println("[INFO] Creating Device " + name)
+		err = client.CreateDevice(
+			name,
+			d.Get("configsync_ip").(string),
+			d.Get("mirror_ip").(string),
+			d.Get("mirror_secondary_ip").(string),
+		)
+		if err == nil && len(d.Get("unicast_address").([]interface{})) > 0 {
+			err = client.ModifyDevice(dataToDevice(name, d))
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("Error creating device (%s): %v", name, apiErrorDetails(err))
 	}
 	d.SetId(name)
 	return resourceBigipCmDeviceRead(d, meta)
@@ -73,29 +122,21 @@ func resourceBigipCmDeviceCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipCmDeviceUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	log.Println("[INFO] Updating Device " + name)
 
-	r := &bigip.Device{
-		Name:              name,
-		ConfigsyncIp:      d.Get("configsync_ip").(string),
-		MirrorIp:          d.Get("mirror_ip").(string),
-		MirrorSecondaryIp: d.Get("mirror_secondary_ip").(string),
-	}
-
-	err := client.ModifyDevice(r)
+	err := client.ModifyDevice(dataToDevice(name, d))
 	if err != nil {
-		log.Printf("[ERROR] Unable to Modidy Device (%s) (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error modifying device (%s): %v", name, apiErrorDetails(err))
 	}
 	return resourceBigipCmDeviceRead(d, meta)
 }
 
 func resourceBigipCmDeviceRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -126,16 +167,38 @@ func resourceBigipCmDeviceRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("[DEBUG] Error saving mirror_secondary_ip  to state for Device (%s): %s", d.Id(), err)
 	}
 
+	var addresses []interface{}
+	for _, a := range members.UnicastAddress {
+		addresses = append(addresses, map[string]interface{}{
+			"ip":   a.Ip,
+			"port": a.Port,
+		})
+	}
+	if err := d.Set("unicast_address", addresses); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving unicast_address  to state for Device (%s): %s", d.Id(), err)
+	}
+
 	return nil
 }
 
+// resourceBigipCmDeviceDelete deletes the device, except the device of the
+// BigIP itself, which cannot be deleted and is only removed from the state.
 func resourceBigipCmDeviceDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
-	err := client.DeleteDevice(name)
+	device, err := client.Devices(name)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Delete Device (%s)  (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error retrieving device (%s): %v", name, apiErrorDetails(err))
+	}
+	if device != nil && device.SelfDevice == "true" {
+		log.Printf("[WARN] Device (%s) is the BigIP itself and is not deleted, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+	if device != nil {
+		if err := client.DeleteDevice(name); err != nil {
+			return fmt.Errorf("Error deleting device (%s): %v", name, apiErrorDetails(err))
+		}
 	}
 	d.SetId("")
 	return nil
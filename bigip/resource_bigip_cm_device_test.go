@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -67,7 +66,7 @@ func TestAccBigipCmDevice_import(t *testing.T) {
 
 func testCheckdeviceExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		device, err := client.Devices(name)
 		if err != nil {
@@ -84,7 +83,7 @@ func testCheckdeviceExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckdevicesDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_cm_device" {
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipCmDevicegroup() *schema.Resource {
@@ -22,13 +22,15 @@ func resourceBigipCmDevicegroup() *schema.Resource {
 
 			"name": {
 				Type:        schema.TypeString,
-				Optional:    true,
+				Required:    true,
+				ForceNew:    true,
 				Description: "Name of the Device group",
 			},
 
 			"partition": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "Device administrative partition",
 			},
 
@@ -39,35 +41,41 @@ func resourceBigipCmDevicegroup() *schema.Resource {
 			},
 
 			"auto_sync": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "disabled",
-				Description: "Specifies if the device-group will automatically sync configuration data to its members",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				Description:  "Specifies if the device-group will automatically sync configuration data to its members",
+				ValidateFunc: validateEnabledDisabled,
 			},
 			"type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "sync-only",
-				Description: "Specifies if the device-group will be used for failover or resource syncing",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				Default:      "sync-only",
+				Description:  "Specifies if the device-group will be used for failover or resource syncing",
+				ValidateFunc: validateStringValue([]string{"sync-failover", "sync-only"}),
 			},
 			"full_load_on_sync": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "false",
-				Description: "Specifies if the device-group will perform a full-load upon sync",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "false",
+				Description:  "Specifies if the device-group will perform a full-load upon sync",
+				ValidateFunc: validateStringValue([]string{"true", "false"}),
 			},
 			"save_on_auto_sync": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "false",
-				Description: "Specifies whether the configuration should be saved upon auto-sync.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "false",
+				Description:  "Specifies whether the configuration should be saved upon auto-sync.",
+				ValidateFunc: validateStringValue([]string{"true", "false"}),
 			},
 
 			"network_failover": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "enabled",
-				Description: "Specifies if the device-group will use a network connection for failover",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "enabled",
+				Description:  "Specifies if the device-group will use a network connection for failover",
+				ValidateFunc: validateEnabledDisabled,
 			},
 
 			"incremental_config": {
@@ -77,19 +85,20 @@ func resourceBigipCmDevicegroup() *schema.Resource {
 				Description: "Specifies the maximum size (in KB) to devote to incremental config sync cached transactions. The default is 1024 KB.",
 			},
 			"device": {
-				Type:     schema.TypeList,
-				Optional: true,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Devices of the device group",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"set_sync_leader": {
 							Type:        schema.TypeBool,
 							Optional:    true,
-							Description: "Name of origin",
+							Description: "Whether the device is the sync leader when the device group is created",
 						},
 						"name": {
 							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Name of origin",
+							Required:    true,
+							Description: "Name of the device, e.g. bigip1.example.com or /Common/bigip1.example.com",
 						},
 					},
 				},
@@ -99,52 +108,65 @@ func resourceBigipCmDevicegroup() *schema.Resource {
 }
 
 func resourceBigipCmDevicegroupCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Get("name").(string)
-	log.Println("[INFO] Creating Device Group" + name)
+	log.Println("[INFO] Creating Devicegroup " + name)
 
 	p := dataToDevicegroup(name, d)
-	d.SetId(name)
 	err := client.CreateDevicegroup(&p)
-
-	log.Println("[INFO] Creating Devicegroup ")
-
 	if err != nil {
-		log.Printf("[ERROR] Unable to Create Devicegroup (%s) (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error creating device group (%s): %v", name, apiErrorDetails(err))
 	}
 	d.SetId(name)
 	return resourceBigipCmDevicegroupRead(d, meta)
 }
 
+// resourceBigipCmDevicegroupUpdate modifies the settings of the device
+// group, then adds and removes its devices one by one, the BigIP does not
+// replace the devices of a device group.
 func resourceBigipCmDevicegroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	log.Println("[INFO] Updating Devicegroup " + name)
 	p := dataToDevicegroup(name, d)
+	devices := p.Deviceb
+	p.Deviceb = nil
 	err := client.UpdateDevicegroup(name, &p)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Update Devicegroup (%s) (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error modifying device group (%s): %v", name, apiErrorDetails(err))
+	}
+
+	if d.HasChange("device") {
+		current, err := client.DevicegroupDevices(name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving devices of device group (%s): %v", name, apiErrorDetails(err))
+		}
+		for _, c := range current {
+			if devicegroupDeviceIndex(devices, c) < 0 {
+				log.Printf("[INFO] Removing device %s from Devicegroup %s", c.Name, name)
+				if err := client.DeleteDevicegroupDevices(name, devicegroupDevicePath(c)); err != nil {
+					return fmt.Errorf("Error removing device %s from device group (%s): %v", c.Name, name, apiErrorDetails(err))
+				}
+			}
+		}
+		for _, r := range devices {
+			if devicegroupDeviceIndex(current, r) < 0 {
+				log.Printf("[INFO] Adding device %s to Devicegroup %s", r.Name, name)
+				if err := client.AddDevicegroupDevice(name, r); err != nil {
+					return fmt.Errorf("Error adding device %s to device group (%s): %v", r.Name, name, apiErrorDetails(err))
+				}
+			}
+		}
 	}
 	return resourceBigipCmDevicegroupRead(d, meta)
 }
 
 func resourceBigipCmDevicegroupRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	log.Println("[INFO] Reading Devicegroup " + name)
-	deviceCount := d.Get("device.#").(int)
-	for i := 0; i < deviceCount; i++ {
-		var r bigip.Devicerecord
-		prefix := fmt.Sprintf("device.%d", i)
-		r.Name = d.Get(prefix + ".name").(string)
-		Rname := r.Name
-		client.DevicegroupsDevices(name, Rname)
-	}
-
 	p, err := client.Devicegroups(name)
 	if err != nil {
 		log.Printf("[ERROR] Unable to retrive Devicegroup (%s) (%v) ", name, err)
@@ -156,49 +178,56 @@ func resourceBigipCmDevicegroupRead(d *schema.ResourceData, meta interface{}) er
 		d.SetId("")
 		return nil
 	}
-	d.Set("name", p.Name)
-	d.Set("description", p.Description)
-	if err := d.Set("auto_sync", p.AutoSync); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving AutoSync  to state for Devicegroup (%s): %s", d.Id(), err)
-	}
-
-	if err := d.Set("type", p.Type); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving Type  to state for Devicegroup (%s): %s", d.Id(), err)
+	p.Deviceb, err = client.DevicegroupDevices(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving devices of device group (%s): %v", name, apiErrorDetails(err))
 	}
-	d.Set("fullLoadOnSync", p.FullLoadOnSync)
-	d.Set("saveOnAutoSync", p.SaveOnAutoSync)
-	d.Set("incrementalConfigSyncSizeMax", p.IncrementalConfigSyncSizeMax)
-	d.Set("networkFailover", p.NetworkFailover)
-	return nil
-
+	return DevicegroupToData(p, d)
 }
 
 func resourceBigipCmDevicegroupDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
-	deviceCount := d.Get("device.#").(int)
-	for i := 0; i < deviceCount; i++ {
-		var r bigip.Devicerecord
-		prefix := fmt.Sprintf("device.%d", i)
-		r.Name = d.Get(prefix + ".name").(string)
-		Rname := r.Name
-		err := client.DeleteDevicegroupDevices(name, Rname)
+	devices, err := client.DevicegroupDevices(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving devices of device group (%s): %v", name, apiErrorDetails(err))
+	}
+	for _, r := range devices {
+		err := client.DeleteDevicegroupDevices(name, devicegroupDevicePath(r))
 		if err != nil {
-			log.Printf("[ERROR] Unable to Delete Deviceg (%s)  (%v) ", Rname, err)
-			return err
+			return fmt.Errorf("Error removing device %s from device group (%s): %v", r.Name, name, apiErrorDetails(err))
 		}
-
 	}
 
-	err := client.DeleteDevicegroup(name)
+	err = client.DeleteDevicegroup(name)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Delete Devicegroup (%s)  (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error deleting device group (%s): %v", name, apiErrorDetails(err))
 	}
 	d.SetId("")
 	return nil
 }
 
+// devicegroupDevicePath returns the path of a device of a device group,
+// the full path when the BigIP reported it.
+func devicegroupDevicePath(r bigip.Devicerecord) string {
+	if r.FullPath != "" {
+		return r.FullPath
+	}
+	return r.Name
+}
+
+// devicegroupDeviceIndex returns the index of the device r in devices, a
+// device configured by name matches the same device reported by full path.
+func devicegroupDeviceIndex(devices []bigip.Devicerecord, r bigip.Devicerecord) int {
+	_, name := parseF5Identifier(devicegroupDevicePath(r))
+	for i, device := range devices {
+		if _, n := parseF5Identifier(devicegroupDevicePath(device)); n == name {
+			return i
+		}
+	}
+	return -1
+}
+
 func dataToDevicegroup(name string, d *schema.ResourceData) bigip.Devicegroup {
 	var p bigip.Devicegroup
 
@@ -215,15 +244,17 @@ func dataToDevicegroup(name string, d *schema.ResourceData) bigip.Devicegroup {
 	p.Deviceb = make([]bigip.Devicerecord, 0, deviceCount)
 	for i := 0; i < deviceCount; i++ {
 		var r bigip.Devicerecord
-		log.Println("I am in dattodevicegroup policy ", p, deviceCount, i)
 		prefix := fmt.Sprintf("device.%d", i)
 		r.Name = d.Get(prefix + ".name").(string)
+		r.SetSyncLeader = d.Get(prefix + ".set_sync_leader").(bool)
 		p.Deviceb = append(p.Deviceb, r)
 	}
 
 	return p
 }
 
+// DevicegroupToData saves the device group to the state, its devices in the
+// order they are configured followed by devices added outside Terraform.
 func DevicegroupToData(p *bigip.Devicegroup, d *schema.ResourceData) error {
 	d.Set("name", p.Name)
 	d.Set("partition", p.Partition)
@@ -235,12 +266,24 @@ func DevicegroupToData(p *bigip.Devicegroup, d *schema.ResourceData) error {
 	d.Set("network_failover", p.NetworkFailover)
 	d.Set("incremental_config", p.IncrementalConfigSyncSizeMax)
 
-	for i, r := range p.Deviceb {
-		device := fmt.Sprintf("device.%d", i)
-
-		d.Set(fmt.Sprintf("%s.name", device), r.Name)
-		d.Set(fmt.Sprintf("%s.set_sync_leader", device), r.SetSyncLeader)
-
+	var devices []interface{}
+	reported := append([]bigip.Devicerecord{}, p.Deviceb...)
+	for _, c := range d.Get("device").([]interface{}) {
+		configured := c.(map[string]interface{})
+		r := bigip.Devicerecord{Name: configured["name"].(string)}
+		if i := devicegroupDeviceIndex(reported, r); i >= 0 {
+			devices = append(devices, configured)
+			reported = append(reported[:i], reported[i+1:]...)
+		}
+	}
+	for _, r := range reported {
+		devices = append(devices, map[string]interface{}{
+			"name":            devicegroupDevicePath(r),
+			"set_sync_leader": false,
+		})
+	}
+	if err := d.Set("device", devices); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving devices to state for Devicegroup (%s): %s", d.Id(), err)
 	}
 	return nil
 }
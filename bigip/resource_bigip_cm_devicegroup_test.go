@@ -2,7 +2,6 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"log"
@@ -89,7 +88,7 @@ func TestAccBigipCmDevicegroup_import(t *testing.T) {
 
 func testCheckCmDevicegroupExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		devicegroup, err := client.Devicegroups(name)
 		if err != nil {
@@ -109,7 +108,7 @@ func testCheckCmDevicegroupExists(name string, exists bool) resource.TestCheckFu
 }
 
 func testCheckCmDevicegroupsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_cm_devicegroup" {
@@ -0,0 +1,252 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipCmServer mocks the devices and device groups of a BigIP whose own
+// device is bigip1.example.com, the requests changing them are recorded in
+// requests.
+func testBigipCmServer(requests *[]string) {
+	devices := map[string]map[string]interface{}{
+		"bigip1.example.com": {"name": "bigip1.example.com", "fullPath": "/Common/bigip1.example.com", "selfDevice": "true", "configsyncIp": "none", "mirrorIp": "any6", "mirrorSecondaryIp": "any6"},
+		"bigip2.example.com": {"name": "bigip2.example.com", "fullPath": "/Common/bigip2.example.com", "selfDevice": "false", "configsyncIp": "10.2.2.2", "mirrorIp": "any6", "mirrorSecondaryIp": "any6"},
+	}
+	groups := map[string]map[string]interface{}{}
+	members := map[string][]string{}
+	record := func(r *http.Request, body map[string]interface{}) {
+		b, _ := json.Marshal(body)
+		*requests = append(*requests, fmt.Sprintf("%s %s %s", r.Method, r.URL.Path, b))
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/device/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/cm/device/")
+		device, ok := devices[name]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested device (/Common/%s) was not found."}`, name)
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			record(r, body)
+			for k, v := range body {
+				device[k] = v
+			}
+		case "DELETE":
+			record(r, nil)
+			delete(devices, name)
+		}
+		json.NewEncoder(w).Encode(device)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/device-group", func(w http.ResponseWriter, r *http.Request) {
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		record(r, body)
+		name := body["name"].(string)
+		if devices, ok := body["devicesReference"].(map[string]interface{}); ok {
+			for _, d := range devices["items"].([]interface{}) {
+				_, device := parseF5Identifier(d.(map[string]interface{})["name"].(string))
+				members[name] = append(members[name], device)
+			}
+			delete(body, "devicesReference")
+		}
+		body["partition"] = "Common"
+		groups[name] = body
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/device-group/", func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Split(strings.TrimPrefix(r.URL.Path, "/mgmt/tm/cm/device-group/"), "/")
+		group, ok := groups[path[0]]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested device group (/Common/%s) was not found."}`, path[0])
+			return
+		}
+		if len(path) > 1 {
+			switch {
+			case r.Method == "POST":
+				body := map[string]interface{}{}
+				json.NewDecoder(r.Body).Decode(&body)
+				record(r, body)
+				_, device := parseF5Identifier(body["name"].(string))
+				members[path[0]] = append(members[path[0]], device)
+			case r.Method == "DELETE":
+				record(r, nil)
+				var kept []string
+				for _, m := range members[path[0]] {
+					if "~Common~"+m != path[2] {
+						kept = append(kept, m)
+					}
+				}
+				members[path[0]] = kept
+			}
+			var items []map[string]interface{}
+			for _, m := range members[path[0]] {
+				items = append(items, map[string]interface{}{"name": m, "partition": "Common", "fullPath": "/Common/" + m})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+			return
+		}
+		switch r.Method {
+		case "PATCH":
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			record(r, body)
+			for k, v := range body {
+				group[k] = v
+			}
+		case "DELETE":
+			record(r, nil)
+			delete(groups, path[0])
+		}
+		json.NewEncoder(w).Encode(group)
+	})
+}
+
+func testBigipCmConfig(url, resources string) string {
+	return fmt.Sprintf(`
+		%s
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, resources, url)
+}
+
+func TestAccBigipCmDeviceGroupMembers(t *testing.T) {
+	setup()
+	var requests []string
+	testBigipCmServer(&requests)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipCmConfig(server.URL, `
+					resource "bigip_cm_device_group" "failover" {
+						name = "failover"
+						type = "sync-failover"
+						auto_sync = "enabled"
+						device { name = "bigip1.example.com" }
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "id", "failover"),
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "type", "sync-failover"),
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "auto_sync", "enabled"),
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "network_failover", "enabled"),
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "device.#", "1"),
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "device.0.name", "bigip1.example.com"),
+				),
+			},
+			{
+				Config: testBigipCmConfig(server.URL, `
+					resource "bigip_cm_device_group" "failover" {
+						name = "failover"
+						type = "sync-failover"
+						auto_sync = "enabled"
+						device { name = "/Common/bigip2.example.com" }
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "device.#", "1"),
+					resource.TestCheckResourceAttr("bigip_cm_device_group.failover", "device.0.name", "/Common/bigip2.example.com"),
+				),
+			},
+			{
+				Config: testBigipCmConfig(server.URL, `
+					resource "bigip_cm_device_group" "failover" {
+						name = "failover"
+						type = "sync-failover"
+						auto_sync = "enabled"
+						device { name = "/Common/bigip2.example.com" }
+					}
+				`),
+				ResourceName:            "bigip_cm_device_group.failover",
+				ImportState:             true,
+				ImportStateId:           "failover",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"device"},
+			},
+		},
+	})
+	assert.Contains(t, requests, `POST /mgmt/tm/cm/device-group/failover/devices {"name":"/Common/bigip2.example.com","setSyncLeader":false}`)
+	assert.Contains(t, requests, `DELETE /mgmt/tm/cm/device-group/failover/devices/~Common~bigip1.example.com null`)
+	for _, r := range requests {
+		if strings.HasPrefix(r, "PATCH /mgmt/tm/cm/device-group/") {
+			assert.NotContains(t, r, "devicesReference", "modifying the group must keep its devices")
+		}
+	}
+}
+
+func TestAccBigipCmDeviceGroupInvalidType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipCmConfig("https://127.0.0.1", `
+					resource "bigip_cm_device_group" "failover" {
+						name = "failover"
+						type = "failover"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`"type" must be one of \[sync-failover sync-only\]`),
+			},
+		},
+	})
+}
+
+func TestAccBigipCmDeviceUnicastAddress(t *testing.T) {
+	setup()
+	var requests []string
+	testBigipCmServer(&requests)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipCmConfig(server.URL, `
+					resource "bigip_cm_device" "bigip1" {
+						name = "bigip1.example.com"
+						configsync_ip = "10.1.1.1"
+						unicast_address { ip = "10.1.1.1" }
+						unicast_address {
+							ip = "192.168.1.1"
+							port = 1027
+						}
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_cm_device.bigip1", "configsync_ip", "10.1.1.1"),
+					resource.TestCheckResourceAttr("bigip_cm_device.bigip1", "mirror_ip", "any6"),
+					resource.TestCheckResourceAttr("bigip_cm_device.bigip1", "unicast_address.#", "2"),
+					resource.TestCheckResourceAttr("bigip_cm_device.bigip1", "unicast_address.0.port", "1026"),
+					resource.TestCheckResourceAttr("bigip_cm_device.bigip1", "unicast_address.1.ip", "192.168.1.1"),
+					resource.TestCheckResourceAttr("bigip_cm_device.bigip1", "unicast_address.1.port", "1027"),
+				),
+			},
+		},
+	})
+	// The device of the BigIP itself is configured, not created, and is
+	// kept when destroyed
+	assert.Equal(t, []string{
+		`PATCH /mgmt/tm/cm/device/bigip1.example.com {"configsyncIp":"10.1.1.1","name":"bigip1.example.com","unicastAddress":[{"ip":"10.1.1.1","port":1026},{"ip":"192.168.1.1","port":1027}]}`,
+	}, requests)
+}
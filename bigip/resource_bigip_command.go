@@ -0,0 +1,66 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// resourceBigipCommand runs a tmsh command when it is created. Nothing is
+// read back from the BigIP, the command is run again only when it or its
+// triggers change.
+func resourceBigipCommand() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipCommandCreate,
+		Read:   resourceBigipCommandRead,
+		Delete: resourceBigipCommandDelete,
+
+		Schema: map[string]*schema.Schema{
+			"command": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "tmsh command to run, e.g. save sys config",
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary values that run the command again when they change",
+			},
+			"result": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Output of the command",
+			},
+		},
+	}
+}
+
+func resourceBigipCommandCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	command := d.Get("command").(string)
+	log.Printf("[INFO] Running tmsh command: %s", command)
+
+	result, err := client.RunBashCommand("tmsh " + command)
+	if err != nil {
+		return fmt.Errorf("Error running tmsh command %q: %v", command, apiErrorDetails(err))
+	}
+
+	d.SetId(resource.PrefixedUniqueId("command-"))
+	d.Set("result", result)
+	return resourceBigipCommandRead(d, meta)
+}
+
+func resourceBigipCommandRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+func resourceBigipCommandDelete(d *schema.ResourceData, meta interface{}) error {
+	// Commands cannot be undone, forget them
+	d.SetId("")
+	return nil
+}
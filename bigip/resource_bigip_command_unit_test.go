@@ -0,0 +1,94 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func testBigipCommandConfig(url, trigger string) string {
+	return fmt.Sprintf(`
+		resource "bigip_command" "test-command" {
+			command = "show sys version | grep 'Version'"
+			triggers = {
+				version = "%s"
+			}
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, trigger, url)
+}
+
+func TestAccBigipCommand(t *testing.T) {
+	setup()
+	var commands []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request) {
+		var command bigip.BashCommand
+		json.NewDecoder(r.Body).Decode(&command)
+		commands = append(commands, command.Command+" "+command.UtilCmdArgs)
+		fmt.Fprintf(w, `{"kind":"tm:util:bash:runstate","command":"run","commandResult":"    Version     13.1.0\n"}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipCommandConfig(server.URL, "1"),
+				Check:  resource.TestCheckResourceAttr("bigip_command.test-command", "result", "    Version     13.1.0\n"),
+			},
+			{
+				Config: testBigipCommandConfig(server.URL, "1"),
+			},
+			{
+				Config: testBigipCommandConfig(server.URL, "2"),
+			},
+		},
+	})
+	command := `run -c 'tmsh show sys version | grep '\''Version'\'''`
+	assert.Equal(t, []string{command, command}, commands)
+}
+
+func TestAccBigipCommandNotRetried(t *testing.T) {
+	setup()
+	calls := 0
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/util/bash", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// e.g. a proxy timing out while the command still runs
+			w.WriteHeader(http.StatusGatewayTimeout)
+			fmt.Fprintf(w, `{"code":504,"message":"Gateway Timeout"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"kind":"tm:util:bash:runstate","command":"run","commandResult":""}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: strings.Replace(testBigipCommandConfig(server.URL, "1"), `password = "admin"`, `password = "admin"
+					retry_backoff = 0`, 1),
+				ExpectError: regexp.MustCompile("Gateway Timeout"),
+			},
+		},
+	})
+	assert.Equal(t, 1, calls)
+}
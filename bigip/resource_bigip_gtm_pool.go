@@ -0,0 +1,275 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// gtmPoolLbModes are the load balancing modes of GTM pools, used by the
+// preferred and the fallback mode.
+var gtmPoolLbModes = []string{
+	"round-robin", "ratio", "topology", "global-availability", "static-persistence",
+	"return-to-dns", "fallback-ip", "drop-packet", "none", "completion-rate",
+	"cpu", "fewest-hops", "kilobytes-per-second", "least-connections",
+	"lowest-round-trip-time", "packet-rate", "quality-of-service",
+	"virtual-server-capacity", "virtual-server-score",
+}
+
+// gtmPoolAlternateModes are the load balancing modes the alternate mode is
+// limited to, the static ones and the few dynamic ones that need no metrics
+// of the path to the client.
+var gtmPoolAlternateModes = []string{
+	"round-robin", "ratio", "topology", "global-availability", "static-persistence",
+	"return-to-dns", "fallback-ip", "drop-packet", "none", "packet-rate",
+	"virtual-server-capacity", "virtual-server-score",
+}
+
+func resourceBigipGtmPool() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipGtmPoolCreate,
+		Read:   resourceBigipGtmPoolRead,
+		Update: resourceBigipGtmPoolUpdate,
+		Delete: resourceBigipGtmPoolDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipGtmPoolImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the pool, either a full path or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("pool"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the pool, for referencing it from wide IPs",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "DNS record type of the pool: a, aaaa, cname, mx, naptr or srv",
+				ValidateFunc: validateGtmWideipType,
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the pool",
+			},
+			"load_balancing_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "round-robin",
+				Description:  "Preferred method of selecting a member, e.g. round-robin, ratio or global-availability",
+				ValidateFunc: validateStringValue(gtmPoolLbModes),
+			},
+			"alternate_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Method of selecting a member when the preferred one fails",
+				ValidateFunc: validateStringValue(gtmPoolAlternateModes),
+			},
+			"fallback_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "return-to-dns",
+				Description:  "Method of selecting a member when the preferred and alternate ones fail",
+				ValidateFunc: validateStringValue(gtmPoolLbModes),
+			},
+			"fallback_ip": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Address answered with the fallback-ip fallback mode",
+			},
+			"monitor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Health monitors of the members, e.g. /Common/http, or default to use the monitors of the servers",
+			},
+			"member": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Members of the pool, in order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"server": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Full path of the GTM server of the virtual server",
+							ValidateFunc: validateF5Name,
+						},
+						"virtual_server": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of a virtual server of the server",
+						},
+						"ratio": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "Weight of the member with the ratio load balancing mode",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataToGtmPool(d *schema.ResourceData) *bigip.GTMPool {
+	pool := &bigip.GTMPool{
+		Description:       d.Get("description").(string),
+		LoadBalancingMode: d.Get("load_balancing_mode").(string),
+		AlternateMode:     d.Get("alternate_mode").(string),
+		FallbackMode:      d.Get("fallback_mode").(string),
+		FallbackIP:        d.Get("fallback_ip").(string),
+		Monitor:           d.Get("monitor").(string),
+		Members:           []bigip.GTMPoolMember{},
+	}
+	// The order of the members is their position in the list
+	for i, m := range d.Get("member").([]interface{}) {
+		member := m.(map[string]interface{})
+		partition, server := parseF5Identifier(member["server"].(string))
+		pool.Members = append(pool.Members, bigip.GTMPoolMember{
+			Name:        server + ":" + member["virtual_server"].(string),
+			Partition:   partition,
+			MemberOrder: i,
+			Ratio:       member["ratio"].(int),
+		})
+	}
+	return pool
+}
+
+// flattenGtmPoolMembers returns the members of a pool sorted by their order.
+func flattenGtmPoolMembers(members []bigip.GTMPoolMember) []interface{} {
+	sort.SliceStable(members, func(i, j int) bool {
+		return members[i].MemberOrder < members[j].MemberOrder
+	})
+	flattened := make([]interface{}, 0, len(members))
+	for _, member := range members {
+		// Member names are server:virtual server, the server in the partition of the member
+		server, vs := member.Name, ""
+		if i := strings.Index(member.Name, ":"); i >= 0 {
+			server, vs = member.Name[:i], member.Name[i+1:]
+		}
+		if member.Partition != "" {
+			server = fmt.Sprintf("/%s/%s", member.Partition, server)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"server":         server,
+			"virtual_server": vs,
+			"ratio":          member.Ratio,
+		})
+	}
+	return flattened
+}
+
+func resourceBigipGtmPoolCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "GTM pool")
+	if err != nil {
+		return err
+	}
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Creating %s GTM pool %s", recordType, name)
+
+	config := dataToGtmPool(d)
+	config.Name = name
+	err = client.AddGTMPool(recordType, config)
+	if err != nil {
+		return fmt.Errorf("Error creating %s GTM pool (%s): %v", recordType, name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipGtmPoolRead(d, meta)
+}
+
+func resourceBigipGtmPoolRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Fetching %s GTM pool %s", recordType, name)
+
+	pool, err := client.GetGTMPool(recordType, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving %s GTM pool (%s): %v", recordType, name, err)
+	}
+	if pool == nil {
+		log.Printf("[WARN] %s GTM pool (%s) not found, removing from state", recordType, name)
+		d.SetId("")
+		return nil
+	}
+
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	d.Set("description", pool.Description)
+	d.Set("load_balancing_mode", pool.LoadBalancingMode)
+	d.Set("alternate_mode", pool.AlternateMode)
+	d.Set("fallback_mode", pool.FallbackMode)
+	d.Set("fallback_ip", pool.FallbackIP)
+	d.Set("monitor", pool.Monitor)
+	if err := d.Set("member", flattenGtmPoolMembers(pool.Members)); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Members to state for GTM pool (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceBigipGtmPoolUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Updating %s GTM pool %s", recordType, name)
+
+	err := client.ModifyGTMPool(recordType, name, dataToGtmPool(d))
+	if err != nil {
+		return fmt.Errorf("Error modifying %s GTM pool (%s): %v", recordType, name, apiErrorDetails(err))
+	}
+	return resourceBigipGtmPoolRead(d, meta)
+}
+
+func resourceBigipGtmPoolDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Deleting %s GTM pool %s", recordType, name)
+
+	err := client.DeleteGTMPool(recordType, name)
+	if err != nil {
+		return fmt.Errorf("Error deleting %s GTM pool (%s): %v", recordType, name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipGtmPoolImport imports a pool by its full path, looking up its
+// type like wide IPs, e.g. aaaa:/Common/pool for a name used by several types.
+func resourceBigipGtmPoolImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigipClient)
+
+	return importGtmTyped(d, "GTM pool", func(recordType, name string) (bool, error) {
+		pool, err := client.GetGTMPool(recordType, name)
+		if err != nil {
+			return false, fmt.Errorf("Error retrieving %s GTM pool (%s): %v", recordType, name, err)
+		}
+		return pool != nil, nil
+	})
+}
@@ -0,0 +1,184 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipGtmPoolServer mocks the A pool /Common/test-pool, the BigIP reports
+// its members as a subcollection in an order of its own with their memberOrder
+// field. The requests are recorded in bodies.
+func testBigipGtmPoolServer(bodies *[]string) {
+	var pool map[string]interface{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		pool = map[string]interface{}{}
+		json.Unmarshal(body, &pool)
+		pool["name"] = "test-pool"
+		pool["partition"] = "Common"
+		pool["fullPath"] = "/Common/test-pool"
+		pool["monitor"] = "default"
+		pool["fallbackIp"] = "any"
+		members := pool["members"].([]interface{})
+		for i, j := 0, len(members)-1; i < j; i, j = i+1, j-1 {
+			members[i], members[j] = members[j], members[i]
+		}
+		delete(pool, "members")
+		pool["membersReference"] = map[string]interface{}{"items": members}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/pool/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested pool was not found."}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/pool/a", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/pool/a/~Common~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			pool = nil
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if pool == nil || r.URL.Query().Get("expandSubcollections") != "true" && r.Method == "GET" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested pool (/Common/test-pool A) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(pool)
+	})
+}
+
+func testBigipGtmPoolConfig(members string) string {
+	return fmt.Sprintf(`
+		resource "bigip_gtm_pool" "test-pool" {
+			name = "test-pool"
+			type = "A"
+			load_balancing_mode = "global-availability"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, members, server.URL)
+}
+
+func TestAccBigipGtmPoolMemberOrder(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipGtmPoolServer(&bodies)
+	defer teardown()
+	reordered := testBigipGtmPoolConfig(`
+		member {
+			server = "/Common/server-2"
+			virtual_server = "/Common/vs-2"
+		}
+		member {
+			server = "/Common/server-1"
+			virtual_server = "/Common/vs-1"
+			ratio = 2
+		}
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipGtmPoolConfig(`
+					member {
+						server = "/Common/server-1"
+						virtual_server = "/Common/vs-1"
+						ratio = 2
+					}
+					member {
+						server = "/Common/server-2"
+						virtual_server = "/Common/vs-2"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "id", "/Common/test-pool"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "name", "test-pool"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "type", "a"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "fallback_mode", "return-to-dns"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "monitor", "default"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "member.#", "2"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "member.0.server", "/Common/server-1"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "member.0.virtual_server", "/Common/vs-1"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "member.0.ratio", "2"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "member.1.server", "/Common/server-2"),
+				),
+			},
+			{
+				Config: reordered,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "member.0.server", "/Common/server-2"),
+					resource.TestCheckResourceAttr("bigip_gtm_pool.test-pool", "member.1.server", "/Common/server-1"),
+				),
+			},
+			{
+				// Imports look up the type and keep the members in order
+				Config:                  reordered,
+				ResourceName:            "bigip_gtm_pool.test-pool",
+				ImportState:             true,
+				ImportStateId:           "/Common/test-pool",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name"},
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"loadBalancingMode":"global-availability"`)
+	assert.Contains(t, bodies[0], `{"name":"server-1:/Common/vs-1","partition":"Common","memberOrder":0,"ratio":2}`)
+	assert.Contains(t, bodies[1], `{"name":"server-2:/Common/vs-2","partition":"Common","memberOrder":0,"ratio":1}`)
+	assert.Contains(t, bodies[1], `{"name":"server-1:/Common/vs-1","partition":"Common","memberOrder":1,"ratio":2}`)
+}
+
+func TestResourceBigipGtmPoolImport(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/tm/gtm/pool/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested pool was not found."}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/pool/aaaa/~Common~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-pool","partition":"Common"}`)
+	})
+	client := &bigipClient{bigip.NewSession(server.URL, "admin", "admin", nil), &Config{}}
+
+	d := resourceBigipGtmPool().Data(nil)
+	d.SetId("/Common/test-pool")
+	imported, err := resourceBigipGtmPoolImport(d, client)
+	assert.NoError(t, err)
+	assert.Equal(t, "aaaa", imported[0].Get("type"))
+
+	d.SetId("a:/Common/test-pool")
+	_, err = resourceBigipGtmPoolImport(d, client)
+	assert.EqualError(t, err, "GTM pool /Common/test-pool not found")
+}
+
+func TestResourceBigipGtmPoolModes(t *testing.T) {
+	s := resourceBigipGtmPool().Schema
+	for _, mode := range []string{"cpu", "least-connections", "completion-rate"} {
+		_, errs := s["alternate_mode"].ValidateFunc(mode, "alternate_mode")
+		assert.NotEmpty(t, errs, "alternate_mode %s was accepted", mode)
+		_, errs = s["fallback_mode"].ValidateFunc(mode, "fallback_mode")
+		assert.Empty(t, errs, "fallback_mode %s was rejected", mode)
+	}
+	_, errs := s["alternate_mode"].ValidateFunc("global-availability", "alternate_mode")
+	assert.Empty(t, errs)
+}
@@ -0,0 +1,231 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func resourceBigipGtmServer() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipGtmServerCreate,
+		Read:   resourceBigipGtmServerRead,
+		Update: resourceBigipGtmServerUpdate,
+		Delete: resourceBigipGtmServerDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the server, either a full path or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("server"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the server, for referencing it from pool members",
+			},
+			"datacenter": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Full path of the data center the server is in",
+				ValidateFunc: validateF5Name,
+			},
+			"product": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "bigip",
+				ForceNew:    true,
+				Description: "Kind of server, e.g. bigip, generic-host or redundant-bigip",
+			},
+			"monitor": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Health monitors of the server, e.g. /Common/bigip, or a rule such as min 1 of { /Common/gateway_icmp /Common/tcp }",
+			},
+			"virtual_server_discovery": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "disabled",
+				Description:  "Discover the virtual servers of a BigIP server: enabled, enabled-no-delete or disabled. Discovered virtual servers show as changes to virtual_server unless listed there.",
+				ValidateFunc: validateStringValue([]string{"enabled", "enabled-no-delete", "disabled"}),
+			},
+			"address": {
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Description: "Addresses of the server",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"ip": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "IP address of the server",
+						},
+						"device_name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Name of the device with the address, for servers with several devices",
+						},
+						"translation": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "none",
+							Description: "Public address the IP address is translated to, or none",
+						},
+					},
+				},
+			},
+			"virtual_server": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Virtual servers of the server, members of GTM pools",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the virtual server",
+						},
+						"destination": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Address and port of the virtual server, e.g. 10.10.10.10:80",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataToGtmServer(d *schema.ResourceData) *bigip.Server {
+	server := &bigip.Server{
+		Datacenter:               d.Get("datacenter").(string),
+		Product:                  d.Get("product").(string),
+		Monitor:                  d.Get("monitor").(string),
+		Virtual_server_discovery: d.Get("virtual_server_discovery").(string),
+		Addresses:                []bigip.ServerAddresses{},
+		GTMVirtual_Server:        []bigip.VSrecord{},
+	}
+	for _, a := range d.Get("address").([]interface{}) {
+		address := a.(map[string]interface{})
+		server.Addresses = append(server.Addresses, bigip.ServerAddresses{
+			Name:        address["ip"].(string),
+			Device_name: address["device_name"].(string),
+			Translation: address["translation"].(string),
+		})
+	}
+	for _, v := range d.Get("virtual_server").([]interface{}) {
+		vs := v.(map[string]interface{})
+		server.GTMVirtual_Server = append(server.GTMVirtual_Server, bigip.VSrecord{
+			Name:        vs["name"].(string),
+			Destination: vs["destination"].(string),
+		})
+	}
+	return server
+}
+
+func resourceBigipGtmServerCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "GTM server")
+	if err != nil {
+		return err
+	}
+	log.Println("[INFO] Creating GTM server " + name)
+
+	config := dataToGtmServer(d)
+	config.Name = name
+	err = client.CreateGtmserver(config)
+	if err != nil {
+		return fmt.Errorf("Error creating GTM server (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipGtmServerRead(d, meta)
+}
+
+func resourceBigipGtmServerRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Fetching GTM server " + name)
+
+	server, err := client.GetGtmserver(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving GTM server (%s): %v", name, err)
+	}
+	if server == nil {
+		log.Printf("[WARN] GTM server (%s) not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	d.Set("datacenter", server.Datacenter)
+	d.Set("product", server.Product)
+	d.Set("monitor", server.Monitor)
+	d.Set("virtual_server_discovery", server.Virtual_server_discovery)
+
+	addresses := make([]interface{}, 0, len(server.Addresses))
+	for _, address := range server.Addresses {
+		addresses = append(addresses, map[string]interface{}{
+			"ip":          address.Name,
+			"device_name": address.Device_name,
+			"translation": address.Translation,
+		})
+	}
+	if err := d.Set("address", addresses); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Addresses to state for GTM server (%s): %s", d.Id(), err)
+	}
+
+	virtualServers := make([]interface{}, 0, len(server.GTMVirtual_Server))
+	for _, vs := range server.GTMVirtual_Server {
+		virtualServers = append(virtualServers, map[string]interface{}{
+			"name":        vs.Name,
+			"destination": vs.Destination,
+		})
+	}
+	if err := d.Set("virtual_server", virtualServers); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Virtual Servers to state for GTM server (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceBigipGtmServerUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Updating GTM server " + name)
+
+	err := client.UpdateGtmserver(name, dataToGtmServer(d))
+	if err != nil {
+		return fmt.Errorf("Error modifying GTM server (%s): %v", name, apiErrorDetails(err))
+	}
+	return resourceBigipGtmServerRead(d, meta)
+}
+
+func resourceBigipGtmServerDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting GTM server " + name)
+
+	err := client.DeleteGtmserver(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting GTM server (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
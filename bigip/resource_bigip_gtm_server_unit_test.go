@@ -0,0 +1,131 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipGtmServerServer mocks the GTM server /Common/test-server, the BigIP
+// reports its virtual servers as a subcollection. The requests are recorded in
+// bodies.
+func testBigipGtmServerServer(bodies *[]string) {
+	var gtmServer map[string]interface{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		gtmServer = map[string]interface{}{}
+		json.Unmarshal(body, &gtmServer)
+		gtmServer["name"] = "test-server"
+		gtmServer["partition"] = "Common"
+		gtmServer["fullPath"] = "/Common/test-server"
+		gtmServer["monitor"] = "/Common/bigip"
+		for _, a := range gtmServer["addresses"].([]interface{}) {
+			address := a.(map[string]interface{})
+			if address["deviceName"] == nil {
+				address["deviceName"] = address["name"]
+			}
+		}
+		gtmServer["virtualServersReference"] = map[string]interface{}{"items": gtmServer["virtualServers"]}
+		delete(gtmServer, "virtualServers")
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/server", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/server/~Common~test-server", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			gtmServer = nil
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if gtmServer == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested server (/Common/test-server) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(gtmServer)
+	})
+}
+
+func testBigipGtmServerConfig(virtualServers string) string {
+	return fmt.Sprintf(`
+		resource "bigip_gtm_server" "test-server" {
+			name = "/Common/test-server"
+			datacenter = "/Common/dc1"
+			address {
+				ip = "10.10.10.1"
+			}
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, virtualServers, server.URL)
+}
+
+func TestAccBigipGtmServer(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipGtmServerServer(&bodies)
+	defer teardown()
+	withVirtualServers := testBigipGtmServerConfig(`
+		virtual_server {
+			name = "/Common/vs-1"
+			destination = "10.10.10.10:80"
+		}
+		virtual_server {
+			name = "/Common/vs-2"
+			destination = "10.10.10.11:443"
+		}
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipGtmServerConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "full_path", "/Common/test-server"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "product", "bigip"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "monitor", "/Common/bigip"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "address.0.device_name", "10.10.10.1"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "address.0.translation", "none"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "virtual_server.#", "0"),
+				),
+			},
+			{
+				Config: withVirtualServers,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "virtual_server.#", "2"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "virtual_server.1.name", "/Common/vs-2"),
+					resource.TestCheckResourceAttr("bigip_gtm_server.test-server", "virtual_server.1.destination", "10.10.10.11:443"),
+				),
+			},
+			{
+				Config:            withVirtualServers,
+				ResourceName:      "bigip_gtm_server.test-server",
+				ImportState:       true,
+				ImportStateId:     "/Common/test-server",
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"datacenter":"/Common/dc1"`)
+	assert.Contains(t, bodies[0], `"addresses":[{"name":"10.10.10.1","translation":"none"}]`)
+	assert.Contains(t, bodies[0], `"virtualServers":[]`)
+	assert.Contains(t, bodies[1], `{"name":"/Common/vs-1","destination":"10.10.10.10:80"}`)
+}
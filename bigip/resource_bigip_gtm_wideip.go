@@ -0,0 +1,274 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// gtmWideipTypes are the DNS record types of wide IPs.
+var gtmWideipTypes = []string{"a", "aaaa", "cname", "mx", "naptr", "srv"}
+
+func resourceBigipGtmWideip() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipGtmWideipCreate,
+		Read:   resourceBigipGtmWideipRead,
+		Update: resourceBigipGtmWideipUpdate,
+		Delete: resourceBigipGtmWideipDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceBigipGtmWideipImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the wide IP, the DNS name it resolves, e.g. www.example.com, either a full path or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("wide IP"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the wide IP",
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "DNS record type of the wide IP: a, aaaa, cname, mx, naptr or srv",
+				ValidateFunc: validateGtmWideipType,
+				StateFunc: func(v interface{}) string {
+					return strings.ToLower(v.(string))
+				},
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the wide IP",
+			},
+			"load_balancing_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "round-robin",
+				Description:  "Method of selecting a pool: round-robin, ratio, topology or global-availability",
+				ValidateFunc: validateStringValue([]string{"round-robin", "ratio", "topology", "global-availability"}),
+			},
+			"last_resort_pool": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Full path of the pool answering when no other pool is available, a pool of the type of the wide IP",
+				ValidateFunc: validateF5Name,
+			},
+			"pool": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Pools of the wide IP, in order",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Full path of a pool of the type of the wide IP",
+							ValidateFunc: validateF5Name,
+						},
+						"ratio": {
+							Type:        schema.TypeInt,
+							Optional:    true,
+							Default:     1,
+							Description: "Weight of the pool with the ratio load balancing mode",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func validateGtmWideipType(value interface{}, field string) ([]string, []error) {
+	return validateStringValue(gtmWideipTypes)(strings.ToLower(value.(string)), field)
+}
+
+// gtmWideipType returns the record type of the wide IP as used in the URLs of
+// the BigIP, in lower case.
+func gtmWideipType(d *schema.ResourceData) string {
+	return strings.ToLower(d.Get("type").(string))
+}
+
+func dataToGtmWideip(d *schema.ResourceData) *bigip.GTMWideIP {
+	wideIP := &bigip.GTMWideIP{
+		Description: d.Get("description").(string),
+		PoolLbMode:  d.Get("load_balancing_mode").(string),
+		Pools:       []bigip.GTMWideIPPool{},
+	}
+	if pool := d.Get("last_resort_pool").(string); pool != "" {
+		wideIP.LastResortPool = gtmWideipType(d) + " " + pool
+	}
+	// The order of the pools is their position in the list
+	for i, p := range d.Get("pool").([]interface{}) {
+		pool := p.(map[string]interface{})
+		partition, name := parseF5Identifier(pool["name"].(string))
+		wideIP.Pools = append(wideIP.Pools, bigip.GTMWideIPPool{
+			Name:      name,
+			Partition: partition,
+			Order:     i,
+			Ratio:     pool["ratio"].(int),
+		})
+	}
+	return wideIP
+}
+
+// flattenGtmWideipPools returns the pools of a wide IP sorted by their order.
+func flattenGtmWideipPools(pools []bigip.GTMWideIPPool) []interface{} {
+	sort.SliceStable(pools, func(i, j int) bool {
+		return pools[i].Order < pools[j].Order
+	})
+	flattened := make([]interface{}, 0, len(pools))
+	for _, pool := range pools {
+		name := pool.Name
+		if pool.Partition != "" {
+			name = fmt.Sprintf("/%s/%s", pool.Partition, pool.Name)
+		}
+		flattened = append(flattened, map[string]interface{}{
+			"name":  name,
+			"ratio": pool.Ratio,
+		})
+	}
+	return flattened
+}
+
+func resourceBigipGtmWideipCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "wide IP")
+	if err != nil {
+		return err
+	}
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Creating %s wide IP %s", recordType, name)
+
+	config := dataToGtmWideip(d)
+	config.Name = name
+	err = client.AddGTMWideIP(recordType, config)
+	if err != nil {
+		return fmt.Errorf("Error creating %s wide IP (%s): %v", recordType, name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipGtmWideipRead(d, meta)
+}
+
+func resourceBigipGtmWideipRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Fetching %s wide IP %s", recordType, name)
+
+	wideIP, err := client.GetGTMWideIP(recordType, name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving %s wide IP (%s): %v", recordType, name, err)
+	}
+	if wideIP == nil {
+		log.Printf("[WARN] %s wide IP (%s) not found, removing from state", recordType, name)
+		d.SetId("")
+		return nil
+	}
+
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	d.Set("description", wideIP.Description)
+	d.Set("load_balancing_mode", wideIP.PoolLbMode)
+	// The BigIP reports the last resort pool with its type, e.g. "a /Common/pool"
+	lastResortPool := wideIP.LastResortPool
+	if fields := strings.Fields(lastResortPool); len(fields) == 2 {
+		lastResortPool = fields[1]
+	}
+	d.Set("last_resort_pool", lastResortPool)
+	if err := d.Set("pool", flattenGtmWideipPools(wideIP.Pools)); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Pools to state for wide IP (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceBigipGtmWideipUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Updating %s wide IP %s", recordType, name)
+
+	err := client.ModifyGTMWideIP(recordType, name, dataToGtmWideip(d))
+	if err != nil {
+		return fmt.Errorf("Error modifying %s wide IP (%s): %v", recordType, name, apiErrorDetails(err))
+	}
+	return resourceBigipGtmWideipRead(d, meta)
+}
+
+func resourceBigipGtmWideipDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	recordType := gtmWideipType(d)
+	log.Printf("[INFO] Deleting %s wide IP %s", recordType, name)
+
+	err := client.DeleteGTMWideIP(recordType, name)
+	if err != nil {
+		return fmt.Errorf("Error deleting %s wide IP (%s): %v", recordType, name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
+
+// resourceBigipGtmWideipImport imports a wide IP by its full path, looking up
+// its type. Names used by wide IPs of several types, e.g. an A and an AAAA
+// wide IP, are imported with the type as prefix, e.g. aaaa:/Common/www.example.com.
+func resourceBigipGtmWideipImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigipClient)
+
+	return importGtmTyped(d, "Wide IP", func(recordType, name string) (bool, error) {
+		wideIP, err := client.GetGTMWideIP(recordType, name)
+		if err != nil {
+			return false, fmt.Errorf("Error retrieving %s wide IP (%s): %v", recordType, name, err)
+		}
+		return wideIP != nil, nil
+	})
+}
+
+// importGtmTyped imports a GTM object whose URL contains its record type, e.g.
+// a wide IP or a pool. The ID is the full path, optionally prefixed by the
+// type, e.g. aaaa:/Common/name, exists reports whether the object exists with
+// a type.
+func importGtmTyped(d *schema.ResourceData, kind string, exists func(recordType, name string) (bool, error)) ([]*schema.ResourceData, error) {
+	name := d.Id()
+	types := gtmWideipTypes
+	if i := strings.Index(name, ":/"); i > 0 && !strings.HasPrefix(name, "/") {
+		types = []string{strings.ToLower(name[:i])}
+		name = name[i+1:]
+	}
+
+	var found []string
+	for _, recordType := range types {
+		ok, err := exists(recordType, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = append(found, recordType)
+		}
+	}
+	switch len(found) {
+	case 0:
+		return nil, fmt.Errorf("%s %s not found", kind, name)
+	case 1:
+		d.SetId(name)
+		d.Set("type", found[0])
+		return []*schema.ResourceData{d}, nil
+	}
+	return nil, fmt.Errorf("%s %s exists with the types %s, import it with the type as prefix, e.g. %s:%s", kind, name, strings.Join(found, ", "), found[0], name)
+}
@@ -0,0 +1,175 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipGtmWideipServer mocks the A wide IP /Common/www.example.com, the
+// BigIP reports its pools in an order of its own with their order field. The
+// requests are recorded in bodies.
+func testBigipGtmWideipServer(bodies *[]string) {
+	var wideIP *bigip.GTMWideIP
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &wideIP)
+		wideIP.Name = "www.example.com"
+		wideIP.Partition = "Common"
+		wideIP.FullPath = "/Common/www.example.com"
+		for i, j := 0, len(wideIP.Pools)-1; i < j; i, j = i+1, j-1 {
+			wideIP.Pools[i], wideIP.Pools[j] = wideIP.Pools[j], wideIP.Pools[i]
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/wideip/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested wide IP was not found."}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/wideip/a", func(w http.ResponseWriter, r *http.Request) {
+		wideIP = nil
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/gtm/wideip/a/~Common~www.example.com", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			wideIP = nil
+			save(r)
+		case "DELETE":
+			wideIP = nil
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if wideIP == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested wide IP (/Common/www.example.com A) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(wideIP)
+	})
+}
+
+func testBigipGtmWideipConfig(pools string) string {
+	return fmt.Sprintf(`
+		resource "bigip_gtm_wideip" "test-wideip" {
+			name = "/Common/www.example.com"
+			type = "A"
+			load_balancing_mode = "topology"
+			last_resort_pool = "/Common/pool-lr"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, pools, server.URL)
+}
+
+func TestAccBigipGtmWideipPoolOrder(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipGtmWideipServer(&bodies)
+	defer teardown()
+	reordered := testBigipGtmWideipConfig(`
+		pool {
+			name = "/Common/pool-2"
+		}
+		pool {
+			name = "/Common/pool-1"
+			ratio = 2
+		}
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipGtmWideipConfig(`
+					pool {
+						name = "/Common/pool-1"
+						ratio = 2
+					}
+					pool {
+						name = "/Common/pool-2"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "type", "a"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "full_path", "/Common/www.example.com"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "last_resort_pool", "/Common/pool-lr"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "pool.#", "2"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "pool.0.name", "/Common/pool-1"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "pool.0.ratio", "2"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "pool.1.name", "/Common/pool-2"),
+				),
+			},
+			{
+				Config: reordered,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "pool.0.name", "/Common/pool-2"),
+					resource.TestCheckResourceAttr("bigip_gtm_wideip.test-wideip", "pool.1.name", "/Common/pool-1"),
+				),
+			},
+			{
+				// Imports look up the type and keep the pools in order
+				Config:            reordered,
+				ResourceName:      "bigip_gtm_wideip.test-wideip",
+				ImportState:       true,
+				ImportStateId:     "/Common/www.example.com",
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"lastResortPool":"a /Common/pool-lr"`)
+	assert.Contains(t, bodies[0], `{"name":"pool-1","partition":"Common","order":0,"ratio":2}`)
+	assert.Contains(t, bodies[1], `{"name":"pool-2","partition":"Common","order":0,"ratio":1}`)
+	assert.Contains(t, bodies[1], `{"name":"pool-1","partition":"Common","order":1,"ratio":2}`)
+}
+
+func TestResourceBigipGtmWideipImport(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/tm/gtm/wideip/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested wide IP was not found."}`)
+	})
+	for _, recordType := range []string{"a", "aaaa"} {
+		mux.HandleFunc("/mgmt/tm/gtm/wideip/"+recordType+"/~Common~www.example.com", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"name":"www.example.com","partition":"Common"}`)
+		})
+	}
+	client := &bigipClient{bigip.NewSession(server.URL, "admin", "admin", nil), &Config{}}
+	importWideip := func(id string) (*schema.ResourceData, error) {
+		d := resourceBigipGtmWideip().Data(nil)
+		d.SetId(id)
+		imported, err := resourceBigipGtmWideipImport(d, client)
+		if err != nil {
+			return nil, err
+		}
+		return imported[0], nil
+	}
+
+	// A name used by wide IPs of several types needs the type
+	_, err := importWideip("/Common/www.example.com")
+	assert.EqualError(t, err, "Wide IP /Common/www.example.com exists with the types a, aaaa, import it with the type as prefix, e.g. a:/Common/www.example.com")
+
+	d, err := importWideip("AAAA:/Common/www.example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "/Common/www.example.com", d.Id())
+	assert.Equal(t, "aaaa", d.Get("type"))
+
+	_, err = importWideip("/Common/missing.example.com")
+	assert.EqualError(t, err, "Wide IP /Common/missing.example.com not found")
+}
@@ -4,19 +4,20 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmDataGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceBigipLtmDataGroupCreate,
-		Read:   resourceBigipLtmDataGroupRead,
-		Update: resourceBigipLtmDataGroupUpdate,
-		Delete: resourceBigipLtmDataGroupDelete,
-		Exists: resourceBigipLtmDataGroupExists,
+		Create:        resourceBigipLtmDataGroupCreate,
+		Read:          resourceBigipLtmDataGroupRead,
+		Update:        resourceBigipLtmDataGroupUpdate,
+		Delete:        resourceBigipLtmDataGroupDelete,
+		Exists:        resourceBigipLtmDataGroupExists,
+		CustomizeDiff: resourceBigipLtmDataGroupCustomizeDiff,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceBigipLtmDataGroupImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -28,14 +29,31 @@ func resourceBigipLtmDataGroup() *schema.Resource {
 				ValidateFunc: validateF5Name,
 			},
 
+			"internal": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+				Description: "Whether the records are configured in the Data Group List rather than read from a data group file",
+			},
+
 			"type": {
 				Type:         schema.TypeString,
-				Required:     true,
+				Optional:     true,
+				Computed:     true,
 				ForceNew:     true,
-				Description:  "The Data Group type (string, ip, integer)",
+				Description:  "The Data Group type (string, ip, integer), required for internal Data Group Lists",
 				ValidateFunc: validateDataGroupType,
 			},
 
+			"external_file_name": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "The data group file the records of an external Data Group List are read from",
+				ValidateFunc:  validateF5Name,
+				ConflictsWith: []string{"record"},
+			},
+
 			"record": {
 				Type:     schema.TypeSet,
 				Optional: true,
@@ -57,32 +75,58 @@ func resourceBigipLtmDataGroup() *schema.Resource {
 	}
 }
 
+// resourceBigipLtmDataGroupCustomizeDiff checks that only external Data Group
+// Lists, and all of them, read their records from a file.
+func resourceBigipLtmDataGroupCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if !d.NewValueKnown("internal") || !d.NewValueKnown("external_file_name") {
+		return nil
+	}
+	internal := d.Get("internal").(bool)
+	file := d.Get("external_file_name").(string)
+	if internal && file != "" {
+		return fmt.Errorf("external_file_name is only allowed when internal is false")
+	}
+	if !internal && file == "" {
+		return fmt.Errorf("external_file_name is required when internal is false")
+	}
+	return nil
+}
+
+// dataToDataGroupRecords returns the records of an internal Data Group List.
+func dataToDataGroupRecords(d *schema.ResourceData) []bigip.DataGroupRecord {
+	var records []bigip.DataGroupRecord
+	for _, r := range d.Get("record").(*schema.Set).List() {
+		record := r.(map[string]interface{})
+		records = append(records, bigip.DataGroupRecord{Name: record["name"].(string), Data: record["data"].(string)})
+	}
+	return records
+}
+
 func resourceBigipLtmDataGroupCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	log.Printf("[DEBUG] Creating Data Group List %s", name)
 
-	dgtype := d.Get("type").(string)
-	rs := d.Get("record").(*schema.Set)
-
-	var records []bigip.DataGroupRecord
-	if rs.Len() > 0 {
-		for _, r := range rs.List() {
-			record := r.(map[string]interface{})
-			records = append(records, bigip.DataGroupRecord{Name: record["name"].(string), Data: record["data"].(string)})
+	var err error
+	if d.Get("internal").(bool) {
+		// The type is computed for external Data Group Lists, which read it
+		// from their file
+		if d.Get("type").(string) == "" {
+			return fmt.Errorf("type is required for internal Data Group List %s", name)
 		}
+		err = client.AddInternalDataGroup(&bigip.DataGroup{
+			Name:    name,
+			Type:    d.Get("type").(string),
+			Records: dataToDataGroupRecords(d),
+		})
 	} else {
-		records = nil
-	}
-
-	dg := &bigip.DataGroup{
-		Name:    name,
-		Type:    dgtype,
-		Records: records,
+		err = client.AddExternalDataGroup(&bigip.ExternalDataGroup{
+			Name:             name,
+			Type:             d.Get("type").(string),
+			ExternalFileName: d.Get("external_file_name").(string),
+		})
 	}
-
-	err := client.AddInternalDataGroup(dg)
 	if err != nil {
 		return fmt.Errorf("Error creating Data Group List %s: %v", name, err)
 	}
@@ -93,12 +137,28 @@ func resourceBigipLtmDataGroupCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceBigipLtmDataGroupRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
-	var records []map[string]interface{}
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Printf("[DEBUG] Retrieving Data Group List %s", name)
 
+	if !d.Get("internal").(bool) {
+		datagroup, err := client.GetExternalDataGroup(name)
+		if err != nil {
+			return fmt.Errorf("Error retrieving Data Group List %s: %v", name, err)
+		}
+		if datagroup == nil {
+			log.Printf("[DEBUG] Data Group List %s not found, removing from state", name)
+			d.SetId("")
+			return nil
+		}
+
+		d.Set("name", datagroup.FullPath)
+		d.Set("type", datagroup.Type)
+		d.Set("external_file_name", datagroup.ExternalFileName)
+		return nil
+	}
+
 	datagroup, err := client.GetInternalDataGroup(name)
 	if err != nil {
 		return fmt.Errorf("Error retrieving Data Group List %s: %v", name, err)
@@ -113,6 +173,7 @@ func resourceBigipLtmDataGroupRead(d *schema.ResourceData, meta interface{}) err
 	d.Set("name", datagroup.FullPath)
 	d.Set("type", datagroup.Type)
 
+	var records []map[string]interface{}
 	for _, record := range datagroup.Records {
 		dgRecord := map[string]interface{}{
 			"name": record.Name,
@@ -129,44 +190,63 @@ func resourceBigipLtmDataGroupRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceBigipLtmDataGroupExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Printf("[DEBUG] Checking if Data Group List (%s) exists", name)
 
-	datagroup, err := client.GetInternalDataGroup(name)
-	if err != nil {
-		return false, fmt.Errorf("Error retrieving Data Group List %s: %v", name, err)
+	var exists bool
+	if d.Get("internal").(bool) {
+		datagroup, err := client.GetInternalDataGroup(name)
+		if err != nil {
+			return false, fmt.Errorf("Error retrieving Data Group List %s: %v", name, err)
+		}
+		exists = datagroup != nil
+	} else {
+		datagroup, err := client.GetExternalDataGroup(name)
+		if err != nil {
+			return false, fmt.Errorf("Error retrieving Data Group List %s: %v", name, err)
+		}
+		exists = datagroup != nil
 	}
 
-	if datagroup == nil {
+	if !exists {
 		log.Printf("[DEBUG] Data Group List (%s) not found, removing from state", name)
 		d.SetId("")
-		return false, nil
 	}
 
-	return datagroup != nil, nil
+	return exists, nil
+}
+
+// resourceBigipLtmDataGroupImport imports a Data Group List by full path,
+// looking it up among the internal and then the external ones.
+func resourceBigipLtmDataGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	datagroup, err := client.GetInternalDataGroup(name)
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving Data Group List %s: %v", name, err)
+	}
+	d.Set("internal", datagroup != nil)
+
+	return []*schema.ResourceData{d}, nil
 }
 
 func resourceBigipLtmDataGroupUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Printf("[DEBUG] Modifying Data Group List %s", name)
 
-	rs := d.Get("record").(*schema.Set)
-
-	var records []bigip.DataGroupRecord
-	if rs.Len() > 0 {
-		for _, r := range rs.List() {
-			record := r.(map[string]interface{})
-			records = append(records, bigip.DataGroupRecord{Name: record["name"].(string), Data: record["data"].(string)})
-		}
+	var err error
+	if d.Get("internal").(bool) {
+		err = client.ModifyInternalDataGroupRecords(name, dataToDataGroupRecords(d))
 	} else {
-		records = nil
+		err = client.ModifyExternalDataGroup(name, &bigip.ExternalDataGroup{
+			ExternalFileName: d.Get("external_file_name").(string),
+		})
 	}
-
-	err := client.ModifyInternalDataGroupRecords(name, records)
 	if err != nil {
 		return fmt.Errorf("Error modifying Data Group List %s: %v", name, err)
 	}
@@ -175,12 +255,17 @@ func resourceBigipLtmDataGroupUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceBigipLtmDataGroupDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Printf("[DEBUG] Deleting Data Group List %s", name)
 
-	err := client.DeleteInternalDataGroup(name)
+	var err error
+	if d.Get("internal").(bool) {
+		err = client.DeleteInternalDataGroup(name)
+	} else {
+		err = client.DeleteExternalDataGroup(name)
+	}
 	if err != nil {
 		return fmt.Errorf("Error deleting Data Group List %s: %v", name, err)
 	}
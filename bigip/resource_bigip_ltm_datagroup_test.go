@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -12,7 +11,7 @@ import (
 var TEST_DATAGROUP_NAME = "/" + TEST_PARTITION + "/test-datagroup"
 
 var TEST_DATAGROUP_STRING_RESOURCE = `
-        resource "bigip_ltm_datagroup" "test-datagroup-string" {
+        resource "bigip_ltm_data_group" "test-datagroup-string" {
                 name = "` + TEST_DATAGROUP_NAME + `"
                 type = "string"
 
@@ -26,7 +25,7 @@ var TEST_DATAGROUP_STRING_RESOURCE = `
         }`
 
 var TEST_DATAGROUP_IP_RESOURCE = `
-	resource "bigip_ltm_datagroup" "test-datagroup-ip" {
+	resource "bigip_ltm_data_group" "test-datagroup-ip" {
 		name = "` + TEST_DATAGROUP_NAME + `"
 		type = "ip"
 
@@ -40,7 +39,7 @@ var TEST_DATAGROUP_IP_RESOURCE = `
 	}`
 
 var TEST_DATAGROUP_INTEGER_RESOURCE = `
-        resource "bigip_ltm_datagroup" "test-datagroup-integer" {
+        resource "bigip_ltm_data_group" "test-datagroup-integer" {
                 name = "` + TEST_DATAGROUP_NAME + `"
                 type = "integer"
 
@@ -164,7 +163,7 @@ func TestAccBigipLtmDataGroup_import(t *testing.T) {
 
 func testCheckDataGroupExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		datagroup, err := client.GetInternalDataGroup(name)
 		if err != nil {
@@ -181,10 +180,10 @@ func testCheckDataGroupExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckDataGroupDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
-		if rs.Type != "bigip_ltm_datagroup" {
+		if rs.Type != "bigip_ltm_data_group" {
 			continue
 		}
 
@@ -0,0 +1,198 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipLtmDataGroupServer mocks the data group test-datagroup of kind,
+// internal or external, the BigIP reads the type of external data groups from
+// their file.
+func testBigipLtmDataGroupServer(kind string) {
+	dg := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/data-group/"+kind, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&dg)
+			dg["fullPath"] = dg["name"]
+			if kind == "external" {
+				dg["type"] = "string"
+			}
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	notFound := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested value list (/Common/test-datagroup) was not found."}`)
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/data-group/"+kind+"/~Common~test-datagroup", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			delete(dg, "records")
+			json.NewDecoder(r.Body).Decode(&dg)
+		case "DELETE":
+			dg = map[string]interface{}{}
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if len(dg) == 0 {
+			notFound(w, r)
+			return
+		}
+		json.NewEncoder(w).Encode(dg)
+	})
+	other := "internal"
+	if kind == "internal" {
+		other = "external"
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/data-group/"+other+"/~Common~test-datagroup", notFound)
+}
+
+func testBigipLtmDataGroupConfig(args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_data_group" "test-datagroup" {
+			name = "/Common/test-datagroup"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, server.URL)
+}
+
+func TestAccBigipLtmDataGroupInternal(t *testing.T) {
+	setup()
+	testBigipLtmDataGroupServer("internal")
+	defer teardown()
+	config := testBigipLtmDataGroupConfig(`
+		type = "string"
+		record {
+			name = "abc.com"
+			data = "pool1"
+		}
+		record {
+			name = "test"
+		}
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_data_group.test-datagroup", "type", "string"),
+					resource.TestCheckResourceAttr("bigip_ltm_data_group.test-datagroup", "internal", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_data_group.test-datagroup", "record.#", "2"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_data_group.test-datagroup",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testBigipLtmDataGroupConfig(`
+					type = "string"
+					record {
+						name = "abc.com"
+						data = "pool2"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_data_group.test-datagroup", "record.#", "1"),
+					func(s *terraform.State) error {
+						dg, err := bigip.NewSession(server.URL, "admin", "admin", nil).GetInternalDataGroup("/Common/test-datagroup")
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, []bigip.DataGroupRecord{{Name: "abc.com", Data: "pool2"}}, dg.Records)
+						return nil
+					},
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmDataGroupExternal(t *testing.T) {
+	setup()
+	testBigipLtmDataGroupServer("external")
+	defer teardown()
+	config := testBigipLtmDataGroupConfig(`
+		internal = false
+		external_file_name = "/Common/hosts-v2"
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmDataGroupConfig(`
+					internal = false
+					external_file_name = "/Common/hosts-v1"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_data_group.test-datagroup", "type", "string"),
+					resource.TestCheckResourceAttr("bigip_ltm_data_group.test-datagroup", "external_file_name", "/Common/hosts-v1"),
+				),
+			},
+			{
+				// Switching to another file updates the data group in place
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_data_group.test-datagroup", "external_file_name", "/Common/hosts-v2"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_data_group.test-datagroup",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmDataGroupArguments(t *testing.T) {
+	for args, expected := range map[string]string{
+		`record {
+			name = "test"
+		}`: "type is required for internal Data Group List /Common/test-datagroup",
+		`type = "string"
+		external_file_name = "/Common/hosts"`: "external_file_name is only allowed when internal is false",
+		`internal = false`: "external_file_name is required when internal is false",
+		`internal = false
+		external_file_name = "/Common/hosts"
+		record {
+			name = "test"
+		}`: "conflicts with record",
+	} {
+		setup()
+		testBigipLtmDataGroupServer("internal")
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipLtmDataGroupConfig(args),
+					ExpectError: regexp.MustCompile(expected),
+				},
+			},
+		})
+		teardown()
+	}
+}
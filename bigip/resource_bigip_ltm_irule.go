@@ -5,8 +5,8 @@ import (
 	"log"
 	"strings"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmIRule() *schema.Resource {
@@ -24,9 +24,17 @@ func resourceBigipLtmIRule() *schema.Resource {
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
-				Description:  "Name of the iRule",
+				Description:  "Name of the iRule, either a full path such as /Common/my_irule or a name relative to partition",
 				ForceNew:     true,
-				ValidateFunc: validateF5Name,
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+
+			"partition": partitionSchema("iRule"),
+
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the iRule, for referencing it from virtual servers",
 			},
 
 			"irule": {
@@ -36,18 +44,32 @@ func resourceBigipLtmIRule() *schema.Resource {
 				StateFunc: func(s interface{}) string {
 					return strings.TrimSpace(s.(string))
 				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// The BigIP saves the body as normalizeIRule does
+					return normalizeIRule(old) == normalizeIRule(new)
+				},
 			},
 		},
 	}
 }
 
+// normalizeIRule returns the body of an iRule as the BigIP saves it, without
+// surrounding whitespace and with LF line endings. Whitespace within the body,
+// such as indentation, is kept.
+func normalizeIRule(rule string) string {
+	return strings.TrimSpace(strings.Replace(rule, "\r\n", "\n", -1))
+}
+
 func resourceBigipLtmIRuleCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
-	name := d.Get("name").(string)
+	name, err := resourceFullPath(d, client, "iRule")
+	if err != nil {
+		return err
+	}
 	log.Printf("[INFO] Creating iRule %s", name)
 
-	err := client.CreateIRule(name, d.Get("irule").(string))
+	err = client.CreateIRule(name, d.Get("irule").(string))
 	if err != nil {
 		return fmt.Errorf("Error creating iRule %s: %v", name, err)
 	}
@@ -58,7 +80,7 @@ func resourceBigipLtmIRuleCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipLtmIRuleRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Printf("[INFO] Retrieving iRule %s", name)
@@ -73,14 +95,18 @@ func resourceBigipLtmIRuleRead(d *schema.ResourceData, meta interface{}) error {
 		return nil
 	}
 
-	d.Set("name", irule.FullPath)
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	// Saved as the BigIP normalized it, the DiffSuppressFunc ignores the
+	// differences to the configured body
 	d.Set("irule", irule.Rule)
 
 	return nil
 }
 
 func resourceBigipLtmIRuleExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Printf("[INFO] Checking if iRule (%s) exists", name)
@@ -99,7 +125,7 @@ func resourceBigipLtmIRuleExists(d *schema.ResourceData, meta interface{}) (bool
 }
 
 func resourceBigipLtmIRuleUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -116,7 +142,7 @@ func resourceBigipLtmIRuleUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipLtmIRuleDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	err := client.DeleteIRule(name)
 	if err != nil {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -62,7 +61,7 @@ func TestAccBigipLtmIRule_import(t *testing.T) {
 
 func testCheckIRuleExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		irule, err := client.IRule(name)
 		if err != nil {
@@ -83,7 +82,7 @@ func testCheckIRuleExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckIRulesDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_irule" {
@@ -0,0 +1,126 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipLtmIRuleServer mocks the iRule path, the BigIP saves the body
+// with LF line endings and reports it with a trailing newline.
+func testBigipLtmIRuleServer(path string, rules *[]string) {
+	var rule *bigip.IRule
+	save := func(r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&rule)
+		*rules = append(*rules, r.Method+" "+rule.Name)
+		rule.FullPath = path
+		rule.Rule = strings.Replace(strings.TrimSpace(rule.Rule), "\r\n", "\n", -1) + "\n"
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/rule", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/rule/"+strings.Replace(path, "/", "~", -1), func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			rule = nil
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if rule == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested rule (%s) was not found."}`, path)
+			return
+		}
+		json.NewEncoder(w).Encode(rule)
+	})
+}
+
+func testBigipLtmIRuleConfig(args, irule string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_irule" "test-rule" {
+			%s
+			irule = %q
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, irule, server.URL)
+}
+
+func TestAccBigipLtmIRulePartition(t *testing.T) {
+	setup()
+	var rules []string
+	testBigipLtmIRuleServer("/Tenant1/test-rule", &rules)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmIRuleConfig(`
+					name = "test-rule"
+					partition = "Tenant1"
+				`, "when CLIENT_ACCEPTED { log local0. \"test\" }"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "name", "test-rule"),
+					resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "partition", "Tenant1"),
+					resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "full_path", "/Tenant1/test-rule"),
+				),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST /Tenant1/test-rule"}, rules)
+}
+
+func TestAccBigipLtmIRuleWhitespace(t *testing.T) {
+	setup()
+	var rules []string
+	testBigipLtmIRuleServer("/Common/test-rule", &rules)
+	defer teardown()
+	irule := "\r\nwhen CLIENT_ACCEPTED {\r\n\tlog local0. \"test\"  \r\n}\r\n\r\n"
+	config := testBigipLtmIRuleConfig(`name = "/Common/test-rule"`, irule)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				// The step fails when the body saved by the BigIP causes a diff
+				Config: config,
+				Check: resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "irule",
+					"when CLIENT_ACCEPTED {\n\tlog local0. \"test\"  \n}\n"),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_irule.test-rule",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				// Indentation is part of the body
+				Config: testBigipLtmIRuleConfig(`name = "/Common/test-rule"`, "when CLIENT_ACCEPTED {\n    log local0. \"test\"\n}"),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_irule.test-rule", "irule",
+					"when CLIENT_ACCEPTED {\n    log local0. \"test\"\n}\n"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST /Common/test-rule", "PUT /Common/test-rule"}, rules)
+}
+
+func TestNormalizeIRule(t *testing.T) {
+	assert.Equal(t, "when CLIENT_ACCEPTED {\n\tlog local0. \"test\"\n}", normalizeIRule("\nwhen CLIENT_ACCEPTED {\r\n\tlog local0. \"test\"\r\n}\r\n"))
+	assert.NotEqual(t, normalizeIRule("when CLIENT_ACCEPTED {\n\tlog local0.\n}"), normalizeIRule("when CLIENT_ACCEPTED {\n    log local0.\n}"))
+}
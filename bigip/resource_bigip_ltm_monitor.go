@@ -3,10 +3,13 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"sort"
+	"strconv"
 	"strings"
+	"unicode"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmMonitor() *schema.Resource {
@@ -34,12 +37,13 @@ func resourceBigipLtmMonitor() *schema.Resource {
 				Required:     true,
 				ValidateFunc: validateParent,
 				ForceNew:     true,
-				Description:  "Existing monitor to inherit from. Must be one of /Common/http, /Common/https, /Common/icmp or /Common/gateway-icmp.",
+				Description:  "Existing monitor to inherit from. Must be one of /Common/http, /Common/https, /Common/icmp, /Common/gateway-icmp, /Common/tcp, /Common/tcp-half-open, /Common/external, /Common/ldap or /Common/smtp.",
 			},
 			"defaults_from": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Existing monitor to inherit from. Must be one of /Common/http, /Common/https, /Common/icmp or /Common/gateway-icmp.",
+				Computed:    true,
+				Description: "Existing monitor to inherit from. Must be one of /Common/http, /Common/https, /Common/icmp, /Common/gateway-icmp, /Common/tcp, /Common/tcp-half-open, /Common/external, /Common/ldap or /Common/smtp.",
 			},
 
 			"interval": {
@@ -88,6 +92,14 @@ func resourceBigipLtmMonitor() *schema.Resource {
 				Default:  "disabled",
 			},
 
+			"logging": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Log the result of each check of the monitor, enabled or disabled",
+				ValidateFunc: validateEnabledDisabled,
+			},
+
 			"manual_resume": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -113,110 +125,200 @@ func resourceBigipLtmMonitor() *schema.Resource {
 				Default:     "*:*",
 				Description: "Alias for the destination",
 			},
+
+			"ssl_profile": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Server SSL profile used by https monitors, e.g. /Common/serverssl",
+			},
+
+			"run": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Script file run by external monitors, e.g. /Common/my_script",
+			},
+
+			"args": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Command line arguments passed to the external monitor script",
+			},
+
+			"user_defined": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Variables passed to the external monitor script as environment variables",
+			},
+
+			"base": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Location in the LDAP tree to search from, for ldap monitors",
+			},
+
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "LDAP search filter, for ldap monitors",
+			},
+
+			"username": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User name the monitor authenticates with",
+			},
+
+			"password": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password the monitor authenticates with",
+			},
+
+			"domain": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Domain name sent in the HELO command, for smtp monitors",
+			},
 		},
 	}
 }
 
 func resourceBigipLtmMonitorCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Get("name").(string)
 
 	log.Println("[INFO] Creating monitor " + name + " :: " + monitorParent(d.Get("parent").(string)))
 
-	err := client.CreateMonitor(
-		name,
-		monitorParent(d.Get("parent").(string)),
-		d.Get("defaults_from").(string),
-		d.Get("interval").(int),
-		d.Get("timeout").(int),
-		d.Get("send").(string),
-		d.Get("receive").(string),
-		d.Get("receive_disable").(string),
-	)
+	err := withTransaction(client, func(client *bigipClient) error {
+		err := client.CreateMonitor(
+			name,
+			monitorParent(d.Get("parent").(string)),
+			d.Get("defaults_from").(string),
+			d.Get("interval").(int),
+			d.Get("timeout").(int),
+			d.Get("send").(string),
+			d.Get("receive").(string),
+			d.Get("receive_disable").(string),
+		)
+		if err != nil {
+			log.Printf("[ERROR] Unable to Create Monitor (%s) (%v) ", name, err)
+			return err
+		}
+		return modifyMonitor(client, name, d)
+	})
 	if err != nil {
-		log.Printf("[ERROR] Unable to Create Monitor (%s) (%v) ", name, err)
 		return err
 	}
 
 	d.SetId(name)
 
-	resourceBigipLtmMonitorUpdate(d, meta)
 	return resourceBigipLtmMonitorRead(d, meta)
 }
 
 func resourceBigipLtmMonitorRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
-	monitors, err := client.Monitors()
+	m, parent, err := getMonitor(client, name, d.Get("parent").(string))
 	if err != nil {
 		log.Printf("[ERROR] Unable to retrieve Monitor (%s) (%v) ", name, err)
 		return err
 	}
-	if monitors == nil {
+	if m == nil {
 		log.Printf("[WARN] Monitor (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
-	for _, m := range monitors {
-		if m.FullPath == name {
-			d.Set("defaults_from", m.DefaultsFrom)
-			d.Set("interval", m.Interval)
-			d.Set("timeout", m.Timeout)
-			if err := d.Set("send", m.SendString); err != nil {
-				return fmt.Errorf("[DEBUG] Error saving SendString to state for Monitor (%s): %s", d.Id(), err)
-			}
-			if err := d.Set("receive", m.ReceiveString); err != nil {
-				return fmt.Errorf("[DEBUG] Error saving ReceiveString to state for Monitor (%s): %s", d.Id(), err)
-			}
-			d.Set("receive_disable", m.ReceiveDisable)
-			d.Set("reverse", m.Reverse)
-			d.Set("transparent", m.Transparent)
-			d.Set("ip_dscp", m.IPDSCP)
-			d.Set("time_until_up", m.TimeUntilUp)
-			d.Set("manual_resume", m.ManualResume)
-			if err := d.Set("destination", m.Destination); err != nil {
-				return fmt.Errorf("[DEBUG] Error saving Destination to state for Monitor (%s): %s", d.Id(), err)
-			}
-			d.Set("name", name)
-			return nil
-		}
+
+	d.Set("parent", parent)
+	d.Set("defaults_from", m.DefaultsFrom)
+	d.Set("interval", m.Interval)
+	d.Set("timeout", m.Timeout)
+	if err := d.Set("send", m.SendString); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving SendString to state for Monitor (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("receive", m.ReceiveString); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving ReceiveString to state for Monitor (%s): %s", d.Id(), err)
 	}
-	return fmt.Errorf("Couldn't find monitor %s", name)
+	d.Set("receive_disable", m.ReceiveDisable)
+	d.Set("reverse", m.Reverse)
+	d.Set("transparent", m.Transparent)
+	d.Set("logging", m.Logging)
+	d.Set("ip_dscp", m.IPDSCP)
+	d.Set("time_until_up", m.TimeUntilUp)
+	d.Set("manual_resume", m.ManualResume)
+	if err := d.Set("destination", m.Destination); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Destination to state for Monitor (%s): %s", d.Id(), err)
+	}
+	d.Set("ssl_profile", m.SSLProfile)
+	d.Set("run", m.Run)
+	d.Set("args", m.Args)
+	if err := d.Set("user_defined", m.UserDefined); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving UserDefined to state for Monitor (%s): %s", d.Id(), err)
+	}
+	d.Set("base", m.Base)
+	d.Set("filter", m.Filter)
+	d.Set("username", m.Username)
+	d.Set("domain", m.Domain)
+	d.Set("name", name)
+	return nil
+}
 
+// getMonitor looks up a monitor by full path. When the parent is unknown, as
+// on import, every supported monitor type is searched. Returns the monitor
+// and its parent, or a nil monitor if it does not exist.
+func getMonitor(client *bigipClient, name, parent string) (*bigip.Monitor, string, error) {
+	parents := []string{parent}
+	if parent == "" {
+		parents = monitorParents
+	}
+	for _, p := range parents {
+		m, err := client.GetMonitor(name, monitorParent(p))
+		if err != nil {
+			return nil, "", err
+		}
+		if m != nil {
+			return m, p, nil
+		}
+	}
+	return nil, "", nil
 }
 
 func resourceBigipLtmMonitorExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Fetching monitor " + name)
 
-	monitors, err := client.Monitors()
+	m, _, err := getMonitor(client, name, d.Get("parent").(string))
 	if err != nil {
 		log.Printf("[ERROR] Unable to retrieve Monitor (%s) (%v) ", name, err)
 		return false, err
 	}
-	if monitors == nil {
+	if m == nil {
 		log.Printf("[WARN] Monitor (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return false, nil
 	}
-	for _, m := range monitors {
-		if m.FullPath == name {
-			return true, nil
-		}
-	}
-
-	return false, nil
+	return true, nil
 }
 
 func resourceBigipLtmMonitorUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
-	name := d.Id()
+	if err := modifyMonitor(client, d.Id(), d); err != nil {
+		return err
+	}
 
+	return resourceBigipLtmMonitorRead(d, meta)
+}
+
+// modifyMonitor sends the configured settings of the monitor to the BigIP.
+func modifyMonitor(client *bigipClient, name string, d *schema.ResourceData) error {
 	m := &bigip.Monitor{
 		Interval:       d.Get("interval").(int),
 		Timeout:        d.Get("timeout").(int),
@@ -225,23 +327,33 @@ func resourceBigipLtmMonitorUpdate(d *schema.ResourceData, meta interface{}) err
 		ReceiveDisable: d.Get("receive_disable").(string),
 		Reverse:        d.Get("reverse").(string),
 		Transparent:    d.Get("transparent").(string),
+		Logging:        d.Get("logging").(string),
 		IPDSCP:         d.Get("ip_dscp").(int),
 		TimeUntilUp:    d.Get("time_until_up").(int),
 		ManualResume:   d.Get("manual_resume").(string),
 		Destination:    d.Get("destination").(string),
+		SSLProfile:     d.Get("ssl_profile").(string),
+		Run:            d.Get("run").(string),
+		Args:           d.Get("args").(string),
+		Base:           d.Get("base").(string),
+		Filter:         d.Get("filter").(string),
+		Username:       d.Get("username").(string),
+		Password:       d.Get("password").(string),
+		Domain:         d.Get("domain").(string),
 	}
+	o, n := d.GetChange("user_defined")
+	m.UserDefined = monitorUserDefined(o.(map[string]interface{}), n.(map[string]interface{}))
 
 	err := client.ModifyMonitor(name, monitorParent(d.Get("parent").(string)), m)
 	if err != nil {
 		log.Printf("[ERROR] Unable to Update Monitor (%s) (%v) ", name, err)
 		return err
 	}
-
-	return resourceBigipLtmMonitorRead(d, meta)
+	return nil
 }
 
 func resourceBigipLtmMonitorDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	parent := monitorParent(d.Get("parent").(string))
 	log.Println("[Info] Deleting monitor " + name + "::" + parent)
@@ -254,15 +366,182 @@ func resourceBigipLtmMonitorDelete(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
+// monitorUserDefined returns the external monitor variables to send to the
+// BigIP. Variables removed from the configuration are set to none, which
+// deletes them.
+func monitorUserDefined(old, new map[string]interface{}) map[string]string {
+	vars := make(map[string]string, len(old)+len(new))
+	for name := range old {
+		vars[name] = "none"
+	}
+	for name, value := range new {
+		vars[name] = value.(string)
+	}
+	return vars
+}
+
+// monitorParents are the monitor types supported by bigip_ltm_monitor
+var monitorParents = []string{"/Common/http", "/Common/https", "/Common/icmp", "/Common/gateway-icmp", "/Common/tcp", "/Common/tcp-half-open", "/Common/external", "/Common/ldap", "/Common/smtp"}
+
 func validateParent(v interface{}, k string) ([]string, []error) {
 	p := v.(string)
-	if p == "/Common/http" || p == "/Common/https" || p == "/Common/icmp" || p == "/Common/gateway-icmp" || p == "/Common/tcp" || p == "/Common/tcp-half-open" {
-		return nil, nil
+	for _, parent := range monitorParents {
+		if p == parent {
+			return nil, nil
+		}
 	}
 
-	return nil, []error{fmt.Errorf("parent must be one of /Common/http, /Common/https, /Common/icmp, /Common/gateway-icmp, /Common/tcp-half-open,  or /Common/tcp")}
+	return nil, []error{fmt.Errorf("parent must be one of %s", strings.Join(monitorParents, ", "))}
 }
 
 func monitorParent(s string) string {
 	return strings.TrimPrefix(s, "/Common/")
 }
+
+// validateMonitorsExist checks that the monitors referenced by a monitor rule
+// such as "/Common/http and /Common/tcp" exist, when the provider is
+// configured with validate_monitors. Monitors given without a partition are
+// looked up in the default partition of the provider, where they are sent to.
+func validateMonitorsExist(client *bigipClient, rule string) error {
+	if !client.config.ValidateMonitors {
+		return nil
+	}
+	names := monitorRuleFields(rule)
+	if len(names) == 0 {
+		return nil
+	}
+
+	types, err := client.MonitorTypes()
+	if err != nil {
+		return fmt.Errorf("Error retrieving monitor types: %v", err)
+	}
+	var available, unlisted []string
+	for _, t := range types {
+		monitors, err := client.MonitorsOfType(t)
+		if err != nil {
+			// Types of modules that are not provisioned cannot be listed
+			log.Printf("[WARN] Unable to retrieve %s monitors: %v", t, err)
+			unlisted = append(unlisted, t)
+			continue
+		}
+		for _, m := range monitors {
+			available = append(available, m.FullPath)
+		}
+	}
+	sort.Strings(available)
+
+	exists := func(name string) bool {
+		i := sort.SearchStrings(available, name)
+		return i < len(available) && available[i] == name
+	}
+	partition := client.config.partition()
+	for _, name := range names {
+		name = monitorFullPath(partition, name)
+		if exists(name) {
+			continue
+		}
+		if len(unlisted) > 0 {
+			log.Printf("[WARN] Monitor %s not found, it may be of a type that could not be listed: %s", name, strings.Join(unlisted, ", "))
+			continue
+		}
+		return fmt.Errorf("Monitor %s does not exist, available monitors are: %s", name, strings.Join(available, ", "))
+	}
+	return nil
+}
+
+// monitorRuleFields returns the monitors referenced by a monitor rule as
+// written, without resolving their partition.
+func monitorRuleFields(rule string) []string {
+	var names []string
+	for _, field := range strings.FieldsFunc(rule, func(r rune) bool {
+		return unicode.IsSpace(r) || r == '{' || r == '}'
+	}) {
+		if _, err := strconv.Atoi(field); err == nil {
+			continue
+		}
+		if isMonitorDestination(field) {
+			continue
+		}
+		switch field {
+		case "and", "min", "of", "default", "inherit", "none":
+			continue
+		}
+		names = append(names, field)
+	}
+	return names
+}
+
+// isMonitorDestination reports whether a monitor rule field is a destination
+// such as *:443 or 10.0.0.1:80 that overrides the address the preceding
+// monitor checks.
+func isMonitorDestination(field string) bool {
+	i := strings.LastIndex(field, ":")
+	if i <= 0 || strings.HasPrefix(field, "/") {
+		return false
+	}
+	port := field[i+1:]
+	if port == "*" {
+		return true
+	}
+	_, err := strconv.Atoi(port)
+	return err == nil
+}
+
+// monitorFullPath returns the full path of a monitor, monitors given without
+// a partition, e.g. http, are the ones in partition, normally the default
+// partition of the provider. They are returned as they are when partition is
+// empty, as are the default, inherit and none keywords.
+func monitorFullPath(partition, name string) string {
+	switch name {
+	case "default", "inherit", "none":
+		return name
+	}
+	if strings.HasPrefix(name, "/") || partition == "" {
+		return name
+	}
+	return "/" + partition + "/" + name
+}
+
+// monitorRuleTerms splits monitor rule fields into monitors by their full
+// path in partition, keeping each monitor together with its destination,
+// e.g. "/Common/http *:443".
+func monitorRuleTerms(fields []string, partition string) []string {
+	var terms []string
+	for _, field := range fields {
+		if isMonitorDestination(field) && len(terms) > 0 {
+			terms[len(terms)-1] += " " + field
+			continue
+		}
+		terms = append(terms, monitorFullPath(partition, field))
+	}
+	return terms
+}
+
+// normalizeMonitorRule formats a monitor rule the same way regardless of
+// whitespace, the order of its monitors and, with a partition to resolve the
+// monitors given without one in, whether they are given with their
+// partition, so rules read back from the BigIP compare equal to the
+// configured ones, e.g.
+// "min 1 of { /Common/icmp /Common/gateway_icmp }" or "/Common/http *:443 and /Common/tcp"
+func normalizeMonitorRule(rule, partition string) string {
+	fields := strings.Fields(strings.NewReplacer("{", " { ", "}", " } ").Replace(rule))
+	if len(fields) == 0 {
+		return ""
+	}
+
+	if len(fields) >= 5 && fields[0] == "min" && fields[2] == "of" && fields[3] == "{" && fields[len(fields)-1] == "}" {
+		terms := monitorRuleTerms(fields[4:len(fields)-1], partition)
+		sort.Strings(terms)
+		return fmt.Sprintf("min %s of { %s }", fields[1], strings.Join(terms, " "))
+	}
+
+	var names []string
+	for _, field := range fields {
+		if field != "and" {
+			names = append(names, field)
+		}
+	}
+	terms := monitorRuleTerms(names, partition)
+	sort.Strings(terms)
+	return strings.Join(terms, " and ")
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -59,6 +58,44 @@ func TestAccBigipLtmMonitor_create(t *testing.T) {
 	})
 }
 
+var TEST_HTTPS_MONITOR_RESOURCE = `
+resource "bigip_ltm_monitor" "test-https-monitor" {
+	name = "` + TEST_MONITOR_NAME + `"
+	parent = "/Common/https"
+	send = "GET /health\r\n"
+	receive = "200 OK"
+	timeout = 16
+	interval = 5
+	ssl_profile = "/Common/serverssl"
+}
+`
+
+func TestAccBigipLtmMonitor_https(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testMonitorsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: TEST_HTTPS_MONITOR_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckMonitorExists(TEST_MONITOR_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-https-monitor", "parent", "/Common/https"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-https-monitor", "ssl_profile", "/Common/serverssl"),
+				),
+			},
+			{
+				ResourceName:      "bigip_ltm_monitor.test-https-monitor",
+				ImportState:       true,
+				ImportStateId:     TEST_MONITOR_NAME,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
 func TestAccBigipLtmMonitor_import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -82,7 +119,7 @@ func TestAccBigipLtmMonitor_import(t *testing.T) {
 
 func testCheckMonitorExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		monitors, err := client.Monitors()
 		if err != nil {
@@ -99,7 +136,7 @@ func testCheckMonitorExists(name string) resource.TestCheckFunc {
 }
 
 func testMonitorsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	monitors, err := client.Monitors()
 	if err != nil {
@@ -0,0 +1,286 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitorRuleFields(t *testing.T) {
+	assert.Equal(t, []string{"/Common/http"}, monitorRuleFields("/Common/http"))
+	assert.Equal(t, []string{"/Common/http", "tcp"}, monitorRuleFields("/Common/http and tcp"))
+	assert.Equal(t, []string{"/Common/http", "/Tenant1/tcp"}, monitorRuleFields("min 1 of { /Common/http /Tenant1/tcp }"))
+	assert.Nil(t, monitorRuleFields("default"))
+	assert.Nil(t, monitorRuleFields("inherit"))
+	assert.Nil(t, monitorRuleFields("none"))
+	assert.Equal(t, []string{"/Common/http", "/Common/tcp"}, monitorRuleFields("/Common/http *:443 and /Common/tcp 10.0.0.1:80"))
+}
+
+func TestMonitorFullPath(t *testing.T) {
+	assert.Equal(t, "/Common/http", monitorFullPath("Common", "http"))
+	assert.Equal(t, "/Tenant1/http", monitorFullPath("Tenant1", "http"))
+	assert.Equal(t, "http", monitorFullPath("", "http"))
+	assert.Equal(t, "/Common/http", monitorFullPath("Tenant1", "/Common/http"))
+	assert.Equal(t, "/Tenant1/http", monitorFullPath("Common", "/Tenant1/http"))
+	assert.Equal(t, "inherit", monitorFullPath("Common", "inherit"))
+}
+
+func TestNormalizeMonitorRule(t *testing.T) {
+	//monitor rule => normalized rule
+	data := map[string]string{
+		"":                                "",
+		"/Common/http":                    "/Common/http",
+		" /Common/http ":                  "/Common/http",
+		"/Common/tcp and /Common/http":    "/Common/http and /Common/tcp",
+		"/Common/http  and  /Common/tcp ": "/Common/http and /Common/tcp",
+		"min 1 of { /Common/icmp /Common/gateway_icmp }":        "min 1 of { /Common/gateway_icmp /Common/icmp }",
+		"min 1 of {/Common/gateway_icmp /Common/icmp}":          "min 1 of { /Common/gateway_icmp /Common/icmp }",
+		"min 2 of {  /Common/tcp /Common/http /Common/icmp  } ": "min 2 of { /Common/http /Common/icmp /Common/tcp }",
+		"/Common/http *:443":                                    "/Common/http *:443",
+		"/Common/tcp and  /Common/http  *:443":                  "/Common/http *:443 and /Common/tcp",
+		"min 1 of { /Common/tcp 10.0.0.1:80 /Common/http *:* }": "min 1 of { /Common/http *:* /Common/tcp 10.0.0.1:80 }",
+		"http":                                   "/Common/http",
+		"tcp and /Common/http":                   "/Common/http and /Common/tcp",
+		"http *:443 and /Tenant1/tcp":            "/Common/http *:443 and /Tenant1/tcp",
+		"min 1 of { icmp /Common/gateway_icmp }": "min 1 of { /Common/gateway_icmp /Common/icmp }",
+		"default":                                "default",
+		"none":                                   "none",
+	}
+	for rule, expected := range data {
+		assert.Equal(t, expected, normalizeMonitorRule(rule, "Common"), "%q was not normalized", rule)
+	}
+	// Monitors given without a partition are in the given one, or kept as
+	// they are without one
+	assert.Equal(t, "/Common/http and /Tenant1/tcp", normalizeMonitorRule("tcp and /Common/http", "Tenant1"))
+	assert.Equal(t, "/Common/http and tcp", normalizeMonitorRule("tcp  and /Common/http", ""))
+}
+
+func TestMonitorUserDefined(t *testing.T) {
+	assert.Equal(t, map[string]string{}, monitorUserDefined(map[string]interface{}{}, map[string]interface{}{}))
+	assert.Equal(t, map[string]string{"HOST": "a", "PATH": "none"}, monitorUserDefined(
+		map[string]interface{}{"HOST": "b", "PATH": "/"},
+		map[string]interface{}{"HOST": "a"},
+	))
+}
+
+func TestAccBigipLtmMonitorExternal(t *testing.T) {
+	setup()
+	var puts []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/external", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/external/~Common~test-monitor", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			b, _ := ioutil.ReadAll(r.Body)
+			defer r.Body.Close()
+			puts = append(puts, string(b))
+		}
+		fmt.Fprintf(w, `{"name":"test-monitor","partition":"Common","defaultsFrom":"/Common/external","interval":3,"timeout":16,
+			"destination":"*:*","transparent":"disabled","manualResume":"disabled","run":"/Common/check.sh","args":"-v",
+			"apiRawValues":{"userDefined PATH":"/health","userDefined HOST":"example.com"}}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_monitor" "test-monitor" {
+						name = "/Common/test-monitor"
+						parent = "/Common/external"
+						run = "/Common/check.sh"
+						args = "-v"
+						user_defined = {
+							PATH = "/health"
+							HOST = "example.com"
+						}
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "user_defined.%", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "user_defined.HOST", "example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "user_defined.PATH", "/health"),
+				),
+			},
+		},
+	})
+	assert.Contains(t, puts[0], `"run":"/Common/check.sh","args":"-v","userDefined":"HOST example.com PATH /health"`)
+}
+
+func TestAccBigipLtmMonitorDefaultsFrom(t *testing.T) {
+	setup()
+	var posts []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/ldap", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			b, _ := ioutil.ReadAll(r.Body)
+			defer r.Body.Close()
+			posts = append(posts, string(b))
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/ldap/~Common~test-monitor", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-monitor","partition":"Common","defaultsFrom":"/Common/custom-ldap","interval":3,"timeout":16,
+			"destination":"*:*","transparent":"disabled","manualResume":"disabled"}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_monitor" "test-monitor" {
+						name = "/Common/test-monitor"
+						parent = "/Common/ldap"
+						defaults_from = "/Common/custom-ldap"
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "defaults_from", "/Common/custom-ldap"),
+			},
+		},
+	})
+	assert.Len(t, posts, 1)
+	assert.Contains(t, posts[0], `"defaultsFrom":"/Common/custom-ldap"`)
+}
+
+func testBigipLtmMonitorLogging(url, logging string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_monitor" "test-monitor" {
+			name = "/Common/test-monitor"
+			parent = "/Common/http"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, logging, url)
+}
+
+func TestAccBigipLtmMonitorLogging(t *testing.T) {
+	setup()
+	var sent []string
+	monitor := map[string]interface{}{"name": "test-monitor", "partition": "Common", "defaultsFrom": "/Common/http", "logging": "disabled"}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/http", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/http/~Common~test-monitor", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			update := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&update)
+			sent = append(sent, fmt.Sprint(update["logging"]))
+			for k, v := range update {
+				monitor[k] = v
+			}
+		}
+		json.NewEncoder(w).Encode(monitor)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				// Monitors inherit logging from their parent
+				Config: testBigipLtmMonitorLogging(server.URL, ``),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "logging", "disabled"),
+			},
+			{
+				Config: testBigipLtmMonitorLogging(server.URL, `logging = "enabled"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_monitor.test-monitor", "logging", "enabled"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"<nil>", "enabled"}, sent)
+}
+
+func TestValidateMonitorsExist(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[
+			{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/http?ver=13.1.0"}},
+			{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/udp?ver=13.1.0"}},
+			{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/dns?ver=13.1.0"}}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/http", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"http","partition":"Common","fullPath":"/Common/http"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/udp", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"udp-check","partition":"Common","fullPath":"/Common/udp-check"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/dns", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"dns-check","partition":"Tenant","fullPath":"/Tenant/dns-check"}]}`)
+	})
+
+	config := testRetryConfig(server.URL)
+	config.ValidateMonitors = true
+	config.DefaultPartition = "Tenant"
+	client, err := config.Client()
+	assert.Nil(t, err)
+
+	assert.Nil(t, validateMonitorsExist(client, "/Common/udp-check and /Tenant/dns-check"))
+	assert.Nil(t, validateMonitorsExist(client, "dns-check and /Common/http"))
+	assert.EqualError(t, validateMonitorsExist(client, "typo"),
+		"Monitor /Tenant/typo does not exist, available monitors are: /Common/http, /Common/udp-check, /Tenant/dns-check")
+	// Monitors without a partition are looked up where they are sent to
+	assert.EqualError(t, validateMonitorsExist(client, "dns-check and http"),
+		"Monitor /Tenant/http does not exist, available monitors are: /Common/http, /Common/udp-check, /Tenant/dns-check")
+}
+
+func TestValidateMonitorsExistUnlistedType(t *testing.T) {
+	setup()
+	defer teardown()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[
+			{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/http?ver=13.1.0"}},
+			{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/diameter?ver=13.1.0"}}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/http", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"http","partition":"Common","fullPath":"/Common/http"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/diameter", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprintf(w, `{"code":400,"message":"01070734:3: Configuration error: The module is not provisioned."}`)
+	})
+
+	config := testRetryConfig(server.URL)
+	config.ValidateMonitors = true
+	client, err := config.Client()
+	assert.Nil(t, err)
+
+	// The monitor may be one of the type that could not be listed
+	assert.Nil(t, validateMonitorsExist(client, "/Common/diameter-check"))
+}
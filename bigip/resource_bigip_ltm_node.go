@@ -2,22 +2,34 @@ package bigip
 
 import (
 	"fmt"
-	"log"
 	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
+// Matches IPv4 and IPv6 (optionally bracketed) node addresses with an optional %route_domain suffix
+var nodeAddressRegex = regexp.MustCompile(`^((?:[0-9]{1,3}\.){3}[0-9]{1,3}|[0-9a-fA-F]*:[0-9a-fA-F:.]*|\[[0-9a-fA-F]*:[0-9a-fA-F:.]*\])(?:%(\d+))?$`)
+
 func resourceBigipLtmNode() *schema.Resource {
-	return &schema.Resource{
+	return withIgnoreFields(&schema.Resource{
 		Create: resourceBigipLtmNodeCreate,
 		Read:   resourceBigipLtmNodeRead,
 		Update: resourceBigipLtmNodeUpdate,
 		Delete: resourceBigipLtmNodeDelete,
-		Exists: resourceBigipLtmNodeExists,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceBigipLtmNodeImport,
+		},
+		CustomizeDiff: resourceBigipLtmNodeCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Read:   schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -26,19 +38,45 @@ func resourceBigipLtmNode() *schema.Resource {
 				Required:     true,
 				Description:  "Name of the node",
 				ForceNew:     true,
-				ValidateFunc: validateF5Name,
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the node",
 			},
 
+			"partition": partitionSchema("node"),
+
 			"address": {
 				Type:        schema.TypeString,
 				Required:    true,
-				Description: "Address of the node",
-				ForceNew:    true,
+				Description: "Address of the node, changing the address of an IP node recreates it",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// The address is saved without its route domain
+					return sameNodeAddress(old, new, d.Get("route_domain").(int))
+				},
 			},
-			"rate_limit": {
+			"full_path": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Specifies the maximum number of connections per second allowed for a node or node address. The default value is 'disabled'.",
+				Computed:    true,
+				Description: "Full path of the node, /partition/name",
+			},
+			"route_domain": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Route domain of the node address",
+			},
+			"rate_limit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validateRateLimit,
+				Description:  "Specifies the maximum number of connections per second allowed for a node or node address, or disabled for no limit. The default value is 'disabled'.",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// The BigIP reports no limit as disabled
+					return nodeRateLimit(old) == nodeRateLimit(new)
+				},
 			},
 
 			"connection_limit": {
@@ -48,43 +86,139 @@ func resourceBigipLtmNode() *schema.Resource {
 				Default:     0,
 			},
 			"dynamic_ratio": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "Sets the dynamic ratio number for the node. Used for dynamic ratio load balancing. ",
-				Default:     0,
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Description:  "Sets the dynamic ratio number for the node, from 1 to 100. Used for dynamic ratio load balancing. The default value is 1.",
+				Default:      1,
+				ValidateFunc: validateIntRange(1, 100),
+			},
+			"ratio": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Sets the ratio weight of the node, from 1 to 65535. Used for ratio load balancing. The default value is 1.",
+				ValidateFunc: validateIntRange(1, 65535),
 			},
 			"monitor": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Specifies the name of the monitor or monitor rule that you want to associate with the node.",
+				Description: "Specifies the name of the monitor or monitor rule that you want to associate with the node, default or inherit to inherit the default node monitor or none for no monitor.",
+				// Monitors given without a partition are saved as they are
+				// written, the default partition they are in is not known here
+				StateFunc: func(v interface{}) string {
+					return normalizeNodeMonitor(v.(string), "")
+				},
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// The BigIP does not report a monitor for nodes set to none
+					return nodeMonitor(old, "") == nodeMonitor(new, "")
+				},
 			},
 			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "user-up",
+				Description:  "Marks the node enabled (user-up), disabled (user-disabled) or forced offline (user-down). The default value is user-up.",
+				ValidateFunc: validateStringValue([]string{"user-up", "user-disabled", "user-down", "enabled", "disabled", "offline"}),
+				StateFunc: func(v interface{}) string {
+					return normalizeNodeState(v.(string))
+				},
+			},
+			"cascade_state": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Apply changes of state to the pool members of the node as well, in every pool of the BigIP.",
+			},
+			"session": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Session status of the node as reported by the BigIP, e.g. monitor-enabled or user-disabled.",
+			},
+			"force_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Force the node offline and wait for its connections to drain before deleting it.",
+			},
+			"drain_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     300,
+				Description: "Seconds to wait for the connections of the node to drain when force_delete is set. The node is deleted once it elapses.",
+			},
+			"adopt_existing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Take over a node of the same name and address that already exists on the BigIP instead of failing to create it, its settings are then updated to the configuration.",
+			},
+			"wait_for_up": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait after creating the node until its monitors mark it up.",
+			},
+			"wait_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     300,
+				Description: "Seconds to wait for the node to come up when wait_for_up is set. Creating the node fails once it elapses.",
+			},
+			"refresh_trigger": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     "user-up",
-				Description: "Marks the node up or down. The default value is user-up.",
+				Description: "Any value, changing it makes the BigIP resolve the FQDN of the node again right away instead of at the next interval.",
+			},
+			"metadata": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "User defined name/value entries attached to the node",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"value": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"persist": {
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     false,
+							Description: "Save the entry in the configuration so it is kept across reboots and config-sync",
+						},
+					},
+				},
 			},
 			"fqdn": {
-				Type:     schema.TypeList,
-				Optional: true,
-				MaxItems: 1,
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				MaxItems:    1,
+				Description: "FQDN settings of the node. A node has a single FQDN, so only one fqdn block may be given.",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"address_family": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Specifies the node's address family. The default is 'unspecified', or IP-agnostic",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Computed:     true,
+							ValidateFunc: validateStringValue([]string{"ipv4", "ipv6", "all"}),
+							Description:  "Specifies the address family of the addresses the FQDN resolves to: ipv4, ipv6 or all. Changed in place.",
 						},
 						"name": {
 							Type:        schema.TypeString,
 							Optional:    true,
+							Computed:    true,
 							Description: "Specifies the fully qualified domain name of the node.",
 						},
 						"interval": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Default:     "3600",
-							Description: "Specifies the amount of time before sending the next DNS query.",
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "3600",
+							ValidateFunc: validateFQDNInterval,
+							Description:  "Specifies the amount of time in seconds before sending the next DNS query, or \"ttl\" to follow the TTL of the DNS record.",
 						},
 						"downinterval": {
 							Type:        schema.TypeInt,
@@ -95,182 +229,725 @@ func resourceBigipLtmNode() *schema.Resource {
 						"autopopulate": {
 							Type:        schema.TypeString,
 							Optional:    true,
-							Default:     "disabled",
-							Description: "Specifies whether the node should scale to the IP address set returned by DNS.",
+							Computed:    true,
+							Description: "Specifies whether the node should scale to the IP address set returned by DNS. The default is 'disabled'.",
+						},
+						"auto_populate": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Deprecated:  "Use autopopulate instead",
+							Description: "Deprecated alias of autopopulate.",
 						},
 					},
 				},
 			},
 		},
-	}
+	}, "description", "connection_limit", "dynamic_ratio", "ratio", "rate_limit", "monitor", "state")
 }
 
 func resourceBigipLtmNodeCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client, deadline := clientForOperation(meta.(*bigipClient), d, schema.TimeoutCreate)
 
-	name := d.Get("name").(string)
+	name, err := resourceFullPath(d, client, "Node")
+	if err != nil {
+		return err
+	}
 	address := d.Get("address").(string)
-	rate_limit := d.Get("rate_limit").(string)
-	connection_limit := d.Get("connection_limit").(int)
-	dynamic_ratio := d.Get("dynamic_ratio").(int)
-	monitor := d.Get("monitor").(string)
-	state := d.Get("state").(string)
-
-	r, _ := regexp.Compile("^((?:[0-9]{1,3}.){3}[0-9]{1,3})|(.*:.*)$")
-
-	log.Println("[INFO] Creating node " + name + "::" + address)
-	var err error
-	if r.MatchString(address) {
-		err = client.CreateNode(
-			name,
-			address,
-			rate_limit,
-			connection_limit,
-			dynamic_ratio,
-			monitor,
-			state,
-		)
+
+	if d.Get("adopt_existing").(bool) {
+		existing, err := client.GetNode(name)
+		if err != nil && !isNotFound(err) {
+			return fmt.Errorf("Error retrieving node %s: %v", name, err)
+		}
+		if existing != nil {
+			if !nodeHasAddress(existing, address) {
+				return fmt.Errorf("Node %s already exists with address %s, not %s, import it or change its name", name, nodeAddress(existing), address)
+			}
+			logNodef("INFO", "create", name, "Adopting existing node with address %s", address)
+			d.SetId(name)
+			return resourceBigipLtmNodeUpdate(d, meta)
+		}
+	}
+
+	node := &bigip.Node{
+		Name:            name,
+		Description:     nodeDescription(d),
+		RateLimit:       nodeRateLimit(d.Get("rate_limit").(string)),
+		ConnectionLimit: nodeConnectionLimit(d),
+		DynamicRatio:    d.Get("dynamic_ratio").(int),
+		Ratio:           d.Get("ratio").(int),
+		Monitor:         normalizeNodeMonitor(d.Get("monitor").(string), client.config.partition()),
+		Metadata:        nodeMetadata(d),
+	}
+	setNodeState(node, d.Get("state").(string))
+
+	if err := validateMonitorsExist(client, d.Get("monitor").(string)); err != nil {
+		return err
+	}
+
+	logNodef("INFO", "create", name, "Creating node with address %s", address)
+	if nodeAddressRegex.MatchString(address) {
+		node.Address = unbracketNodeAddress(address)
 	} else {
-		interval := d.Get("fqdn.0.interval").(string)
-		address_family := d.Get("fqdn.0.address_family").(string)
-		autopopulate := d.Get("fqdn.0.autopopulate").(string)
-		downinterval := d.Get("fqdn.0.downinterval").(int)
-
-		err = client.CreateFQDNNode(
-			name,
-			address,
-			rate_limit,
-			connection_limit,
-			dynamic_ratio,
-			monitor,
-			state,
-			interval,
-			address_family,
-			autopopulate,
-			downinterval,
-		)
+		node.FQDN.Name = address
+		node.FQDN.Interval = d.Get("fqdn.0.interval").(string)
+		node.FQDN.AddressFamily = d.Get("fqdn.0.address_family").(string)
+		node.FQDN.AutoPopulate = nodeFQDNAutopopulate(d)
+		node.FQDN.DownInterval = d.Get("fqdn.0.downinterval").(int)
 	}
 
+	err = client.AddNode(node)
 	if err != nil {
-		return fmt.Errorf("Error modifying node %s: %v", name, err)
+		return fmt.Errorf("Error creating node %s: %v", name, apiErrorDetails(err))
 	}
 
 	d.SetId(name)
 
+	if shouldCascadeState(d) {
+		if err := cascadeNodeState(client, name, d.Get("state").(string)); err != nil {
+			return err
+		}
+	}
+
+	if d.Get("wait_for_up").(bool) {
+		timeout := timeoutBefore(time.Duration(d.Get("wait_timeout").(int))*time.Second, deadline)
+		if err := waitForNodeUp(client, name, timeout); err != nil {
+			return err
+		}
+	}
+
 	return resourceBigipLtmNodeRead(d, meta)
 }
 
 func resourceBigipLtmNodeRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client, _ := clientForOperation(meta.(*bigipClient), d, schema.TimeoutRead)
 
 	name := d.Id()
 
-	log.Println("[INFO] Fetching node " + name)
+	logNodef("DEBUG", "read", name, "Fetching node")
 
 	node, err := client.GetNode(name)
-	if err != nil {
-		log.Printf("[ERROR] Unable to retrieve node %s  %v :", name, err)
+	if bigip.IsPermissionDenied(err) {
+		partition, _ := partitionAndName(client, name)
+		return permissionDeniedError(err, "node", name, partition)
+	}
+	if err != nil && !isNotFound(err) {
 		return err
 	}
+	// Nodes deleted out of band are detected here rather than by an Exists
+	// function, so a refresh takes a single request per node
 	if node == nil {
-		log.Printf("[WARN] Node (%s) not found, removing from state", d.Id())
+		logNodef("WARN", "read", name, "Node not found, removing from state")
 		d.SetId("")
 		return nil
 	}
+	// The nodes autopopulate creates for the addresses of an FQDN node come
+	// and go with its DNS records, only the FQDN node itself is managed
+	if node.Ephemeral == "true" {
+		return fmt.Errorf("Node %s is an ephemeral node of FQDN node %s created by autopopulate, manage the FQDN node instead", name, node.FQDN.Name)
+	}
+	// The BigIP reports the full path of the node, the ID is used when it does not
+	fullPath := node.FullPath
+	if fullPath == "" {
+		fullPath = name
+	}
+	if err := setNameAndPartition(d, client, fullPath); err != nil {
+		return err
+	}
+
 	if node.FQDN.Name != "" {
 		if err := d.Set("address", node.FQDN.Name); err != nil {
 			return fmt.Errorf("[DEBUG] Error saving address to state for Node (%s): %s", d.Id(), err)
 		}
 	} else {
-		// xxx.xxx.xxx.xxx(%x)
-		regex := regexp.MustCompile(`((?:[0-9]{1,3}\.){3}[0-9]{1,3})(?:\%\d+)?`)
-		address := regex.FindStringSubmatch(node.Address)
-		if err := d.Set("address", address[1]); err != nil {
+		address, routeDomain := reportedNodeAddress(node)
+		if err := d.Set("address", address); err != nil {
 			return fmt.Errorf("[DEBUG] Error saving address to state for Node (%s): %s", d.Id(), err)
 		}
+		d.Set("route_domain", routeDomain)
+	}
+	if err := d.Set("description", reportedNodeDescription(node)); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving description to state for Node (%s): %s", d.Id(), err)
 	}
-	d.Set("name", name)
-	if err := d.Set("monitor", node.Monitor); err != nil {
+	// The node reports its own monitor rule only, the monitors of the pools it
+	// is a member of are set on the pools and never show up as node drift.
+	// It is kept as configured when the configured monitors are the ones
+	// reported, e.g. http for /Common/http.
+	monitor := normalizeNodeMonitor(node.Monitor, "")
+	if configured := d.Get("monitor").(string); monitor != "" && normalizeNodeMonitor(configured, client.config.partition()) == monitor {
+		monitor = normalizeNodeMonitor(configured, "")
+	}
+	if err := d.Set("monitor", monitor); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Monitor to state for Node (%s): %s", d.Id(), err)
 	}
 	if err := d.Set("rate_limit", node.RateLimit); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Monitor to state for Node (%s): %s", d.Id(), err)
 	}
 
-	d.Set("connection_limit", node.ConnectionLimit)
-	d.Set("dynamic_ratio", node.DynamicRatio)
-	d.Set("fqdn.0.interval", node.FQDN.Interval)
-	d.Set("fqdn.0.downinterval", node.FQDN.DownInterval)
-	d.Set("fqdn.0.autopopulate", node.FQDN.AutoPopulate)
-	d.Set("fqdn.0.address_family", node.FQDN.AddressFamily)
+	d.Set("state", nodeState(node))
+	d.Set("session", node.Session)
+
+	d.Set("connection_limit", reportedConnectionLimit(node))
+	d.Set("dynamic_ratio", reportedDynamicRatio(node))
+	d.Set("ratio", node.Ratio)
+	if node.FQDN.Name != "" {
+		fqdn := map[string]interface{}{
+			"name":           node.FQDN.Name,
+			"interval":       node.FQDN.Interval,
+			"downinterval":   node.FQDN.DownInterval,
+			"autopopulate":   node.FQDN.AutoPopulate,
+			"auto_populate":  node.FQDN.AutoPopulate,
+			"address_family": node.FQDN.AddressFamily,
+		}
+		if err := d.Set("fqdn", []interface{}{fqdn}); err != nil {
+			return fmt.Errorf("[DEBUG] Error saving fqdn to state for Node (%s): %s", d.Id(), err)
+		}
+	} else {
+		d.Set("fqdn", []interface{}{})
+	}
+
+	var metadata []map[string]interface{}
+	if node.Metadata != nil {
+		for _, m := range *node.Metadata {
+			metadata = append(metadata, map[string]interface{}{
+				"name":    m.Name,
+				"value":   m.Value,
+				"persist": m.Persist == "true",
+			})
+		}
+	}
+	if err := d.Set("metadata", metadata); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving metadata to state for Node (%s): %s", d.Id(), err)
+	}
 
 	return nil
 }
 
-func resourceBigipLtmNodeExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+func resourceBigipLtmNodeUpdate(d *schema.ResourceData, meta interface{}) error {
+	client, _ := clientForOperation(meta.(*bigipClient), d, schema.TimeoutUpdate)
 
 	name := d.Id()
-	log.Println("[INFO] Fetching node " + name)
+	address := d.Get("address").(string)
+	// Only the changed attributes are sent, so attributes changed on the BigIP
+	// that are not managed here are kept. Adopted nodes get all of them but
+	// the ignored ones.
+	changed := func(key string) bool {
+		return !isFieldIgnored(d, key) && (d.IsNewResource() || d.HasChange(key))
+	}
+	node := &bigip.Node{}
+	if nodeAddressRegex.MatchString(address) {
+		if changed("address") {
+			node.Address = unbracketNodeAddress(address)
+		}
+		if !d.IsNewResource() && d.HasChange("refresh_trigger") {
+			logNodef("WARN", "update", name, "Ignoring refresh_trigger, the node has an IP address and no FQDN to resolve")
+		}
+	} else {
+		if changed("address") {
+			logNodef("INFO", "update", name, "Changing FQDN to %s", address)
+			node.FQDN.Name = address
+		}
+		if changed("fqdn.0.address_family") {
+			node.FQDN.AddressFamily = d.Get("fqdn.0.address_family").(string)
+			logNodef("INFO", "update", name, "Changing address family to %s", node.FQDN.AddressFamily)
+		}
+		if changed("fqdn.0.interval") {
+			node.FQDN.Interval = d.Get("fqdn.0.interval").(string)
+		}
+		if changed("fqdn.0.downinterval") {
+			node.FQDN.DownInterval = d.Get("fqdn.0.downinterval").(int)
+		}
+		if changed("fqdn.0.autopopulate") || changed("fqdn.0.auto_populate") {
+			node.FQDN.AutoPopulate = nodeFQDNAutopopulate(d)
+		}
+		// Setting the FQDN of the node again makes the BigIP send a DNS
+		// query for it right away. New nodes are resolved anyway.
+		if !d.IsNewResource() && d.HasChange("refresh_trigger") {
+			logNodef("INFO", "update", name, "Forcing DNS resolution of %s", address)
+			node.FQDN.Name = address
+			node.FQDN.Interval = d.Get("fqdn.0.interval").(string)
+		}
+	}
+	if changed("description") {
+		node.Description = nodeDescription(d)
+	}
+	if changed("connection_limit") {
+		node.ConnectionLimit = nodeConnectionLimit(d)
+	}
+	if changed("dynamic_ratio") {
+		node.DynamicRatio = d.Get("dynamic_ratio").(int)
+	}
+	if changed("ratio") {
+		node.Ratio = d.Get("ratio").(int)
+	}
+	if changed("rate_limit") {
+		node.RateLimit = nodeRateLimit(d.Get("rate_limit").(string))
+	}
+	if changed("metadata") {
+		node.Metadata = nodeMetadata(d)
+	}
+	if changed("state") {
+		setNodeState(node, d.Get("state").(string))
+	}
+	if changed("monitor") {
+		node.Monitor = nodeMonitor(d.Get("monitor").(string), client.config.partition())
+		if err := validateMonitorsExist(client, d.Get("monitor").(string)); err != nil {
+			return err
+		}
+	}
 
-	node, err := client.GetNode(name)
+	err := client.PatchNode(name, node)
 	if err != nil {
-		log.Printf("[ERROR] Unable to retrieve node %s  %v :", name, err)
-		return false, err
+		return fmt.Errorf("Error modifying node %s: %v", name, apiErrorDetails(err))
 	}
 
-	if node == nil {
-		log.Printf("[WARN] node (%s) not found, removing from state", d.Id())
-		return false, nil
+	if shouldCascadeState(d) {
+		fullPath := d.Get("full_path").(string)
+		if fullPath == "" {
+			fullPath = name
+		}
+		if err := cascadeNodeState(client, fullPath, d.Get("state").(string)); err != nil {
+			return err
+		}
 	}
-	return node != nil, nil
+	return resourceBigipLtmNodeRead(d, meta)
 }
 
-func resourceBigipLtmNodeUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+// shouldCascadeState reports whether the state of the node is applied to its
+// pool members, when cascade_state is set and the state changes or a node is
+// created or adopted in another state than user-up.
+func shouldCascadeState(d *schema.ResourceData) bool {
+	if !d.Get("cascade_state").(bool) {
+		return false
+	}
+	if d.IsNewResource() {
+		return normalizeNodeState(d.Get("state").(string)) != "user-up"
+	}
+	return d.HasChange("state")
+}
 
-	name := d.Id()
-	address := d.Get("address").(string)
-	r, _ := regexp.Compile("^((?:[0-9]{1,3}.){3}[0-9]{1,3})|(.*:.*)$")
+// cascadeNodeState sets the pool members of the node with the full path node
+// to state, in every pool of the BigIP. Only the state of the members is
+// sent, their other settings are kept.
+func cascadeNodeState(client *bigipClient, node, state string) error {
+	s := nodeStates[normalizeNodeState(state)]
+	pools, err := client.Pools()
+	if err != nil {
+		return fmt.Errorf("Error retrieving pools to cascade the state of node %s: %v", node, err)
+	}
+	for _, pool := range pools.Pools {
+		members, err := client.PoolMembers(pool.FullPath)
+		if err != nil {
+			return fmt.Errorf("Error retrieving members of pool %s to cascade the state of node %s: %v", pool.FullPath, node, err)
+		}
+		for _, m := range members.PoolMembers {
+			if !isNodePoolMember(node, m.FullPath) {
+				continue
+			}
+			logNodef("INFO", "update", node, "Setting pool member %s of pool %s to %s", m.FullPath, pool.FullPath, state)
+			member := &bigip.PoolMember{FullPath: m.FullPath, State: s.state, Session: s.session}
+			if err := client.PatchPoolMember(pool.FullPath, member); err != nil {
+				return fmt.Errorf("Error cascading the state of node %s to pool member %s of pool %s: %v", node, m.FullPath, pool.FullPath, apiErrorDetails(err))
+			}
+		}
+	}
+	return nil
+}
 
-	var node *bigip.Node
-	if r.MatchString(address) {
-		node = &bigip.Node{
-			Address:         address,
-			ConnectionLimit: d.Get("connection_limit").(int),
-			DynamicRatio:    d.Get("dynamic_ratio").(int),
-			Monitor:         d.Get("monitor").(string),
-			RateLimit:       d.Get("rate_limit").(string),
-			State:           d.Get("state").(string),
+// isNodePoolMember reports whether the pool member with the full path member
+// belongs to the node with the full path node. Members are named after their
+// node and port, node:port, or node.port for nodes named after an IPv6
+// address.
+func isNodePoolMember(node, member string) bool {
+	if !strings.HasPrefix(member, node) {
+		return false
+	}
+	separator := ":"
+	if strings.Contains(node, ":") {
+		separator = "."
+	}
+	port := strings.TrimPrefix(member[len(node):], separator)
+	if port == member[len(node):] {
+		return false
+	}
+	_, err := strconv.ParseUint(port, 10, 16)
+	return err == nil
+}
+
+// resourceBigipLtmNodeCustomizeDiff recreates the node when its address
+// changes, unless both the old and the new address are FQDNs, which can be
+// changed in place, or only the route domain is written differently. It also
+// checks that the fqdn block is given for FQDN nodes only, and that nodes
+// waited for have a monitor to mark them up.
+func resourceBigipLtmNodeCustomizeDiff(d *schema.ResourceDiff, meta interface{}) error {
+	if d.Get("wait_for_up").(bool) && d.NewValueKnown("monitor") && nodeMonitor(d.Get("monitor").(string), "") == "none" {
+		// Nodes without a monitor stay unchecked and are never marked up
+		return fmt.Errorf("wait_for_up needs a monitor, node %s has none", d.Get("name").(string))
+	}
+	if d.Id() != "" && d.HasChange("address") {
+		o, n := d.GetChange("address")
+		if sameNodeAddress(o.(string), n.(string), d.Get("route_domain").(int)) {
+			// The address differs only by how its route domain is written,
+			// which the DiffSuppressFunc of address already ignores
+			return nil
 		}
-	} else {
-		node = &bigip.Node{
-			ConnectionLimit: d.Get("connection_limit").(int),
-			DynamicRatio:    d.Get("dynamic_ratio").(int),
-			Monitor:         d.Get("monitor").(string),
-			RateLimit:       d.Get("rate_limit").(string),
-			State:           d.Get("state").(string),
+		if nodeAddressRegex.MatchString(o.(string)) || nodeAddressRegex.MatchString(n.(string)) {
+			// Pool members only follow the new node when they reference its
+			// full_path, which is unknown until the node is recreated
+			logNodef("WARN", "plan", d.Id(), "Node is recreated because its address changes from %s to %s, "+
+				"pool members referencing it by name rather than full_path are not recreated with it", o, n)
+			// The fqdn block is checked when the diff of the new node is computed
+			return d.ForceNew("address")
 		}
+	}
+	if !d.NewValueKnown("address") {
+		return nil
+	}
+	return validateNodeFQDN(d.Get("address").(string), len(d.Get("fqdn").([]interface{})))
+}
 
-		err := client.ModifyNode(name, node)
-		if err != nil {
-			return fmt.Errorf("Error modifying node %s: %v", name, err)
+// normalizeNodeMonitor normalizes the monitor rule of a node, resolving the
+// monitors given without a partition in partition unless it is empty. The
+// special default and none monitors are reported by the BigIP as full paths
+// but are configured without a partition. inherit is an alias of default.
+func normalizeNodeMonitor(monitor, partition string) string {
+	monitor = normalizeMonitorRule(monitor, partition)
+	switch monitor {
+	case "/Common/default", "inherit":
+		return "default"
+	case "/Common/none":
+		return "none"
+	}
+	return monitor
+}
+
+// nodeMonitor returns the monitor to send to the BigIP, removing the monitor
+// of a node needs an explicit none.
+func nodeMonitor(monitor, partition string) string {
+	monitor = normalizeNodeMonitor(monitor, partition)
+	if monitor == "" {
+		return "none"
+	}
+	return monitor
+}
+
+// nodeFQDNAutopopulate returns the autopopulate setting of an FQDN node,
+// given as autopopulate or as its deprecated alias auto_populate. When only
+// the alias changes, its value is used over the one kept in state.
+func nodeFQDNAutopopulate(d *schema.ResourceData) string {
+	autopopulate := d.Get("fqdn.0.autopopulate").(string)
+	if autopopulate == "" || (!d.IsNewResource() && !d.HasChange("fqdn.0.autopopulate") && d.HasChange("fqdn.0.auto_populate")) {
+		autopopulate = d.Get("fqdn.0.auto_populate").(string)
+	}
+	if autopopulate == "" {
+		autopopulate = "disabled"
+	}
+	return autopopulate
+}
+
+// nodeRateLimit returns the rate limit to send to the BigIP, a rate limit of
+// 0 or no rate limit is disabled.
+func nodeRateLimit(rateLimit string) string {
+	if rateLimit == "" || rateLimit == "0" {
+		return "disabled"
+	}
+	return rateLimit
+}
+
+// nodeConnectionLimit returns the connection limit to send to the BigIP, 0 is
+// sent as well so that removing a limit takes effect.
+func nodeConnectionLimit(d *schema.ResourceData) *int {
+	connectionLimit := d.Get("connection_limit").(int)
+	return &connectionLimit
+}
+
+// reportedConnectionLimit returns the connection limit of node, 0 when the
+// BigIP omits it because no limit is set.
+func reportedConnectionLimit(node *bigip.Node) int {
+	if node.ConnectionLimit == nil {
+		return 0
+	}
+	return *node.ConnectionLimit
+}
+
+// reportedDynamicRatio returns the dynamic ratio of node, the default of 1
+// when the BigIP omits it.
+func reportedDynamicRatio(node *bigip.Node) int {
+	if node.DynamicRatio == 0 {
+		return 1
+	}
+	return node.DynamicRatio
+}
+
+// logNodef logs a message about an operation on a node, see logResourcef.
+func logNodef(level, operation, name, format string, args ...interface{}) {
+	logResourcef(level, "bigip_ltm_node", operation, name, format, args...)
+}
+
+// reportedNodeDescription returns the description of the node, the BigIP
+// omits the field when the node has none.
+func reportedNodeDescription(node *bigip.Node) string {
+	if node.Description == nil {
+		return ""
+	}
+	return *node.Description
+}
+
+func nodeDescription(d *schema.ResourceData) *string {
+	description := d.Get("description").(string)
+	return &description
+}
+
+// nodeStates maps the state of a node as configured to the state and session
+// of the node on the BigIP. Disabled nodes only accept persistent and active
+// connections, nodes forced offline only active ones.
+var nodeStates = map[string]struct{ state, session string }{
+	"user-up":       {"user-up", "user-enabled"},
+	"user-disabled": {"user-up", "user-disabled"},
+	"user-down":     {"user-down", "user-disabled"},
+}
+
+// normalizeNodeState returns the state of a node for one of its aliases,
+// enabled, disabled and offline as shown by the BigIP UI.
+func normalizeNodeState(state string) string {
+	switch state {
+	case "enabled":
+		return "user-up"
+	case "disabled":
+		return "user-disabled"
+	case "offline":
+		return "user-down"
+	}
+	return state
+}
+
+// setNodeState sets the state and session of node to those of state.
+func setNodeState(node *bigip.Node, state string) {
+	s := nodeStates[normalizeNodeState(state)]
+	node.State = s.state
+	node.Session = s.session
+}
+
+// nodeState returns the state of a node as configured. The BigIP reports the
+// monitored status (up, down, unchecked, ...) in state unless the node is
+// forced offline, and whether the node is enabled by the user or a monitor in
+// session.
+func nodeState(node *bigip.Node) string {
+	if node.State == "user-down" {
+		return "user-down"
+	}
+	if node.Session == "user-disabled" {
+		return "user-disabled"
+	}
+	return "user-up"
+}
+
+// validateNodeFQDN checks that an fqdn block is given if and only if the node
+// address is a hostname.
+func validateNodeFQDN(address string, fqdnBlocks int) error {
+	if nodeAddressRegex.MatchString(address) {
+		if fqdnBlocks > 0 {
+			return fmt.Errorf("Node address %q is an IP address and can not have an fqdn block, remove the block or use a hostname as address", address)
 		}
+		return nil
 	}
-	return resourceBigipLtmNodeRead(d, meta)
+	if fqdnBlocks != 1 {
+		return fmt.Errorf("Node address %q is a hostname and requires an fqdn block, e.g. fqdn { interval = \"3600\" }", address)
+	}
+	return nil
 }
 
 func resourceBigipLtmNodeDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client, deadline := clientForOperation(meta.(*bigipClient), d, schema.TimeoutDelete)
 
 	name := d.Id()
-	log.Println("[INFO] Deleting node " + name)
+	if d.Get("force_delete").(bool) {
+		timeout := timeoutBefore(time.Duration(d.Get("drain_timeout").(int))*time.Second, deadline)
+		if err := drainNode(client, name, timeout); err != nil {
+			return err
+		}
+	}
+
+	logNodef("INFO", "delete", name, "Deleting node")
 	err := client.DeleteNode(name)
 
 	if err != nil {
-		log.Printf("[ERROR] Unable to Delete Node %s  %v : ", name, err)
-		return err
+		return fmt.Errorf("Error deleting node %s: %v", name, apiErrorDetails(err))
 	}
 	d.SetId("")
 	return nil
 }
+
+// nodeMetadata returns the configured metadata entries of the node, an empty
+// list removes existing entries.
+func nodeMetadata(d *schema.ResourceData) *[]bigip.NodeMetadata {
+	metadata := []bigip.NodeMetadata{}
+	for _, m := range d.Get("metadata").(*schema.Set).List() {
+		entry := m.(map[string]interface{})
+		metadata = append(metadata, bigip.NodeMetadata{
+			Name:    entry["name"].(string),
+			Value:   entry["value"].(string),
+			Persist: strconv.FormatBool(entry["persist"].(bool)),
+		})
+	}
+	return &metadata
+}
+
+// drainNode forces the node offline and waits until its active connections
+// are closed or the timeout elapses. Only the state of the node is sent, an
+// error retrieving its connections stops the deletion.
+func drainNode(client *bigipClient, name string, timeout time.Duration) error {
+	logNodef("INFO", "delete", name, "Forcing node offline before deletion")
+	offline := &bigip.Node{}
+	setNodeState(offline, "user-down")
+	err := client.PatchNode(name, offline)
+	if err != nil {
+		return fmt.Errorf("Error forcing node %s offline: %v", name, apiErrorDetails(err))
+	}
+
+	var conns int64
+	err = waitForCondition(time.Second, timeout, func() (bool, error) {
+		stats, err := client.GetNodeStats(name)
+		if err != nil {
+			return false, err
+		}
+		if stats != nil {
+			conns = stats.CurConns
+		}
+		return stats == nil || conns == 0, nil
+	})
+	if err == errWaitTimeout {
+		logNodef("WARN", "delete", name, "Node was not drained within %s, deleting anyway: %d active connections", timeout, conns)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("Error retrieving the connections of node %s: %v", name, apiErrorDetails(err))
+	}
+	return nil
+}
+
+// resourceBigipLtmNodeImport imports a node by its full path, or by its
+// address given as address=<address>, e.g. address=10.0.0.5 or
+// address=10.0.0.5%2 in route domain 2.
+func resourceBigipLtmNodeImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if !strings.HasPrefix(d.Id(), "address=") {
+		return []*schema.ResourceData{d}, nil
+	}
+	client := meta.(*bigipClient)
+
+	address := strings.TrimPrefix(d.Id(), "address=")
+	if address == "" {
+		return nil, fmt.Errorf("Import ID address= is missing the address of the node")
+	}
+	nodes, err := client.Nodes()
+	if err != nil {
+		return nil, fmt.Errorf("Error retrieving nodes: %v", err)
+	}
+	var matches []string
+	for i := range nodes.Nodes {
+		node := &nodes.Nodes[i]
+		// The nodes of FQDN nodes share their addresses and are not managed
+		if node.Ephemeral == "true" || !nodeHasAddress(node, address) {
+			continue
+		}
+		fullPath := node.FullPath
+		if fullPath == "" {
+			fullPath = fmt.Sprintf("/%s/%s", node.Partition, node.Name)
+		}
+		matches = append(matches, fullPath)
+	}
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("No node with address %s found", address)
+	case 1:
+		logNodef("INFO", "import", matches[0], "Found node with address %s", address)
+		d.SetId(matches[0])
+		return []*schema.ResourceData{d}, nil
+	}
+	sort.Strings(matches)
+	hint := "Import one of them by its full path"
+	if nodeAddressRegex.MatchString(address) && !strings.Contains(address, "%") {
+		hint += fmt.Sprintf(", or add the route domain to the address, e.g. address=%s%%2", address)
+	}
+	return nil, fmt.Errorf("Several nodes have address %s: %s. %s", address, strings.Join(matches, ", "), hint)
+}
+
+// waitForNodeUp waits until the monitors of the node mark it up, or returns
+// an error once the timeout elapses.
+func waitForNodeUp(client *bigipClient, name string, timeout time.Duration) error {
+	logNodef("INFO", "create", name, "Waiting for node to come up")
+	var node *bigip.Node
+	err := waitForCondition(time.Second, timeout, func() (bool, error) {
+		var err error
+		node, err = client.GetNode(name)
+		if err != nil {
+			return false, fmt.Errorf("Error retrieving node %s: %v", name, apiErrorDetails(err))
+		}
+		if node == nil {
+			return false, fmt.Errorf("Node %s not found", name)
+		}
+		return node.State == "up", nil
+	})
+	if err == errWaitTimeout {
+		return fmt.Errorf("Node %s did not come up within %s, its state is %q and its session %q", name, timeout, node.State, node.Session)
+	}
+	return err
+}
+
+// parseNodeAddress splits a node address of the form address[%route_domain]
+// into the address and its route domain (0 when not present).
+func parseNodeAddress(address string) (string, int, error) {
+	match := nodeAddressRegex.FindStringSubmatch(address)
+	if match == nil {
+		return "", 0, fmt.Errorf("%q is not a valid IPv4 or IPv6 node address", address)
+	}
+	routeDomain := 0
+	if match[2] != "" {
+		routeDomain, _ = strconv.Atoi(match[2])
+	}
+	return unbracketNodeAddress(match[1]), routeDomain, nil
+}
+
+// sameNodeAddress reports whether the node address old, as saved in state
+// with its route domain in routeDomain, and the configured address new are
+// the same. An address without a route domain is in the default route domain
+// of its partition and matches any route domain.
+func sameNodeAddress(old, new string, routeDomain int) bool {
+	oldAddress, oldRouteDomain, err := parseNodeAddress(old)
+	if err != nil {
+		return false
+	}
+	if strings.Contains(old, "%") {
+		routeDomain = oldRouteDomain
+	}
+	newAddress, newRouteDomain, err := parseNodeAddress(new)
+	if err != nil {
+		return false
+	}
+	return oldAddress == newAddress && (!strings.Contains(new, "%") || newRouteDomain == routeDomain)
+}
+
+// nodeAddress returns the address of a node as configured, its FQDN or its
+// IP address.
+func nodeAddress(node *bigip.Node) string {
+	if node.FQDN.Name != "" {
+		return node.FQDN.Name
+	}
+	return node.Address
+}
+
+// nodeHasAddress reports whether node has the configured address, which
+// adopt_existing requires to take the node over.
+func nodeHasAddress(node *bigip.Node, address string) bool {
+	if !nodeAddressRegex.MatchString(address) {
+		return node.FQDN.Name == address
+	}
+	return node.FQDN.Name == "" && sameNodeAddress(node.Address, address, 0)
+}
+
+// unbracketNodeAddress drops the brackets of an [IPv6] address, which the
+// BigIP API does not accept.
+func unbracketNodeAddress(address string) string {
+	return strings.NewReplacer("[", "", "]", "").Replace(address)
+}
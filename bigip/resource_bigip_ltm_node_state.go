@@ -0,0 +1,133 @@
+package bigip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// resourceBigipLtmNodeState sets many existing nodes to the same state at
+// once, e.g. forced offline for a maintenance window. Like bigip_command,
+// nothing is read back, the state is set again when the nodes or the state
+// change.
+func resourceBigipLtmNodeState() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmNodeStateCreate,
+		Read:   resourceBigipLtmNodeStateRead,
+		Update: resourceBigipLtmNodeStateUpdate,
+		Delete: resourceBigipLtmNodeStateDelete,
+
+		Schema: map[string]*schema.Schema{
+			"nodes": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString, ValidateFunc: validateF5Name},
+				Set:         schema.HashString,
+				Description: "Full paths of the nodes to set the state of",
+			},
+			"state": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "State the nodes are set to, enabled (user-up), disabled (user-disabled) or forced offline (user-down)",
+				ValidateFunc: validateStringValue([]string{"user-up", "user-disabled", "user-down", "enabled", "disabled", "offline"}),
+				StateFunc: func(v interface{}) string {
+					return normalizeNodeState(v.(string))
+				},
+			},
+			"cascade_state": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Set the pool members of the nodes to the state as well, in every pool of the BigIP",
+			},
+			"succeeded": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Nodes set to the state by the last apply",
+			},
+			"failed": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "Nodes that could not be set to the state by the last apply, with the error of each",
+			},
+		},
+	}
+}
+
+func resourceBigipLtmNodeStateCreate(d *schema.ResourceData, meta interface{}) error {
+	d.SetId(resource.PrefixedUniqueId("node-state-"))
+	return resourceBigipLtmNodeStateUpdate(d, meta)
+}
+
+func resourceBigipLtmNodeStateRead(d *schema.ResourceData, meta interface{}) error {
+	return nil
+}
+
+// resourceBigipLtmNodeStateUpdate sets every node to the state, going on with
+// the other nodes when one fails. The nodes that failed are reported
+// together once all of them are done, and the nodes and the state are not
+// saved, so the next apply sets them again.
+func resourceBigipLtmNodeStateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	state := normalizeNodeState(d.Get("state").(string))
+	nodes := setToStringSlice(d.Get("nodes").(*schema.Set))
+	sort.Strings(nodes)
+
+	succeeded := []string{}
+	failed := map[string]string{}
+	for _, name := range nodes {
+		if err := setNodeStateOf(client, name, state, d.Get("cascade_state").(bool)); err != nil {
+			logNodef("WARN", "update", name, "Unable to set node to %s: %v", state, err)
+			failed[name] = err.Error()
+			continue
+		}
+		succeeded = append(succeeded, name)
+	}
+	d.Set("succeeded", succeeded)
+	d.Set("failed", failed)
+
+	if len(failed) > 0 {
+		d.Partial(true)
+		d.SetPartial("succeeded")
+		d.SetPartial("failed")
+		var errs []string
+		for _, name := range nodes {
+			if err, ok := failed[name]; ok {
+				errs = append(errs, fmt.Sprintf("%s: %s", name, err))
+			}
+		}
+		return fmt.Errorf("Unable to set %d of %d nodes to %s:\n%s", len(failed), len(nodes), state, strings.Join(errs, "\n"))
+	}
+	return resourceBigipLtmNodeStateRead(d, meta)
+}
+
+func resourceBigipLtmNodeStateDelete(d *schema.ResourceData, meta interface{}) error {
+	// The nodes are left in their state, set it back before destroying
+	d.SetId("")
+	return nil
+}
+
+// setNodeStateOf sets the node with the full path name to state, and its pool
+// members as well when cascade is set. Only the state of the node is sent,
+// its other settings are kept.
+func setNodeStateOf(client *bigipClient, name, state string, cascade bool) error {
+	logNodef("INFO", "update", name, "Setting node to %s", state)
+	node := &bigip.Node{}
+	setNodeState(node, state)
+	if err := client.PatchNode(name, node); err != nil {
+		if isNotFound(err) {
+			return fmt.Errorf("node not found")
+		}
+		return apiErrorDetails(err)
+	}
+	if cascade {
+		return cascadeNodeState(client, name, state)
+	}
+	return nil
+}
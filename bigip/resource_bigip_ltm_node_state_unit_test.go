@@ -0,0 +1,124 @@
+package bigip
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmNodeStateServer mocks the nodes a and b, the bodies of the
+// requests changing them are recorded by node in patches.
+func testBigipLtmNodeStateServer(patches map[string][]string) {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	for _, name := range []string{"a", "b"} {
+		name := name
+		mux.HandleFunc("/mgmt/tm/ltm/node/~Common~"+name, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := ioutil.ReadAll(r.Body)
+			patches[name] = append(patches[name], string(body))
+			fmt.Fprintf(w, `{}`)
+		})
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~missing", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node (/Common/missing) was not found."}`)
+	})
+}
+
+func testBigipLtmNodeStateConfig(url, nodes, state string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node_state" "maintenance" {
+			nodes = [%s]
+			state = "%s"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, nodes, state, url)
+}
+
+func TestAccBigipLtmNodeStateResource(t *testing.T) {
+	setup()
+	patches := map[string][]string{}
+	testBigipLtmNodeStateServer(patches)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeStateConfig(server.URL, `"/Common/a", "/Common/b"`, "offline"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node_state.maintenance", "state", "user-down"),
+					resource.TestCheckResourceAttr("bigip_ltm_node_state.maintenance", "succeeded.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_node_state.maintenance", "succeeded.0", "/Common/a"),
+					resource.TestCheckResourceAttr("bigip_ltm_node_state.maintenance", "succeeded.1", "/Common/b"),
+					resource.TestCheckResourceAttr("bigip_ltm_node_state.maintenance", "failed.%", "0"),
+				),
+			},
+			{
+				Config: testBigipLtmNodeStateConfig(server.URL, `"/Common/a", "/Common/b"`, "user-up"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node_state.maintenance", "state", "user-up"),
+			},
+		},
+	})
+	for _, name := range []string{"a", "b"} {
+		if assert.Len(t, patches[name], 2) {
+			assert.Contains(t, patches[name][0], `"session":"user-disabled","state":"user-down"`)
+			assert.Contains(t, patches[name][1], `"session":"user-enabled","state":"user-up"`)
+		}
+	}
+}
+
+func TestAccBigipLtmNodeStateResourcePartialFailure(t *testing.T) {
+	setup()
+	patches := map[string][]string{}
+	testBigipLtmNodeStateServer(patches)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmNodeStateConfig(server.URL, `"/Common/a", "/Common/missing", "/Common/b"`, "user-down"),
+				ExpectError: regexp.MustCompile(`Unable to set 1 of 3 nodes to user-down:\n/Common/missing: node not found`),
+			},
+		},
+	})
+	// The other nodes are set nonetheless
+	assert.Len(t, patches["a"], 1)
+	assert.Len(t, patches["b"], 1)
+}
+
+func TestAccBigipLtmNodeStateResourceUpdateFailure(t *testing.T) {
+	setup()
+	patches := map[string][]string{}
+	testBigipLtmNodeStateServer(patches)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeStateConfig(server.URL, `"/Common/a"`, "user-down"),
+			},
+			{
+				Config:      testBigipLtmNodeStateConfig(server.URL, `"/Common/a", "/Common/missing"`, "user-up"),
+				ExpectError: regexp.MustCompile(`/Common/missing: node not found`),
+			},
+			{
+				Config:             testBigipLtmNodeStateConfig(server.URL, `"/Common/a", "/Common/missing"`, "user-up"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
@@ -4,9 +4,9 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 var TEST_NODE_NAME = fmt.Sprintf("/%s/test-node", TEST_PARTITION)
@@ -83,6 +83,114 @@ func TestAccBigipLtmNode_create(t *testing.T) {
 	})
 }
 
+func TestAccBigipLtmNode_fqdnRefresh(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckNodesDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: TEST_FQDN_NODE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeExists(TEST_FQDN_NODE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.name", "f5.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.interval", "3000"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.downinterval", "5"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.autopopulate", "disabled"),
+				),
+			},
+			{
+				// Refresh and make sure the fqdn block read back from the BigIP yields no diff
+				Config:             TEST_FQDN_NODE_RESOURCE,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+var TEST_NODE_UPDATED_RESOURCE = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10"
+	connection_limit = "100"
+	dynamic_ratio = "1"
+	monitor = "default"
+	rate_limit = "disabled"
+}
+`
+
+func TestAccBigipLtmNode_update(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckNodesDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: TEST_NODE_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeExists(TEST_NODE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "0"),
+				),
+			},
+			{
+				Config: TEST_NODE_UPDATED_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeConnectionLimit(TEST_NODE_NAME, 100),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "100"),
+				),
+			},
+		},
+	})
+}
+
+var TEST_NODE_RATIO_RESOURCE = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10"
+	ratio = 1
+	state = "user-up"
+}
+`
+
+func TestAccBigipLtmNode_ratioDrift(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckNodesDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: TEST_NODE_RATIO_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeExists(TEST_NODE_NAME, true),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "ratio", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", "user-up"),
+					// Change the ratio and state outside of terraform, the refresh must detect it
+					testModifyNode(TEST_NODE_NAME, 5, "user-disabled"),
+				),
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				// An empty plan leaves the BigIP as modified, so the check
+				// fails unless the refresh found the drift and it was reverted
+				Config: TEST_NODE_RATIO_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					testCheckNodeRatioAndState(TEST_NODE_NAME, 1, "user-up"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "ratio", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", "user-up"),
+				),
+			},
+		},
+	})
+}
+
 func TestAccBigipLtmNode_import(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		PreCheck: func() {
@@ -125,7 +233,7 @@ func TestAccBigipLtmNode_import(t *testing.T) {
 
 func testCheckNodeExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		node, err := client.GetNode(name)
 		if err != nil {
@@ -141,8 +249,64 @@ func testCheckNodeExists(name string, exists bool) resource.TestCheckFunc {
 	}
 }
 
+func testCheckNodeConnectionLimit(name string, limit int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigipClient)
+
+		node, err := client.GetNode(name)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return fmt.Errorf("Node %s does not exist.", name)
+		}
+		if reportedConnectionLimit(node) != limit {
+			return fmt.Errorf("Node %s connection limit is %d, expected %d.", name, reportedConnectionLimit(node), limit)
+		}
+		return nil
+	}
+}
+
+func testCheckNodeRatioAndState(name string, ratio int, state string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigipClient)
+
+		node, err := client.GetNode(name)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return fmt.Errorf("Node %s does not exist.", name)
+		}
+		if node.Ratio != ratio {
+			return fmt.Errorf("Node %s ratio is %d, expected %d.", name, node.Ratio, ratio)
+		}
+		if nodeState(node) != state {
+			return fmt.Errorf("Node %s state is %s, expected %s.", name, nodeState(node), state)
+		}
+		return nil
+	}
+}
+
+func testModifyNode(name string, ratio int, state string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*bigipClient)
+
+		node, err := client.GetNode(name)
+		if err != nil {
+			return err
+		}
+		if node == nil {
+			return fmt.Errorf("Node %s does not exist.", name)
+		}
+		config := &bigip.Node{Ratio: ratio}
+		setNodeState(config, state)
+		return client.PatchNode(name, config)
+	}
+}
+
 func testCheckNodesDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_node" {
@@ -1,15 +1,22 @@
 package bigip
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
 	"github.com/stretchr/testify/assert"
-	//"io/ioutil"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/http/httptest"
 	"regexp"
+	"sort"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func testBigipLtmNodeInvalid(resourceName string) string {
@@ -41,6 +48,53 @@ func TestAccBigipLtmNodeInvalid(t *testing.T) {
 	})
 }
 
+func TestAccBigipLtmNodeDynamicRatioRange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(testBigipLtmNodeAddress("http://localhost", "10.10.10.10"), "address =", "dynamic_ratio = 101\n\t\t\taddress =", 1),
+				ExpectError: regexp.MustCompile(`"dynamic_ratio" must be between 1 and 100, got 101`),
+			},
+		},
+	})
+}
+
+func testBigipLtmNodeMultipleFQDN(resourceName string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "%s"
+			address = "f5.com"
+			fqdn {
+				interval = "3000"
+			}
+			fqdn {
+				interval = "300"
+			}
+		}
+		provider "bigip" {
+			address = "10.10.10.1"
+			username = "admin"
+			password = "admin"
+		}
+	`, resourceName)
+}
+
+func TestAccBigipLtmNodeMultipleFQDN(t *testing.T) {
+	resourceName := "/Common/test-node"
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmNodeMultipleFQDN(resourceName),
+				ExpectError: regexp.MustCompile("fqdn: attribute supports 1 item maximum"),
+			},
+		},
+	})
+}
+
 func testBigipLtmNodeCreate(resourceName string, url string, address string) string {
 	return fmt.Sprintf(`
 		resource "bigip_ltm_node" "test-node" {
@@ -91,6 +145,378 @@ func TestAccBigipLtmNodeCreate(t *testing.T) {
 	})
 }
 
+func testBigipLtmNodePartitionCreate(url string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "10.0.0.5"
+			partition = "Tenant1"
+			address = "10.0.0.5"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, url)
+}
+
+func TestAccBigipLtmNodePartitionCreate(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method, "Expected method 'POST', got %s", r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		assert.Contains(t, string(b), `"name":"/Tenant1/10.0.0.5"`)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Tenant1~10.0.0.5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"10.0.0.5","partition":"Tenant1","fullPath":"/Tenant1/10.0.0.5","address":"10.0.0.5"}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodePartitionCreate(server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "id", "/Tenant1/10.0.0.5"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "name", "10.0.0.5"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "partition", "Tenant1"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "full_path", "/Tenant1/10.0.0.5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmNodeDefaultPartition(t *testing.T) {
+	setup()
+	var names []string
+	var mu sync.Mutex
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		var node bigip.Node
+		json.NewDecoder(r.Body).Decode(&node)
+		mu.Lock()
+		names = append(names, node.Name)
+		mu.Unlock()
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Prod~10.0.0.5", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"10.0.0.5","partition":"Prod","fullPath":"/Prod/10.0.0.5","address":"10.0.0.5"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Tenant1~10.0.0.6", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"10.0.0.6","partition":"Tenant1","fullPath":"/Tenant1/10.0.0.6","address":"10.0.0.6"}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_node" "default" {
+						name = "10.0.0.5"
+						address = "10.0.0.5"
+					}
+					resource "bigip_ltm_node" "tenant" {
+						name = "10.0.0.6"
+						partition = "Tenant1"
+						address = "10.0.0.6"
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+						default_partition = "/Prod"
+					}
+				`, server.URL),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.default", "id", "/Prod/10.0.0.5"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.default", "partition", "Prod"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.tenant", "id", "/Tenant1/10.0.0.6"),
+				),
+			},
+		},
+	})
+	sort.Strings(names)
+	assert.Equal(t, []string{"/Prod/10.0.0.5", "/Tenant1/10.0.0.6"}, names)
+}
+
+func testBigipLtmNodeFQDNCreate(url string, autopopulateKey string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-fqdn-node" {
+			name = "/Common/test-fqdn-node"
+			address = "f5.com"
+			fqdn {
+				%s = "enabled"
+			}
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, autopopulateKey, url)
+}
+
+func TestAccBigipLtmNodeFQDNAutopopulate(t *testing.T) {
+	for _, key := range []string{"autopopulate", "auto_populate"} {
+		setup()
+		mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{}`)
+		})
+		mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method, "Expected method 'POST', got %s", r.Method)
+			b, _ := ioutil.ReadAll(r.Body)
+			defer r.Body.Close()
+			assert.Contains(t, string(b), `"autopopulate":"enabled"`)
+			fmt.Fprintf(w, `{}`)
+		})
+		mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-fqdn-node", func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"name":"test-fqdn-node","partition":"Common","fqdn":{"tmName":"f5.com","autopopulate":"enabled","interval":"3600","downInterval":5,"addressFamily":"ipv4"}}`)
+		})
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testBigipLtmNodeFQDNCreate(server.URL, key),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.autopopulate", "enabled"),
+						resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.auto_populate", "enabled"),
+						resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.name", "f5.com"),
+					),
+				},
+			},
+		})
+		teardown()
+	}
+}
+
+func TestAccBigipLtmNodeForceDelete(t *testing.T) {
+	setup()
+	var calls []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			b, _ := ioutil.ReadAll(r.Body)
+			defer r.Body.Close()
+			calls = append(calls, r.Method+" "+strings.TrimSpace(string(b)))
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node/stats", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "STATS")
+		fmt.Fprintf(w, `{"entries":{"https://localhost/mgmt/tm/ltm/node/~Common~test-node/stats":{"nestedStats":{"entries":{"serverside.curConns":{"value":0}}}}}}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_node" "test-node" {
+						name = "/Common/test-node"
+						address = "10.10.10.10"
+						force_delete = true
+						drain_timeout = 5
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+			},
+		},
+	})
+	assert.Equal(t, []string{`PATCH {"session":"user-disabled","state":"user-down","fqdn":{}}`, "STATS", "DELETE "}, calls)
+}
+
+func TestAccBigipLtmNodeForceDeleteStatsError(t *testing.T) {
+	setup()
+	var calls []string
+	statsFail := true
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			calls = append(calls, r.Method)
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node/stats", func(w http.ResponseWriter, r *http.Request) {
+		// The first deletion fails to read the connections, the second
+		// deletes the node
+		if statsFail {
+			statsFail = false
+			calls = append(calls, "STATS 401")
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprintf(w, `{"code":401,"message":"Authorization failed: no user authentication header or token detected."}`)
+			return
+		}
+		calls = append(calls, "STATS")
+		fmt.Fprintf(w, `{"entries":{"https://localhost/mgmt/tm/ltm/node/~Common~test-node/stats":{"nestedStats":{"entries":{"serverside.curConns":{"value":0}}}}}}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_node" "test-node" {
+						name = "/Common/test-node"
+						address = "10.10.10.10"
+						force_delete = true
+						drain_timeout = 5
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+				ExpectError: regexp.MustCompile("Error retrieving the connections of node /Common/test-node"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"PATCH", "STATS 401", "PATCH", "STATS", "DELETE"}, calls)
+}
+
+func TestAccBigipLtmNodeValidateMonitors(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	monitorTypes := []string{"http", "https", "icmp", "gateway-icmp", "tcp", "tcp-half-open", "external", "ldap", "smtp"}
+	mux.HandleFunc("/mgmt/tm/ltm/monitor", func(w http.ResponseWriter, r *http.Request) {
+		var items []string
+		for _, m := range monitorTypes {
+			items = append(items, fmt.Sprintf(`{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/%s?ver=13.1.0"}}`, m))
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	})
+	for _, m := range monitorTypes {
+		monitor := m
+		mux.HandleFunc("/mgmt/tm/ltm/monitor/"+monitor, func(w http.ResponseWriter, r *http.Request) {
+			fmt.Fprintf(w, `{"items":[{"name":"%s","partition":"Common","fullPath":"/Common/%s"}]}`, monitor, monitor)
+		})
+	}
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_node" "test-node" {
+						name = "/Common/test-node"
+						address = "10.10.10.10"
+						monitor = "/Common/typo"
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+						validate_monitors = true
+					}
+				`, server.URL),
+				ExpectError: regexp.MustCompile("Monitor /Common/typo does not exist, available monitors are: /Common/external, /Common/gateway-icmp, /Common/http"),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmNodeValidateMonitorsDefaultPartition(t *testing.T) {
+	setup()
+	var monitors []string
+	testBigipLtmNodeMonitorServer(&monitors)
+	mux.HandleFunc("/mgmt/tm/ltm/monitor", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[
+			{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/http?ver=13.1.0"}},
+			{"reference":{"link":"https://localhost/mgmt/tm/ltm/monitor/dns?ver=13.1.0"}}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/http", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"http","partition":"Common","fullPath":"/Common/http"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/dns", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"dns-check","partition":"Tenant","fullPath":"/Tenant/dns-check"}]}`)
+	})
+	defer teardown()
+	config := func(monitor string) string {
+		return strings.Replace(testBigipLtmNodeMonitor(server.URL, monitor), `password = "admin"`, `password = "admin"
+			default_partition = "Tenant"
+			validate_monitors = true`, 1)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`monitor = "dns-check and /Common/http"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/http and dns-check"),
+			},
+			{
+				// http is not in the default partition the monitor is sent with
+				Config:      config(`monitor = "dns-check and http"`),
+				ExpectError: regexp.MustCompile("Monitor /Tenant/http does not exist, available monitors are: /Common/http, /Tenant/dns-check"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"/Common/http and /Tenant/dns-check"}, monitors)
+}
+
+func TestParseNodeAddress(t *testing.T) {
+	data := map[string]struct {
+		address     string
+		routeDomain int
+	}{
+		"10.10.10.10":      {"10.10.10.10", 0},
+		"10.10.10.10%2":    {"10.10.10.10", 2},
+		"fe80::1%1":        {"fe80::1", 1},
+		"2001:db8::5":      {"2001:db8::5", 0},
+		"[2001:db8::5]%10": {"2001:db8::5", 10},
+	}
+	for in, expected := range data {
+		address, routeDomain, err := parseNodeAddress(in)
+		assert.Nil(t, err, "%s returned an error", in)
+		assert.Equal(t, expected.address, address, "%s parsed to the wrong address", in)
+		assert.Equal(t, expected.routeDomain, routeDomain, "%s parsed to the wrong route domain", in)
+	}
+
+	// FQDN nodes are not IP addresses and must take the fqdn path
+	assert.False(t, nodeAddressRegex.MatchString("f5.com"))
+	_, _, err := parseNodeAddress("f5.com")
+	assert.NotNil(t, err)
+}
+
 var (
 	// mux is the HTTP request multiplexer used with the test server.
 	mux *http.ServeMux
@@ -108,3 +534,2211 @@ func setup() {
 func teardown() {
 	server.Close()
 }
+
+func TestAccBigipLtmNodeMonitorDestination(t *testing.T) {
+	setup()
+	var monitors []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		var node struct {
+			Monitor string `json:"monitor"`
+		}
+		json.NewDecoder(r.Body).Decode(&node)
+		monitors = append(monitors, node.Monitor)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10","monitor":"/Common/http *:443 "}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_node" "test-node" {
+						name = "/Common/test-node"
+						address = "10.10.10.10"
+						monitor = "/Common/http *:443"
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/http *:443"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"/Common/http *:443"}, monitors)
+}
+
+func TestAccBigipLtmNodeMetadata(t *testing.T) {
+	setup()
+	var bodies []string
+	metadata := `[]`
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	saveMetadata := func(r *http.Request) {
+		var node struct {
+			Metadata json.RawMessage `json:"metadata"`
+		}
+		json.NewDecoder(r.Body).Decode(&node)
+		metadata = string(node.Metadata)
+		bodies = append(bodies, r.Method+" "+metadata)
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		saveMetadata(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			saveMetadata(r)
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10","metadata":%s}`, metadata)
+	})
+	defer teardown()
+	config := func(metadata string) string {
+		return fmt.Sprintf(`
+			resource "bigip_ltm_node" "test-node" {
+				name = "/Common/test-node"
+				address = "10.10.10.10"
+				%s
+			}
+			provider "bigip" {
+				address = "%s"
+				username = "admin"
+				password = "admin"
+			}
+		`, metadata, server.URL)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config(`
+					metadata {
+						name = "owner"
+						value = "team-a"
+						persist = true
+					}
+				`),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "metadata.#", "1"),
+			},
+			{
+				Config: config(`
+					metadata {
+						name = "owner"
+						value = "team-b"
+						persist = true
+					}
+					metadata {
+						name = "cost-center"
+						value = "42"
+					}
+				`),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "metadata.#", "2"),
+			},
+			{
+				Config: config(""),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "metadata.#", "0"),
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		`POST [{"name":"owner","value":"team-a","persist":"true"}]`,
+		`PATCH [{"name":"cost-center","value":"42","persist":"false"},{"name":"owner","value":"team-b","persist":"true"}]`,
+		`PATCH []`,
+	}, bodies)
+}
+
+func testBigipLtmNodeAddress(url, address string) string {
+	fqdn := ""
+	if !nodeAddressRegex.MatchString(address) {
+		fqdn = `fqdn { interval = "3600" }`
+	}
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "%s"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, address, fqdn, url)
+}
+
+func testBigipLtmNodeAddressServer(address *string, calls *[]string) {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	saveAddress := func(r *http.Request) {
+		var node bigip.Node
+		json.NewDecoder(r.Body).Decode(&node)
+		if node.FQDN.Name != "" {
+			*address = node.FQDN.Name
+		} else if node.Address != "" {
+			*address = node.Address
+		}
+		*calls = append(*calls, r.Method+" "+*address)
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		saveAddress(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			saveAddress(r)
+		case "DELETE":
+			*calls = append(*calls, "DELETE")
+		}
+		if *address == "" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node (/Common/test-node) was not found."}`)
+			return
+		}
+		if nodeAddressRegex.MatchString(*address) {
+			fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"%s"}`, *address)
+		} else {
+			fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"any6","fqdn":{"tmName":"%s","autopopulate":"disabled","interval":"3600","downInterval":5}}`, *address)
+		}
+	})
+}
+
+func TestAccBigipLtmNodeFQDNAddressUpdate(t *testing.T) {
+	setup()
+	var address string
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "f5.com"),
+			},
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "www.f5.com"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.name", "www.f5.com"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST f5.com", "PATCH www.f5.com", "DELETE"}, calls)
+}
+
+func TestAccBigipLtmNodeIPAddressUpdate(t *testing.T) {
+	setup()
+	var address string
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "10.10.10.10"),
+			},
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "10.10.10.11"),
+			},
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "f5.com"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST 10.10.10.10", "DELETE", "POST 10.10.10.11", "DELETE", "POST f5.com", "DELETE"}, calls)
+}
+
+func testBigipLtmNodeRefreshTrigger(url, address, trigger string) string {
+	return strings.Replace(testBigipLtmNodeAddress(url, address), "name =", fmt.Sprintf("refresh_trigger = %q\n\t\t\tname =", trigger), 1)
+}
+
+func TestAccBigipLtmNodeRefreshTrigger(t *testing.T) {
+	setup()
+	var address string
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeRefreshTrigger(server.URL, "f5.com", "1"),
+			},
+			{
+				Config:   testBigipLtmNodeRefreshTrigger(server.URL, "f5.com", "1"),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmNodeRefreshTrigger(server.URL, "f5.com", "2"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "refresh_trigger", "2"),
+			},
+		},
+	})
+	// The FQDN is only sent again when the trigger changes
+	assert.Equal(t, []string{"POST f5.com", "PATCH f5.com", "DELETE"}, calls)
+}
+
+func TestAccBigipLtmNodeRefreshTriggerIPAddress(t *testing.T) {
+	setup()
+	var address string
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeRefreshTrigger(server.URL, "10.10.10.10", "1"),
+			},
+			{
+				Config: testBigipLtmNodeRefreshTrigger(server.URL, "10.10.10.10", "2"),
+			},
+		},
+	})
+	// IP nodes have nothing to resolve, the node is left alone
+	assert.Equal(t, []string{"POST 10.10.10.10", "PATCH 10.10.10.10", "DELETE"}, calls)
+}
+
+func TestAccBigipLtmNodeRouteDomain(t *testing.T) {
+	setup()
+	var address string
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				// The step fails when the route domain causes a diff
+				Config: testBigipLtmNodeAddress(server.URL, "10.0.0.5%2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "address", "10.0.0.5"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "route_domain", "2"),
+				),
+			},
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "10.0.0.5%3"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "route_domain", "3"),
+			},
+			{
+				Config:            testBigipLtmNodeAddress(server.URL, "10.0.0.5%3"),
+				ResourceName:      "bigip_ltm_node.test-node",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Settings of the provider only, the BigIP does not report them
+				ImportStateVerifyIgnore: []string{"adopt_existing", "cascade_state", "drain_timeout", "force_delete", "wait_for_up", "wait_timeout"},
+			},
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "[2001:db8::5]%10"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "address", "2001:db8::5"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "route_domain", "10"),
+				),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST 10.0.0.5%2", "DELETE", "POST 10.0.0.5%3", "DELETE", "POST 2001:db8::5%10", "DELETE"}, calls)
+}
+
+func testBigipLtmNodeAdopt(url, address string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "%s"
+			adopt_existing = true
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, address, url)
+}
+
+func TestAccBigipLtmNodeAdoptExisting(t *testing.T) {
+	setup()
+	address := "10.0.0.5%0"
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAdopt(server.URL, "10.0.0.5"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "address", "10.0.0.5"),
+			},
+		},
+	})
+	// The existing node is updated rather than created
+	assert.Equal(t, []string{"PATCH 10.0.0.5", "DELETE"}, calls)
+}
+
+func TestAccBigipLtmNodeAdoptExistingNew(t *testing.T) {
+	setup()
+	var address string
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAdopt(server.URL, "10.0.0.5"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST 10.0.0.5", "DELETE"}, calls)
+}
+
+func TestAccBigipLtmNodeAdoptExistingAddressMismatch(t *testing.T) {
+	setup()
+	address := "10.0.0.6"
+	var calls []string
+	testBigipLtmNodeAddressServer(&address, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmNodeAdopt(server.URL, "10.0.0.5"),
+				ExpectError: regexp.MustCompile("Node /Common/test-node already exists with address 10.0.0.6, not 10.0.0.5"),
+			},
+		},
+	})
+	assert.Empty(t, calls)
+}
+
+func TestNodeHasAddress(t *testing.T) {
+	ip := &bigip.Node{Address: "10.0.0.5%2"}
+	assert.True(t, nodeHasAddress(ip, "10.0.0.5"))
+	assert.True(t, nodeHasAddress(ip, "10.0.0.5%2"))
+	assert.False(t, nodeHasAddress(ip, "10.0.0.5%3"))
+	assert.False(t, nodeHasAddress(ip, "10.0.0.6"))
+	assert.False(t, nodeHasAddress(ip, "f5.com"))
+
+	fqdn := &bigip.Node{Address: "any6"}
+	fqdn.FQDN.Name = "f5.com"
+	assert.True(t, nodeHasAddress(fqdn, "f5.com"))
+	assert.False(t, nodeHasAddress(fqdn, "www.f5.com"))
+	assert.False(t, nodeHasAddress(fqdn, "10.0.0.5"))
+}
+
+func TestSameNodeAddress(t *testing.T) {
+	assert.True(t, sameNodeAddress("10.0.0.5", "10.0.0.5%2", 2))
+	assert.True(t, sameNodeAddress("10.0.0.5", "10.0.0.5", 2))
+	assert.True(t, sameNodeAddress("10.0.0.5%2", "10.0.0.5%2", 0))
+	assert.True(t, sameNodeAddress("2001:db8::5", "[2001:db8::5]%10", 10))
+	assert.False(t, sameNodeAddress("10.0.0.5", "10.0.0.5%3", 2))
+	assert.False(t, sameNodeAddress("10.0.0.5", "10.0.0.6%2", 2))
+	assert.False(t, sameNodeAddress("f5.com", "f5.com", 0))
+}
+
+func TestAccBigipLtmNodeFQDNBlock(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	defer teardown()
+	node := func(address, fqdn string) string {
+		return fmt.Sprintf(`
+			resource "bigip_ltm_node" "test-node" {
+				name = "/Common/test-node"
+				address = "%s"
+				%s
+			}
+			provider "bigip" {
+				address = "%s"
+				username = "admin"
+				password = "admin"
+			}
+		`, address, fqdn, server.URL)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      node("10.10.10.10", `fqdn { interval = "3600" }`),
+				ExpectError: regexp.MustCompile(`Node address "10.10.10.10" is an IP address and can not have an fqdn block`),
+			},
+			{
+				Config:      node("f5.com", ""),
+				ExpectError: regexp.MustCompile(`Node address "f5.com" is a hostname and requires an fqdn block`),
+			},
+		},
+	})
+}
+
+func TestValidateNodeFQDN(t *testing.T) {
+	assert.Nil(t, validateNodeFQDN("10.10.10.10", 0))
+	assert.Nil(t, validateNodeFQDN("[2001:db8::1]%2", 0))
+	assert.Nil(t, validateNodeFQDN("f5.com", 1))
+	assert.NotNil(t, validateNodeFQDN("10.10.10.10", 1))
+	assert.NotNil(t, validateNodeFQDN("f5.com", 0))
+}
+
+func TestAccBigipLtmNodeFQDNIntervalTTL(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		defer r.Body.Close()
+		assert.Contains(t, string(b), `"interval":"ttl"`)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-fqdn-node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-fqdn-node","partition":"Common","fqdn":{"tmName":"f5.com","autopopulate":"disabled","interval":"ttl","downInterval":5,"addressFamily":"ipv4"}}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					resource "bigip_ltm_node" "test-fqdn-node" {
+						name = "/Common/test-fqdn-node"
+						address = "f5.com"
+						fqdn {
+							interval = "ttl"
+						}
+					}
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "fqdn.0.interval", "ttl"),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmNodeCreateErrorDetails(t *testing.T) {
+	setup()
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		fmt.Fprintf(w, `{"code":409,"message":"01020066:3: The requested Node (/Common/test-node) already exists in partition Common.","errorStack":[],"apiError":3}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmNodeAddress(server.URL, "10.10.10.10"),
+				ExpectError: regexp.MustCompile(`Error creating node /Common/test-node: 01020066:3: The requested Node \(/Common/test-node\) already exists in partition Common. \(HTTP 409: \{"code":409,.*"apiError":3\}\)`),
+			},
+		},
+	})
+}
+
+func TestAPIErrorDetails(t *testing.T) {
+	err := &bigip.APIError{StatusCode: 400, Body: "<html>Bad Request</html>\n"}
+	assert.EqualError(t, err, "HTTP 400 :: <html>Bad Request</html>\n")
+	assert.EqualError(t, apiErrorDetails(err), "HTTP 400 :: <html>Bad Request</html>\n")
+	assert.EqualError(t, apiErrorDetails(fmt.Errorf("timeout")), "timeout")
+}
+
+func testBigipLtmNodeDeletedServer(status *int, calls *[]string) {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method)
+		*status = http.StatusOK
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			*calls = append(*calls, r.Method)
+		}
+		switch *status {
+		case http.StatusOK:
+			fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10"}`)
+		case http.StatusNotFound:
+			// A 404 without the usual JSON body, as answered by some versions
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, "Not Found")
+		default:
+			w.WriteHeader(*status)
+			fmt.Fprintf(w, `{"code":%d,"message":"access denied"}`, *status)
+		}
+	})
+}
+
+func TestAccBigipLtmNodeDeletedOutOfBand(t *testing.T) {
+	setup()
+	var status int
+	var calls []string
+	testBigipLtmNodeDeletedServer(&status, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "10.10.10.10"),
+			},
+			{
+				PreConfig: func() { status = http.StatusNotFound },
+				Config:    testBigipLtmNodeAddress(server.URL, "10.10.10.10"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST", "POST", "DELETE"}, calls)
+}
+
+func TestAccBigipLtmNodeReadError(t *testing.T) {
+	setup()
+	var status int
+	var calls []string
+	testBigipLtmNodeDeletedServer(&status, &calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAddress(server.URL, "10.10.10.10"),
+			},
+			{
+				PreConfig:   func() { status = http.StatusForbidden },
+				Config:      testBigipLtmNodeAddress(server.URL, "10.10.10.10"),
+				ExpectError: regexp.MustCompile("access denied"),
+			},
+			{
+				PreConfig: func() { status = http.StatusOK },
+				Config:    testBigipLtmNodeAddress(server.URL, "10.10.10.10"),
+			},
+		},
+	})
+}
+
+func TestBigipLtmNodeRefreshSingleRequest(t *testing.T) {
+	setup()
+	defer teardown()
+	status := http.StatusOK
+	gets := 0
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if status == http.StatusNotFound {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node (/Common/test-node) was not found."}`)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","fullPath":"/Common/test-node","address":"10.10.10.10"}`)
+	})
+	client := &bigipClient{bigip.NewSession(server.URL, "admin", "admin", nil), &Config{}}
+	state := &terraform.InstanceState{
+		ID:         "/Common/test-node",
+		Attributes: map[string]string{"name": "/Common/test-node", "address": "10.10.10.10"},
+	}
+
+	refreshed, err := resourceBigipLtmNode().Refresh(state, client)
+	assert.NoError(t, err)
+	assert.Equal(t, "/Common/test-node", refreshed.ID)
+	assert.Equal(t, 1, gets)
+
+	// A node deleted out of band is removed from the state, with one request as well
+	status = http.StatusNotFound
+	gets = 0
+	refreshed, err = resourceBigipLtmNode().Refresh(state, client)
+	assert.NoError(t, err)
+	assert.Nil(t, refreshed)
+	assert.Equal(t, 1, gets)
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, isNotFound(&bigip.APIError{StatusCode: 404, Body: "Not Found"}))
+	assert.True(t, isNotFound(&bigip.APIError{StatusCode: 400, RequestError: bigip.RequestError{Code: 404}}))
+	assert.False(t, isNotFound(&bigip.APIError{StatusCode: 500, Body: "Internal Server Error"}))
+	assert.False(t, isNotFound(fmt.Errorf("404")))
+	assert.False(t, isNotFound(&bigip.APIError{StatusCode: 403, RequestError: bigip.RequestError{Code: 404}}))
+}
+
+func TestBigipLtmNodeRefreshPermissionDenied(t *testing.T) {
+	for _, status := range []int{http.StatusUnauthorized, http.StatusForbidden} {
+		setup()
+		// The body claims the node does not exist, as answered by some
+		// versions for partitions the user has no role for
+		mux.HandleFunc("/mgmt/tm/ltm/node/~Tenant1~test-node", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node (/Tenant1/test-node) was not found."}`)
+		})
+		client := &bigipClient{bigip.NewSession(server.URL, "admin", "admin", nil), &Config{}}
+		state := &terraform.InstanceState{
+			ID:         "/Tenant1/test-node",
+			Attributes: map[string]string{"name": "/Tenant1/test-node", "address": "10.10.10.10"},
+		}
+		refreshed, err := resourceBigipLtmNode().Refresh(state, client)
+		if assert.Error(t, err, "HTTP %d", status) {
+			assert.Contains(t, err.Error(), "Insufficient permissions for partition Tenant1 to read node /Tenant1/test-node")
+			assert.Contains(t, err.Error(), fmt.Sprintf("HTTP %d", status))
+		}
+		// The node is kept in the state
+		if assert.NotNil(t, refreshed) {
+			assert.Equal(t, "/Tenant1/test-node", refreshed.ID)
+		}
+		teardown()
+	}
+}
+
+func testBigipLtmNodeMonitor(url, monitor string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, monitor, url)
+}
+
+// testBigipLtmNodeMonitorServer mocks a node whose monitor is set like the
+// BigIP does, the monitors sent are recorded in monitors.
+func testBigipLtmNodeMonitorServer(monitors *[]string) {
+	monitor := ""
+	saveMonitor := func(r *http.Request) {
+		var node bigip.Node
+		json.NewDecoder(r.Body).Decode(&node)
+		*monitors = append(*monitors, node.Monitor)
+		// The BigIP does not report a monitor for nodes without one and
+		// reports the default monitor with its partition
+		switch node.Monitor {
+		case "none":
+			monitor = ""
+		case "default":
+			monitor = "/Common/default"
+		default:
+			monitor = node.Monitor
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		saveMonitor(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			saveMonitor(r)
+		}
+		json.NewEncoder(w).Encode(map[string]string{"name": "test-node", "partition": "Common", "address": "10.10.10.10", "monitor": monitor})
+	})
+}
+
+func TestAccBigipLtmNodeMonitorKeywords(t *testing.T) {
+	setup()
+	var monitors []string
+	testBigipLtmNodeMonitorServer(&monitors)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, `monitor = "none"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", ""),
+			},
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, `monitor = "default"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "default"),
+			},
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, `monitor = "/Common/icmp"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/icmp"),
+			},
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, `monitor = "inherit"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "default"),
+			},
+			{
+				// inherit and default are the same monitor
+				Config:   testBigipLtmNodeMonitor(server.URL, `monitor = "default"`),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, ""),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", ""),
+			},
+		},
+	})
+	assert.Equal(t, []string{"", "default", "/Common/icmp", "default", "none"}, monitors)
+}
+
+func TestAccBigipLtmNodeMonitorShortNames(t *testing.T) {
+	setup()
+	var monitors []string
+	testBigipLtmNodeMonitorServer(&monitors)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, `monitor = "icmp"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "icmp"),
+			},
+			{
+				// icmp is the monitor in /Common reported by the BigIP
+				Config:   testBigipLtmNodeMonitor(server.URL, `monitor = "icmp"`),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, `monitor = "icmp and /Common/gateway_icmp"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/gateway_icmp and icmp"),
+			},
+			{
+				Config:   testBigipLtmNodeMonitor(server.URL, `monitor = "/Common/gateway_icmp and icmp"`),
+				PlanOnly: true,
+			},
+		},
+	})
+	assert.Equal(t, []string{"/Common/icmp", "/Common/gateway_icmp and /Common/icmp"}, monitors)
+}
+
+func TestAccBigipLtmNodeMonitorDefaultPartition(t *testing.T) {
+	setup()
+	var monitors []string
+	testBigipLtmNodeMonitorServer(&monitors)
+	defer teardown()
+	config := strings.Replace(testBigipLtmNodeMonitor(server.URL, `monitor = "dns-check and /Common/icmp"`),
+		`password = "admin"`, `password = "admin"
+			default_partition = "Tenant"`, 1)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/icmp and dns-check"),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+	// Monitors without a partition are the ones in the default partition
+	assert.Equal(t, []string{"/Common/icmp and /Tenant/dns-check"}, monitors)
+}
+
+func TestAccBigipLtmNodeMonitorRemoved(t *testing.T) {
+	setup()
+	node := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		// Like the BigIP, a PUT without a monitor keeps the current one
+		// and none removes it
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&node)
+			if node["monitor"] == "none" {
+				delete(node, "monitor")
+			}
+		}
+		json.NewEncoder(w).Encode(node)
+	})
+	defer teardown()
+	testNodeMonitor := func(monitor string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			n, err := bigip.NewSession(server.URL, "admin", "admin", nil).GetNode("/Common/test-node")
+			if err != nil {
+				return err
+			}
+			if n.Monitor != monitor {
+				return fmt.Errorf("expected monitor %q, got %q", monitor, n.Monitor)
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, `monitor = "/Common/icmp"`),
+				Check:  testNodeMonitor("/Common/icmp"),
+			},
+			{
+				Config: testBigipLtmNodeMonitor(server.URL, ""),
+				Check: resource.ComposeTestCheckFunc(
+					testNodeMonitor(""),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestNormalizeNodeMonitor(t *testing.T) {
+	assert.Equal(t, "default", normalizeNodeMonitor("/Common/default", ""))
+	assert.Equal(t, "none", normalizeNodeMonitor(" /Common/none ", ""))
+	assert.Equal(t, "", normalizeNodeMonitor("", "Common"))
+	assert.Equal(t, "default", normalizeNodeMonitor("inherit", "Common"))
+	assert.Equal(t, "default", nodeMonitor("inherit", "Tenant"))
+	assert.Equal(t, "none", nodeMonitor("", "Tenant"))
+	assert.Equal(t, "/Common/http and /Common/tcp", nodeMonitor("/Common/tcp and /Common/http", ""))
+	assert.Equal(t, "/Common/http and /Tenant/tcp", nodeMonitor("tcp and /Common/http", "Tenant"))
+}
+
+func testBigipLtmNodeWaitForUp(url string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			monitor = "/Common/icmp"
+			wait_for_up = true
+			wait_timeout = 5
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, url)
+}
+
+func testBigipLtmNodeWaitForUpServer(states *[]string) {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		state := (*states)[0]
+		if len(*states) > 1 {
+			*states = (*states)[1:]
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.10.10.10","monitor":"/Common/icmp","state":"%s","session":"monitor-enabled"}`, state)
+	})
+}
+
+func TestAccBigipLtmNodeWaitForUp(t *testing.T) {
+	setup()
+	states := []string{"unchecked", "down", "up"}
+	testBigipLtmNodeWaitForUpServer(&states)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeWaitForUp(server.URL),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", "user-up"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"up"}, states)
+}
+
+func TestAccBigipLtmNodeWaitForUpTimeout(t *testing.T) {
+	setup()
+	states := []string{"down"}
+	testBigipLtmNodeWaitForUpServer(&states)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(testBigipLtmNodeWaitForUp(server.URL), "wait_timeout = 5", "wait_timeout = 1", 1),
+				ExpectError: regexp.MustCompile(`Node /Common/test-node did not come up within 1s, its state is "down" and its session "monitor-enabled"`),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmNodeWaitForUpWithoutMonitor(t *testing.T) {
+	for _, monitor := range []string{"", `monitor = "none"`} {
+		setup()
+		states := []string{"unchecked"}
+		testBigipLtmNodeWaitForUpServer(&states)
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      strings.Replace(testBigipLtmNodeWaitForUp(server.URL), `monitor = "/Common/icmp"`, monitor, 1),
+					ExpectError: regexp.MustCompile(`wait_for_up needs a monitor, node /Common/test-node has none`),
+				},
+			},
+		})
+		teardown()
+	}
+}
+
+func TestAccBigipLtmNodeCreateTimeout(t *testing.T) {
+	setup()
+	states := []string{"down"}
+	testBigipLtmNodeWaitForUpServer(&states)
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: strings.Replace(testBigipLtmNodeWaitForUp(server.URL), "wait_timeout = 5", `
+						wait_timeout = 300
+						timeouts {
+							create = "2s"
+						}
+					`, 1),
+					ExpectError: regexp.MustCompile(`Node /Common/test-node did not come up within [0-9.]+m?s,`),
+				},
+			},
+		})
+		var waited time.Duration
+		for _, sleep := range c.sleeps {
+			waited += sleep
+		}
+		assert.True(t, waited <= 2*time.Second, "waited %s", waited)
+	})
+}
+
+func testBigipLtmNodeAddressFamily(url, family string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "f5.com"
+			fqdn {
+				address_family = "%s"
+			}
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, family, url)
+}
+
+func TestAccBigipLtmNodeFQDNAddressFamily(t *testing.T) {
+	setup()
+	var calls []string
+	family := ""
+	saveFamily := func(r *http.Request) {
+		var node bigip.Node
+		json.NewDecoder(r.Body).Decode(&node)
+		if node.FQDN.AddressFamily != "" {
+			family = node.FQDN.AddressFamily
+		}
+		calls = append(calls, r.Method+" "+node.FQDN.AddressFamily)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		saveFamily(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			saveFamily(r)
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"any6","fqdn":{"tmName":"f5.com","addressFamily":"%s","autopopulate":"disabled","interval":"3600","downInterval":5}}`, family)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAddressFamily(server.URL, "ipv4"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.address_family", "ipv4"),
+			},
+			{
+				Config: testBigipLtmNodeAddressFamily(server.URL, "all"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "fqdn.0.address_family", "all"),
+			},
+		},
+	})
+	// The node was updated in place, not recreated
+	assert.Equal(t, []string{"POST ipv4", "PATCH all", "DELETE "}, calls)
+}
+
+func testBigipLtmNodeFQDNSettings(url, settings string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "f5.com"
+			fqdn {
+				%s
+			}
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, settings, url)
+}
+
+func TestAccBigipLtmNodeFQDNSettingsUpdate(t *testing.T) {
+	setup()
+	var patches []string
+	var node bigip.Node
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			b, _ := ioutil.ReadAll(r.Body)
+			patches = append(patches, strings.TrimSpace(string(b)))
+			var patch bigip.Node
+			json.Unmarshal(b, &patch)
+			if patch.FQDN.Interval != "" {
+				node.FQDN.Interval = patch.FQDN.Interval
+			}
+			if patch.FQDN.DownInterval != 0 {
+				node.FQDN.DownInterval = patch.FQDN.DownInterval
+			}
+			if patch.FQDN.AutoPopulate != "" {
+				node.FQDN.AutoPopulate = patch.FQDN.AutoPopulate
+			}
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"any6","fqdn":{"tmName":"f5.com","addressFamily":"ipv4","autopopulate":"%s","interval":"%s","downInterval":%d}}`,
+			node.FQDN.AutoPopulate, node.FQDN.Interval, node.FQDN.DownInterval)
+	})
+	defer teardown()
+	step := func(settings, attribute, value string) resource.TestStep {
+		return resource.TestStep{
+			Config: testBigipLtmNodeFQDNSettings(server.URL, settings),
+			Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", attribute, value),
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			step(`interval = "3600"`, "fqdn.0.interval", "3600"),
+			step(`interval = "ttl"`, "fqdn.0.interval", "ttl"),
+			step(`interval = "ttl"
+				downinterval = 10`, "fqdn.0.downinterval", "10"),
+			step(`interval = "ttl"
+				downinterval = 10
+				autopopulate = "enabled"`, "fqdn.0.autopopulate", "enabled"),
+			step(`interval = "ttl"
+				downinterval = 10
+				auto_populate = "disabled"`, "fqdn.0.autopopulate", "disabled"),
+		},
+	})
+	// Each setting is changed in place and sent on its own
+	assert.Equal(t, []string{
+		`{"fqdn":{"interval":"ttl"}}`,
+		`{"fqdn":{"downInterval":10}}`,
+		`{"fqdn":{"autopopulate":"enabled"}}`,
+		`{"fqdn":{"autopopulate":"disabled"}}`,
+	}, patches)
+}
+
+func testBigipLtmNodeAttachment(url, address string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "%s"
+		}
+		resource "bigip_ltm_pool_attachment" "test-member" {
+			pool = "/Common/test-pool"
+			node = "${bigip_ltm_node.test-node.full_path}:80"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, address, url)
+}
+
+func TestAccBigipLtmNodeRecreateAttachment(t *testing.T) {
+	setup()
+	var calls []string
+	var address string
+	member := false
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		var node bigip.Node
+		json.NewDecoder(r.Body).Decode(&node)
+		address = node.Address
+		calls = append(calls, "POST node "+address)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "DELETE" {
+			calls = append(calls, "DELETE node "+address)
+		}
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","fullPath":"/Common/test-node","address":"%s"}`, address)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-pool","partition":"Common"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			member = true
+			calls = append(calls, "POST member")
+		}
+		if member {
+			fmt.Fprintf(w, `{"items":[{"name":"test-node:80","fullPath":"/Common/test-node:80"}]}`)
+		} else {
+			fmt.Fprintf(w, `{"items":[]}`)
+		}
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~test-node:80", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE":
+			member = false
+			calls = append(calls, "DELETE member")
+		case r.Method == "GET" && !member:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAttachment(server.URL, "10.10.10.10"),
+			},
+			{
+				Config: testBigipLtmNodeAttachment(server.URL, "10.10.10.11"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "node", "/Common/test-node:80"),
+			},
+		},
+	})
+	// The member is removed before its node and added back to the new node
+	assert.Equal(t, []string{
+		"POST node 10.10.10.10", "POST member",
+		"DELETE member", "DELETE node 10.10.10.10", "POST node 10.10.10.11", "POST member",
+		"DELETE member", "DELETE node 10.10.10.11",
+	}, calls)
+}
+
+func testBigipLtmNodeAttachmentCreateBeforeDestroy(url, address string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/%[1]s"
+			address = "%[1]s"
+			lifecycle {
+				create_before_destroy = true
+			}
+		}
+		resource "bigip_ltm_pool_attachment" "test-member" {
+			pool = "/Common/test-pool"
+			node = "${bigip_ltm_node.test-node.full_path}:80"
+			lifecycle {
+				create_before_destroy = true
+			}
+		}
+		provider "bigip" {
+			address = "%[2]s"
+			username = "admin"
+			password = "admin"
+		}
+	`, address, url)
+}
+
+func TestAccBigipLtmNodeRecreateAttachmentCreateBeforeDestroy(t *testing.T) {
+	setup()
+	var calls []string
+	nodes := map[string]bool{}
+	members := map[string]bool{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		var node bigip.Node
+		json.NewDecoder(r.Body).Decode(&node)
+		nodes[node.Address] = true
+		calls = append(calls, "POST node "+node.Address)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/", func(w http.ResponseWriter, r *http.Request) {
+		address := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/ltm/node/~Common~")
+		switch {
+		case r.Method == "DELETE":
+			delete(nodes, address)
+			calls = append(calls, "DELETE node "+address)
+		case !nodes[address]:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"%[1]s","partition":"Common","fullPath":"/Common/%[1]s","address":"%[1]s"}`, address)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-pool","partition":"Common"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			var member bigip.PoolMember
+			json.NewDecoder(r.Body).Decode(&member)
+			address := strings.TrimSuffix(strings.TrimPrefix(member.FullPath+member.Name, "/Common/"), ":80")
+			members[address] = true
+			calls = append(calls, "POST member "+address)
+		}
+		var items []string
+		for address := range members {
+			items = append(items, fmt.Sprintf(`{"name":"%[1]s:80","fullPath":"/Common/%[1]s:80"}`, address))
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members/", func(w http.ResponseWriter, r *http.Request) {
+		address := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~"), ":80")
+		switch {
+		case r.Method == "DELETE":
+			delete(members, address)
+			calls = append(calls, "DELETE member "+address)
+		case r.Method == "GET" && !members[address]:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeAttachmentCreateBeforeDestroy(server.URL, "10.10.10.10"),
+			},
+			{
+				Config: testBigipLtmNodeAttachmentCreateBeforeDestroy(server.URL, "10.10.10.11"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-member", "node", "/Common/10.10.10.11:80"),
+			},
+		},
+	})
+	// The new node and member are in place before the old ones are removed
+	assert.Equal(t, []string{
+		"POST node 10.10.10.10", "POST member 10.10.10.10",
+		"POST node 10.10.10.11", "POST member 10.10.10.11", "DELETE member 10.10.10.10", "DELETE node 10.10.10.10",
+		"DELETE member 10.10.10.11", "DELETE node 10.10.10.11",
+	}, calls)
+}
+
+func testBigipLtmNodeImportServer(response string) {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	})
+}
+
+func testBigipLtmNodeImportStep(address string) resource.TestStep {
+	return resource.TestStep{
+		Config: fmt.Sprintf(`
+			provider "bigip" {
+				address = "%s"
+				username = "admin"
+				password = "admin"
+			}
+		`, server.URL),
+		ResourceName:  "bigip_ltm_node.test-node",
+		ImportState:   true,
+		ImportStateId: "/Common/test-node",
+		ImportStateCheck: func(s []*terraform.InstanceState) error {
+			if len(s) != 1 {
+				return fmt.Errorf("Expected 1 imported node, got %d", len(s))
+			}
+			if s[0].Attributes["address"] != address {
+				return fmt.Errorf("Expected address %s, got %s", address, s[0].Attributes["address"])
+			}
+			return nil
+		},
+	}
+}
+
+func TestAccBigipLtmNodeImportFQDNResolved(t *testing.T) {
+	setup()
+	// FQDN nodes may report the address they resolved to
+	testBigipLtmNodeImportServer(`{"name":"test-node","partition":"Common","address":"10.1.1.1","fqdn":{"tmName":"f5.com","autopopulate":"disabled","interval":"3600","downInterval":5}}`)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps:      []resource.TestStep{testBigipLtmNodeImportStep("f5.com")},
+	})
+}
+
+func TestAccBigipLtmNodeImportUnparsedAddress(t *testing.T) {
+	setup()
+	testBigipLtmNodeImportServer(`{"name":"test-node","partition":"Common","address":"any6"}`)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps:      []resource.TestStep{testBigipLtmNodeImportStep("any6")},
+	})
+}
+
+func testBigipLtmNodeConnectionLimit(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+// testBigipLtmNodeConnectionLimitServer mocks a BigIP omitting the connection
+// limit of nodes without a limit, the connection limits sent are recorded in
+// limits.
+func testBigipLtmNodeConnectionLimitServer(limits *[]string) {
+	node := map[string]interface{}{}
+	save := func(r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		limit, ok := node["connectionLimit"]
+		if !ok {
+			*limits = append(*limits, r.Method+" omitted")
+			return
+		}
+		*limits = append(*limits, fmt.Sprintf("%s %v", r.Method, limit))
+		if limit == float64(0) {
+			delete(node, "connectionLimit")
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			save(r)
+		case "DELETE":
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		json.NewEncoder(w).Encode(node)
+	})
+}
+
+func TestAccBigipLtmNodeConnectionLimit(t *testing.T) {
+	setup()
+	var limits []string
+	testBigipLtmNodeConnectionLimitServer(&limits)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				// The step fails when the omitted limit causes a diff
+				Config: testBigipLtmNodeConnectionLimit(server.URL, ""),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "0"),
+			},
+			{
+				Config:                  testBigipLtmNodeConnectionLimit(server.URL, ""),
+				ResourceName:            "bigip_ltm_node.test-node",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"adopt_existing", "cascade_state", "drain_timeout", "force_delete", "wait_for_up", "wait_timeout"},
+			},
+			{
+				Config: testBigipLtmNodeConnectionLimit(server.URL, "connection_limit = 10"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "10"),
+			},
+			{
+				// Removing the limit sends 0, the BigIP kept 10 when it was omitted
+				Config: testBigipLtmNodeConnectionLimit(server.URL, ""),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "connection_limit", "0"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST 0", "PATCH 10", "PATCH 0"}, limits)
+}
+
+func testBigipLtmNodeState(url, state string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			state = "%s"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, state, url)
+}
+
+func TestAccBigipLtmNodeState(t *testing.T) {
+	setup()
+	var sent []string
+	node := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	update := func(r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		sent = append(sent, fmt.Sprintf("%s/%s", node["state"], node["session"]))
+		// The BigIP reports the monitored status unless the node is forced
+		// offline, and whether the node is enabled by a monitor
+		if node["state"] == "user-up" {
+			node["state"] = "unchecked"
+		}
+		if node["session"] == "user-enabled" {
+			node["session"] = "monitor-enabled"
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		update(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			update(r)
+		}
+		json.NewEncoder(w).Encode(node)
+	})
+	defer teardown()
+
+	check := func(state, session string) resource.TestCheckFunc {
+		return resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "state", state),
+			resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "session", session),
+		)
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeState(server.URL, "user-up"),
+				Check:  check("user-up", "monitor-enabled"),
+			},
+			{
+				Config: testBigipLtmNodeState(server.URL, "user-disabled"),
+				Check:  check("user-disabled", "user-disabled"),
+			},
+			{
+				Config: testBigipLtmNodeState(server.URL, "user-down"),
+				Check:  check("user-down", "user-disabled"),
+			},
+			{
+				Config: testBigipLtmNodeState(server.URL, "enabled"),
+				Check:  check("user-up", "monitor-enabled"),
+			},
+			{
+				Config: testBigipLtmNodeState(server.URL, "offline"),
+				Check:  check("user-down", "user-disabled"),
+			},
+			{
+				Config: testBigipLtmNodeState(server.URL, "disabled"),
+				Check:  check("user-disabled", "user-disabled"),
+			},
+			{
+				Config: testBigipLtmNodeState(server.URL, "user-disabled"),
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		"user-up/user-enabled",
+		"user-up/user-disabled",
+		"user-down/user-disabled",
+		"user-up/user-enabled",
+		"user-down/user-disabled",
+		"user-up/user-disabled",
+	}, sent)
+}
+
+func testBigipLtmNodeCascadeState(url, state string, cascade bool) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			state = "%s"
+			cascade_state = %t
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, state, cascade, url)
+}
+
+// testBigipLtmNodeCascadeStateServer mocks test-node, a member of the pools
+// web and api, the changes of pool members are recorded in members.
+func testBigipLtmNodeCascadeStateServer(members *[]string) {
+	node := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&node)
+		}
+		json.NewEncoder(w).Encode(node)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"web","fullPath":"/Common/web"},{"name":"api","fullPath":"/Tenant1/api"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~web/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"fullPath":"/Common/test-node:80"},{"fullPath":"/Common/test-node-2:80"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Tenant1~api/members", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"fullPath":"/Common/test-node:8080"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/", func(w http.ResponseWriter, r *http.Request) {
+		var member bigip.PoolMember
+		json.NewDecoder(r.Body).Decode(&member)
+		*members = append(*members, fmt.Sprintf("%s %s %s/%s", r.Method, r.URL.Path, member.State, member.Session))
+		fmt.Fprintf(w, `{}`)
+	})
+}
+
+func TestAccBigipLtmNodeCascadeState(t *testing.T) {
+	setup()
+	var members []string
+	testBigipLtmNodeCascadeStateServer(&members)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeCascadeState(server.URL, "user-up", true),
+			},
+			{
+				Config: testBigipLtmNodeCascadeState(server.URL, "offline", true),
+			},
+			{
+				Config: testBigipLtmNodeCascadeState(server.URL, "user-up", true),
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		"PATCH /mgmt/tm/ltm/pool/~Common~web/members/~Common~test-node:80 user-down/user-disabled",
+		"PATCH /mgmt/tm/ltm/pool/~Tenant1~api/members/~Common~test-node:8080 user-down/user-disabled",
+		"PATCH /mgmt/tm/ltm/pool/~Common~web/members/~Common~test-node:80 user-up/user-enabled",
+		"PATCH /mgmt/tm/ltm/pool/~Tenant1~api/members/~Common~test-node:8080 user-up/user-enabled",
+	}, members)
+}
+
+func TestAccBigipLtmNodeCascadeStateOnCreate(t *testing.T) {
+	setup()
+	var members []string
+	testBigipLtmNodeCascadeStateServer(&members)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeCascadeState(server.URL, "user-disabled", true),
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		"PATCH /mgmt/tm/ltm/pool/~Common~web/members/~Common~test-node:80 user-up/user-disabled",
+		"PATCH /mgmt/tm/ltm/pool/~Tenant1~api/members/~Common~test-node:8080 user-up/user-disabled",
+	}, members)
+}
+
+func TestAccBigipLtmNodeCascadeStateDisabled(t *testing.T) {
+	setup()
+	var members []string
+	testBigipLtmNodeCascadeStateServer(&members)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeCascadeState(server.URL, "user-up", false),
+			},
+			{
+				Config: testBigipLtmNodeCascadeState(server.URL, "user-down", false),
+			},
+		},
+	})
+	assert.Empty(t, members)
+}
+
+func TestIsNodePoolMember(t *testing.T) {
+	assert.True(t, isNodePoolMember("/Common/test-node", "/Common/test-node:80"))
+	assert.True(t, isNodePoolMember("/Common/10.0.0.5%2", "/Common/10.0.0.5%2:443"))
+	assert.True(t, isNodePoolMember("/Common/2001:db8::5", "/Common/2001:db8::5.80"))
+	assert.False(t, isNodePoolMember("/Common/test-node", "/Common/test-node-2:80"))
+	assert.False(t, isNodePoolMember("/Common/test-node", "/Tenant1/test-node:80"))
+	assert.False(t, isNodePoolMember("/Common/10.0.0.5", "/Common/10.0.0.5%2:80"))
+	assert.False(t, isNodePoolMember("/Common/2001:db8::5", "/Common/2001:db8::5:80"))
+	assert.False(t, isNodePoolMember("/Common/test-node", "/Common/test-node:http"))
+}
+
+func TestNodeState(t *testing.T) {
+	for _, c := range []struct {
+		state, session, expected string
+	}{
+		{"up", "monitor-enabled", "user-up"},
+		{"unchecked", "user-enabled", "user-up"},
+		{"down", "monitor-enabled", "user-up"},
+		{"up", "user-disabled", "user-disabled"},
+		{"user-down", "user-disabled", "user-down"},
+	} {
+		assert.Equal(t, c.expected, nodeState(&bigip.Node{State: c.state, Session: c.session}), c.state+"/"+c.session)
+	}
+	assert.Equal(t, "user-disabled", normalizeNodeState("disabled"))
+	assert.Equal(t, "user-down", normalizeNodeState("user-down"))
+}
+
+func testBigipLtmNodeRateLimit(url, rateLimit string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, rateLimit, url)
+}
+
+func TestAccBigipLtmNodeRateLimit(t *testing.T) {
+	setup()
+	var sent []string
+	node := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		sent = append(sent, node["rateLimit"].(string))
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			json.NewDecoder(r.Body).Decode(&node)
+			sent = append(sent, node["rateLimit"].(string))
+		}
+		json.NewEncoder(w).Encode(node)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeRateLimit(server.URL, `rate_limit = 100`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "rate_limit", "100"),
+			},
+			{
+				Config: testBigipLtmNodeRateLimit(server.URL, `rate_limit = 0`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "rate_limit", "disabled"),
+			},
+			{
+				Config: testBigipLtmNodeRateLimit(server.URL, ``),
+			},
+			{
+				Config: testBigipLtmNodeRateLimit(server.URL, `rate_limit = "disabled"`),
+			},
+		},
+	})
+	assert.Equal(t, []string{"100", "disabled"}, sent)
+}
+
+func testBigipLtmNodePoolMonitors(url, poolMonitor string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			monitor = "/Common/icmp and /Common/gateway_icmp"
+		}
+		resource "bigip_ltm_pool" "test-pool" {
+			name = "/Common/test-pool"
+			monitors = ["%s"]
+		}
+		resource "bigip_ltm_pool_attachment" "test-member" {
+			pool = "${bigip_ltm_pool.test-pool.name}"
+			node = "${bigip_ltm_node.test-node.full_path}:80"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, poolMonitor, url)
+}
+
+func TestAccBigipLtmNodePoolMonitors(t *testing.T) {
+	setup()
+	var nodeMonitors []string
+	node := map[string]interface{}{}
+	pool := map[string]interface{}{}
+	member := false
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	saveNode := func(r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		nodeMonitors = append(nodeMonitors, r.Method+" "+node["monitor"].(string))
+		// Like the BigIP, the node reports its own monitor rule only, in
+		// its own format
+		node["monitor"] = "/Common/gateway_icmp and /Common/icmp "
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		saveNode(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			saveNode(r)
+		}
+		json.NewEncoder(w).Encode(node)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&pool)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PUT" {
+			json.NewDecoder(r.Body).Decode(&pool)
+		}
+		pool["partition"] = "Common"
+		pool["fullPath"] = "/Common/test-pool"
+		json.NewEncoder(w).Encode(pool)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			member = true
+		}
+		if member {
+			// The member inherits the monitor of its pool
+			fmt.Fprintf(w, `{"items":[{"name":"test-node:80","fullPath":"/Common/test-node:80","monitor":"default"}]}`)
+		} else {
+			fmt.Fprintf(w, `{"items":[]}`)
+		}
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~test-node:80", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE":
+			member = false
+		case r.Method == "GET" && !member:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"test-node:80","fullPath":"/Common/test-node:80","monitor":"default"}`)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodePoolMonitors(server.URL, "/Common/http"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/gateway_icmp and /Common/icmp"),
+			},
+			{
+				// Changing the pool monitor changes the pool only
+				Config: testBigipLtmNodePoolMonitors(server.URL, "/Common/tcp"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "monitors.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "monitor", "/Common/gateway_icmp and /Common/icmp"),
+				),
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST /Common/gateway_icmp and /Common/icmp"}, nodeMonitors)
+	assert.Equal(t, "/Common/tcp", pool["monitor"])
+}
+
+func testBigipLtmNodePartialUpdate(url, ratio string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.10.10.10"
+			description = "web server"
+			ratio = %s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, ratio, url)
+}
+
+func TestAccBigipLtmNodePartialUpdate(t *testing.T) {
+	setup()
+	var patches []string
+	node := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&node)
+		// An operator enables logging, which is not managed by the resource
+		node["logging"] = "enabled"
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			b, _ := ioutil.ReadAll(r.Body)
+			patches = append(patches, strings.TrimSpace(string(b)))
+			json.Unmarshal(b, &node)
+		}
+		json.NewEncoder(w).Encode(node)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodePartialUpdate(server.URL, "1"),
+			},
+			{
+				Config: testBigipLtmNodePartialUpdate(server.URL, "3"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "ratio", "3"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "description", "web server"),
+				),
+			},
+		},
+	})
+	// Only the ratio is sent, the logging set out of band survives the update
+	assert.Equal(t, []string{`{"ratio":3,"fqdn":{}}`}, patches)
+	assert.Equal(t, "enabled", node["logging"])
+}
+
+// testBigipLtmNodeEphemeralServer mocks an FQDN node with autopopulate and
+// the ephemeral nodes of the addresses it resolves to, dns sets the resolved
+// addresses and the state reported by the FQDN node.
+func testBigipLtmNodeEphemeralServer() (dns func(state string, addresses ...string)) {
+	state, addresses := "fqdn-up", []string{"10.1.1.1"}
+	ephemeral := func(address string) string {
+		return fmt.Sprintf(`{"name":"_auto_%s","partition":"Common","fullPath":"/Common/_auto_%s","address":"%s","ephemeral":"true","state":"up","session":"monitor-enabled","fqdn":{"tmName":"f5.com","autopopulate":"enabled","interval":"3600","downInterval":5,"addressFamily":"ipv4"}}`, address, address, address)
+	}
+	template := func() string {
+		return fmt.Sprintf(`{"name":"test-fqdn-node","partition":"Common","fullPath":"/Common/test-fqdn-node","address":"any6","ephemeral":"false","state":"%s","session":"monitor-enabled","fqdn":{"tmName":"f5.com","autopopulate":"enabled","interval":"3600","downInterval":5,"addressFamily":"ipv4"}}`, state)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		items := []string{template()}
+		for _, address := range addresses {
+			items = append(items, ephemeral(address))
+		}
+		fmt.Fprintf(w, `{"items":[%s]}`, strings.Join(items, ","))
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/mgmt/tm/ltm/node/~Common~test-fqdn-node" {
+			fmt.Fprint(w, template())
+			return
+		}
+		for _, address := range addresses {
+			if r.URL.Path == "/mgmt/tm/ltm/node/~Common~_auto_"+address {
+				fmt.Fprint(w, ephemeral(address))
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Node was not found."}`)
+	})
+	return func(s string, a ...string) {
+		state, addresses = s, a
+	}
+}
+
+func TestAccBigipLtmNodeFQDNEphemeralNodes(t *testing.T) {
+	setup()
+	dns := testBigipLtmNodeEphemeralServer()
+	defer teardown()
+	config := testBigipLtmNodeFQDNCreate(server.URL, "autopopulate")
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "address", "f5.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_node.test-fqdn-node", "state", "user-up"),
+				),
+			},
+			{
+				// The DNS records change, replacing the ephemeral nodes
+				PreConfig: func() { dns("fqdn-up", "10.1.1.2", "10.1.1.3") },
+				Config:    config,
+				PlanOnly:  true,
+			},
+			{
+				// No address resolved
+				PreConfig: func() { dns("fqdn-up-no-addr") },
+				Config:    config,
+				PlanOnly:  true,
+			},
+			{
+				PreConfig: func() { dns("fqdn-checking", "10.1.1.4") },
+				Config:    config,
+				PlanOnly:  true,
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmNodeImportEphemeral(t *testing.T) {
+	setup()
+	testBigipLtmNodeEphemeralServer()
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "bigip" {
+						address = "%s"
+						username = "admin"
+						password = "admin"
+					}
+				`, server.URL),
+				ResourceName:  "bigip_ltm_node.test-node",
+				ImportState:   true,
+				ImportStateId: "/Common/_auto_10.1.1.1",
+				ExpectError:   regexp.MustCompile(`ephemeral node of FQDN node f5.com created by autopopulate, manage the FQDN node instead`),
+			},
+		},
+	})
+}
+
+// testBigipLtmNodeImportByAddressServer lists nodes of several partitions and
+// route domains, and an FQDN node with an ephemeral node.
+func testBigipLtmNodeImportByAddressServer() {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[
+			{"name":"web1","partition":"Tenant1","fullPath":"/Tenant1/web1","address":"10.0.0.5"},
+			{"name":"web2","partition":"Tenant1","fullPath":"/Tenant1/web2","address":"10.0.0.6"},
+			{"name":"web2","partition":"Tenant2","fullPath":"/Tenant2/web2","address":"10.0.0.6%%2"},
+			{"name":"app","partition":"Common","fullPath":"/Common/app","address":"any6","fqdn":{"tmName":"app.example.com","autopopulate":"enabled"}},
+			{"name":"_auto_10.0.0.7","partition":"Common","fullPath":"/Common/_auto_10.0.0.7","address":"10.0.0.7","ephemeral":"true","fqdn":{"tmName":"app.example.com","autopopulate":"enabled"}}
+		]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Tenant1~web1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"web1","partition":"Tenant1","fullPath":"/Tenant1/web1","address":"10.0.0.5","state":"unchecked","session":"user-enabled"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Tenant2~web2", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"web2","partition":"Tenant2","fullPath":"/Tenant2/web2","address":"10.0.0.6%%2","state":"unchecked","session":"user-enabled"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~app", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"app","partition":"Common","fullPath":"/Common/app","address":"any6","fqdn":{"tmName":"app.example.com","autopopulate":"enabled"}}`)
+	})
+}
+
+func testBigipLtmNodeImportByAddressStep(id string, expectedID string) resource.TestStep {
+	step := testBigipLtmNodeImportStep("")
+	step.ImportStateId = id
+	step.ImportStateCheck = func(s []*terraform.InstanceState) error {
+		if len(s) != 1 {
+			return fmt.Errorf("Expected 1 imported node, got %d", len(s))
+		}
+		if s[0].ID != expectedID {
+			return fmt.Errorf("Expected node %s, got %s", expectedID, s[0].ID)
+		}
+		return nil
+	}
+	return step
+}
+
+func TestAccBigipLtmNodeImportByAddress(t *testing.T) {
+	setup()
+	testBigipLtmNodeImportByAddressServer()
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			testBigipLtmNodeImportByAddressStep("address=10.0.0.5", "/Tenant1/web1"),
+			testBigipLtmNodeImportByAddressStep("address=10.0.0.6%2", "/Tenant2/web2"),
+			testBigipLtmNodeImportByAddressStep("address=app.example.com", "/Common/app"),
+		},
+	})
+}
+
+func TestAccBigipLtmNodeImportByAddressErrors(t *testing.T) {
+	for id, expected := range map[string]string{
+		"address=10.0.0.6": `Several nodes have address 10.0.0.6: /Tenant1/web2, /Tenant2/web2. Import one of them by its full path, or add the route domain to the address, e.g. address=10.0.0.6%2`,
+		// Ephemeral nodes of FQDN nodes are not imported
+		"address=10.0.0.7": `No node with address 10.0.0.7 found`,
+		"address=":         `address= is missing the address of the node`,
+	} {
+		setup()
+		testBigipLtmNodeImportByAddressServer()
+		step := testBigipLtmNodeImportByAddressStep(id, "")
+		step.ImportStateCheck = nil
+		step.ExpectError = regexp.MustCompile(regexp.QuoteMeta(expected))
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps:      []resource.TestStep{step},
+		})
+		teardown()
+	}
+}
+
+func testBigipLtmNodeIgnoreFields(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_node" "test-node" {
+			name = "/Common/test-node"
+			address = "10.0.0.5"
+			description = "terraform"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+func TestAccBigipLtmNodeIgnoreFields(t *testing.T) {
+	setup()
+	var bodies []string
+	ratio := 1
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+string(body))
+		var node bigip.Node
+		json.Unmarshal(body, &node)
+		if node.Ratio != 0 {
+			ratio = node.Ratio
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/ltm/node", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/node/~Common~test-node", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "PATCH" {
+			save(r)
+		}
+		// The iApp managing the node stamps its own description
+		fmt.Fprintf(w, `{"name":"test-node","partition":"Common","address":"10.0.0.5","description":"Managed by iApp f5.dns","ratio":%d,"state":"unchecked","session":"user-enabled"}`, ratio)
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmNodeIgnoreFields(server.URL, `ignore_fields = ["description"]`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "description", "Managed by iApp f5.dns"),
+			},
+			{
+				Config: testBigipLtmNodeIgnoreFields(server.URL, `
+					ignore_fields = ["description"]
+					ratio = 3
+				`),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_node.test-node", "ratio", "3"),
+			},
+			{
+				// The description shows up as drift again once it is not ignored
+				Config:             testBigipLtmNodeIgnoreFields(server.URL, `ratio = 3`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+	if assert.Len(t, bodies, 2) {
+		// The description is set when the node is created, not afterwards
+		assert.Contains(t, bodies[0], `"description":"terraform"`)
+		assert.Contains(t, bodies[1], `"ratio":3`)
+		assert.NotContains(t, bodies[1], "description")
+	}
+}
+
+func TestAccBigipLtmNodeIgnoreFieldsInvalid(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmNodeIgnoreFields("https://127.0.0.1", `ignore_fields = ["address"]`),
+				ExpectError: regexp.MustCompile(`must be one of \[description connection_limit dynamic_ratio ratio rate_limit monitor state\]`),
+			},
+		},
+	})
+}
@@ -0,0 +1,132 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// persistenceProfileSchema returns the schema of a persistence profile
+// resource, the arguments shared by all persistence profiles plus those
+// specific to its type.
+func persistenceProfileSchema(specific map[string]*schema.Schema) map[string]*schema.Schema {
+	s := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ForceNew:     true,
+			Description:  "Name of the persistence profile",
+			ValidateFunc: validateF5Name,
+		},
+
+		"app_service": {
+			Type:     schema.TypeString,
+			Default:  "",
+			Optional: true,
+		},
+
+		"defaults_from": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Inherit defaults from parent profile",
+			ValidateFunc: validateF5Name,
+		},
+
+		"match_across_pools": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "To enable _ disable match across pools with given persistence record",
+			ValidateFunc: validateEnabledDisabled,
+		},
+
+		"match_across_services": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "To enable _ disable match across services with given persistence record",
+			ValidateFunc: validateEnabledDisabled,
+		},
+
+		"match_across_virtuals": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "To enable _ disable match across virtual servers with given persistence record",
+			ValidateFunc: validateEnabledDisabled,
+		},
+
+		"mirror": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "To enable _ disable",
+			ValidateFunc: validateEnabledDisabled,
+		},
+
+		"timeout": {
+			Type:        schema.TypeInt,
+			Optional:    true,
+			Computed:    true,
+			Description: "Timeout for persistence of the session",
+		},
+
+		"override_conn_limit": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Computed:     true,
+			Description:  "To enable _ disable that pool member connection limits are overridden for persisted clients. Per-virtual connection limits remain hard limits and are not overridden.",
+			ValidateFunc: validateEnabledDisabled,
+		},
+	}
+	for k, v := range specific {
+		s[k] = v
+	}
+	return s
+}
+
+// dataToPersistenceProfile returns the arguments shared by all persistence
+// profiles, unset computed arguments are left to the parent profile.
+func dataToPersistenceProfile(d *schema.ResourceData) bigip.PersistenceProfile {
+	pp := bigip.PersistenceProfile{
+		AppService:              d.Get("app_service").(string),
+		DefaultsFrom:            d.Get("defaults_from").(string),
+		MatchAcrossPools:        d.Get("match_across_pools").(string),
+		MatchAcrossServices:     d.Get("match_across_services").(string),
+		MatchAcrossVirtuals:     d.Get("match_across_virtuals").(string),
+		Mirror:                  d.Get("mirror").(string),
+		OverrideConnectionLimit: d.Get("override_conn_limit").(string),
+	}
+	if timeout, ok := d.GetOk("timeout"); ok {
+		pp.Timeout = strconv.Itoa(timeout.(int))
+	}
+	return pp
+}
+
+// persistenceProfileToData saves the arguments shared by all persistence
+// profiles to state.
+func persistenceProfileToData(pp *bigip.PersistenceProfile, d *schema.ResourceData) error {
+	d.Set("name", d.Id())
+	if err := d.Set("app_service", pp.AppService); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving AppService to state for PersistenceProfile (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("defaults_from", pp.DefaultsFrom); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving DefaultsFrom to state for PersistenceProfile (%s): %s", d.Id(), err)
+	}
+	d.Set("match_across_pools", pp.MatchAcrossPools)
+	d.Set("match_across_services", pp.MatchAcrossServices)
+	d.Set("match_across_virtuals", pp.MatchAcrossVirtuals)
+	d.Set("mirror", pp.Mirror)
+	d.Set("override_conn_limit", pp.OverrideConnectionLimit)
+
+	// The BigIP reports the timeout as a string
+	timeout, err := strconv.Atoi(pp.Timeout)
+	if err != nil {
+		log.Printf("[WARN] Unable to parse timeout %q of PersistenceProfile (%s): %s", pp.Timeout, d.Id(), err)
+	}
+	d.Set("timeout", timeout)
+	return nil
+}
@@ -3,10 +3,9 @@ package bigip
 import (
 	"fmt"
 	"log"
-	"strconv"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmPersistenceProfileCookie() *schema.Resource {
@@ -20,75 +19,20 @@ func resourceBigipLtmPersistenceProfileCookie() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Name of the persistence profile",
-				ValidateFunc: validateF5Name,
-			},
-
-			"app_service": {
-				Type:     schema.TypeString,
-				Default:  "",
-				Optional: true,
-			},
-
-			"defaults_from": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Inherit defaults from parent profile",
-				ValidateFunc: validateF5Name,
-			},
-
-			"match_across_pools": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across pools with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"match_across_services": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across services with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"match_across_virtuals": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across virtual servers with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"mirror": {
-				Type:         schema.TypeString,
-				Default:      "disabled",
-				Optional:     true,
-				Description:  "To enable _ disable",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "Timeout for persistence of the session",
-			},
-
-			"override_conn_limit": {
+		Schema: persistenceProfileSchema(map[string]*schema.Schema{
+			// Specific to CookiePersistenceProfile
+			"method": {
 				Type:         schema.TypeString,
-				Default:      false,
 				Optional:     true,
-				Description:  "To enable _ disable that pool member connection limits are overridden for persisted clients. Per-virtual connection limits remain hard limits and are not overridden.",
-				ValidateFunc: validateEnabledDisabled,
+				Computed:     true,
+				Description:  "Method used to persist sessions with cookies: insert, rewrite, passive or hash",
+				ValidateFunc: validateStringValue([]string{"insert", "rewrite", "passive", "hash"}),
 			},
 
-			// Specific to CookiePersistenceProfile
 			"always_send": {
 				Type:         schema.TypeString,
-				Default:      "default",
 				Optional:     true,
+				Computed:     true,
 				Description:  "To enable _ disable always sending cookies",
 				ValidateFunc: validateEnabledDisabled,
 			},
@@ -104,13 +48,14 @@ func resourceBigipLtmPersistenceProfileCookie() *schema.Resource {
 			"cookie_encryption_passphrase": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Sensitive:   true,
 				Description: "Passphrase for encrypted cookies",
 			},
 
 			"cookie_name": {
 				Type:        schema.TypeString,
-				Default:     "disabled",
 				Optional:    true,
+				Computed:    true,
 				Description: "Name of the cookie to track persistence",
 			},
 
@@ -142,12 +87,12 @@ func resourceBigipLtmPersistenceProfileCookie() *schema.Resource {
 				Description:  "To enable _ disable sending only over http",
 				ValidateFunc: validateEnabledDisabled,
 			},
-		},
+		}),
 	}
 }
 
 func resourceBigipLtmPersistenceProfileCookieCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	parent := d.Get("defaults_from").(string)
@@ -174,7 +119,7 @@ func resourceBigipLtmPersistenceProfileCookieCreate(d *schema.ResourceData, meta
 }
 
 func resourceBigipLtmPersistenceProfileCookieRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -190,31 +135,17 @@ func resourceBigipLtmPersistenceProfileCookieRead(d *schema.ResourceData, meta i
 		d.SetId("")
 		return nil
 	}
-	d.Set("name", name)
-	if err := d.Set("app_service", pp.AppService); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving AppService to state for PersistenceProfileCookie (%s): %s", d.Id(), err)
-	}
-
-	if err := d.Set("defaults_from", pp.DefaultsFrom); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving DefaultsFrom to state for PersistenceProfileCookie (%s): %s", d.Id(), err)
-	}
-	d.Set("match_across_pools", pp.MatchAcrossPools)
-	d.Set("match_across_services", pp.MatchAcrossServices)
-	d.Set("match_across_virtuals", pp.MatchAcrossVirtuals)
-	if err := d.Set("mirror", pp.Mirror); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving Mirror to state for PersistenceProfileCookie (%s): %s", d.Id(), err)
+	if err := persistenceProfileToData(&pp.PersistenceProfile, d); err != nil {
+		return err
 	}
-	d.Set("timeout", pp.Timeout)
-	d.Set("override_conn_limit", pp.OverrideConnectionLimit)
 
 	// Specific to CookiePersistenceProfile
+	d.Set("method", pp.Method)
 	d.Set("always_send", pp.AlwaysSend)
 	if err := d.Set("cookie_encryption", pp.CookieEncryption); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving CookieEncryption to state for PersistenceProfileCookie (%s): %s", d.Id(), err)
 	}
-	if err := d.Set("cookie_encryption_passphrase", pp.CookieEncryptionPassphrase); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving CookieEncryptionPassphrase to state for PersistenceProfileCookie (%s): %s", d.Id(), err)
-	}
+	// The BigIP only reports the passphrase encrypted, keep it as configured
 	d.Set("cookie_name", pp.CookieName)
 	d.Set("expiration", pp.Expiration)
 	d.Set("hash_length", pp.HashLength)
@@ -225,21 +156,12 @@ func resourceBigipLtmPersistenceProfileCookieRead(d *schema.ResourceData, meta i
 }
 
 func resourceBigipLtmPersistenceProfileCookieUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	pp := &bigip.CookiePersistenceProfile{
-		PersistenceProfile: bigip.PersistenceProfile{
-			AppService:              d.Get("app_service").(string),
-			DefaultsFrom:            d.Get("defaults_from").(string),
-			MatchAcrossPools:        d.Get("match_across_pools").(string),
-			MatchAcrossServices:     d.Get("match_across_services").(string),
-			MatchAcrossVirtuals:     d.Get("match_across_virtuals").(string),
-			Mirror:                  d.Get("mirror").(string),
-			OverrideConnectionLimit: d.Get("override_conn_limit").(string),
-			Timeout:                 strconv.Itoa(d.Get("timeout").(int)),
-		},
+		PersistenceProfile: dataToPersistenceProfile(d),
 		// Specific to CookiePersistenceProfile
 		AlwaysSend:                 d.Get("always_send").(string),
 		CookieEncryption:           d.Get("cookie_encryption").(string),
@@ -250,6 +172,7 @@ func resourceBigipLtmPersistenceProfileCookieUpdate(d *schema.ResourceData, meta
 		HashOffset:                 d.Get("hash_offset").(int),
 		HTTPOnly:                   d.Get("httponly").(string),
 	}
+	pp.Method = d.Get("method").(string)
 
 	err := client.ModifyCookiePersistenceProfile(name, pp)
 	if err != nil {
@@ -261,7 +184,7 @@ func resourceBigipLtmPersistenceProfileCookieUpdate(d *schema.ResourceData, meta
 }
 
 func resourceBigipLtmPersistenceProfileCookieDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Cookie Persistence Profile " + name)
@@ -275,7 +198,7 @@ func resourceBigipLtmPersistenceProfileCookieDelete(d *schema.ResourceData, meta
 }
 
 func resourceBigipLtmPersistenceProfileCookieExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Fetching Cookie Persistence Profile " + name)
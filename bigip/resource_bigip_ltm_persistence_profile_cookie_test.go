@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -76,9 +75,14 @@ func TestAccBigipLtmPersistenceProfileCookieImport(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testBigipLtmPersistenceProfileCookieExists(TEST_PPCOOKIE_NAME, true),
 				),
-				ResourceName:      TEST_PPCOOKIE_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_PPCOOKIE_RESOURCE,
+				ResourceName:      "bigip_ltm_persistence_profile_cookie.test_ppcookie",
+				ImportState:       true,
 				ImportStateVerify: true,
+				// The BigIP only reports the passphrase encrypted
+				ImportStateVerifyIgnore: []string{"cookie_encryption_passphrase"},
 			},
 		},
 	})
@@ -86,7 +90,7 @@ func TestAccBigipLtmPersistenceProfileCookieImport(t *testing.T) {
 
 func testBigipLtmPersistenceProfileCookieExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		pp, err := client.GetCookiePersistenceProfile(name)
 		if err != nil {
@@ -103,7 +107,7 @@ func testBigipLtmPersistenceProfileCookieExists(name string, exists bool) resour
 }
 
 func testCheckBigipLtmPersistenceProfileCookieDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_persistence_profile_cookie" {
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -70,8 +69,11 @@ func TestAccBigipLtmPersistenceProfileDstAddrImport(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testBigipLtmPersistenceProfileDstAddrExists(TEST_PPDSTADDR_NAME, true),
 				),
-				ResourceName:      TEST_PPDSTADDR_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_PPDSTADDR_RESOURCE,
+				ResourceName:      "bigip_ltm_persistence_profile_dstaddr.test_ppdstaddr",
+				ImportState:       true,
 				ImportStateVerify: true,
 			},
 		},
@@ -80,7 +82,7 @@ func TestAccBigipLtmPersistenceProfileDstAddrImport(t *testing.T) {
 
 func testBigipLtmPersistenceProfileDstAddrExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		pp, err := client.GetDestAddrPersistenceProfile(name)
 		if err != nil {
@@ -97,7 +99,7 @@ func testBigipLtmPersistenceProfileDstAddrExists(name string, exists bool) resou
 }
 
 func testCheckBigipLtmPersistenceProfileDstAddrDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_persistence_profile_dstaddr" {
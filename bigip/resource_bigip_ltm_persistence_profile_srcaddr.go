@@ -3,10 +3,9 @@ package bigip
 import (
 	"fmt"
 	"log"
-	"strconv"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmPersistenceProfileSrcAddr() *schema.Resource {
@@ -20,69 +19,7 @@ func resourceBigipLtmPersistenceProfileSrcAddr() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Name of the persistence profile",
-				ValidateFunc: validateF5Name,
-			},
-
-			"app_service": {
-				Type:     schema.TypeString,
-				Default:  "",
-				Optional: true,
-			},
-
-			"defaults_from": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Inherit defaults from parent profile",
-				ValidateFunc: validateF5Name,
-			},
-
-			"match_across_pools": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across pools with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"match_across_services": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across services with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"match_across_virtuals": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across services with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"mirror": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "Timeout for persistence of the session",
-			},
-
-			"override_conn_limit": {
-				Type:         schema.TypeString,
-				Default:      false,
-				Optional:     true,
-				Description:  "To enable _ disable that pool member connection limits are overridden for persisted clients. Per-virtual connection limits remain hard limits and are not overridden.",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
+		Schema: persistenceProfileSchema(map[string]*schema.Schema{
 			// Specific to SourceAddrPersistenceProfile
 			"hash_algorithm": {
 				Type:        schema.TypeString,
@@ -93,8 +30,8 @@ func resourceBigipLtmPersistenceProfileSrcAddr() *schema.Resource {
 
 			"map_proxies": {
 				Type:         schema.TypeString,
-				Default:      true,
 				Optional:     true,
+				Computed:     true,
 				Description:  "To enable _ disable directs all to the same single pool member",
 				ValidateFunc: validateEnabledDisabled,
 			},
@@ -104,12 +41,12 @@ func resourceBigipLtmPersistenceProfileSrcAddr() *schema.Resource {
 				Optional:    true,
 				Description: "Identify a range of source IP addresses to manage together as a single source address affinity persistent connection when connecting to the pool. Must be a valid IPv4 or IPv6 mask.",
 			},
-		},
+		}),
 	}
 }
 
 func resourceBigipLtmPersistenceProfileSrcAddrCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	parent := d.Get("defaults_from").(string)
@@ -136,7 +73,7 @@ func resourceBigipLtmPersistenceProfileSrcAddrCreate(d *schema.ResourceData, met
 }
 
 func resourceBigipLtmPersistenceProfileSrcAddrRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -152,21 +89,9 @@ func resourceBigipLtmPersistenceProfileSrcAddrRead(d *schema.ResourceData, meta
 		d.SetId("")
 		return nil
 	}
-	d.Set("name", name)
-	if err := d.Set("app_service", pp.AppService); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving AppService to state for PersistenceProfileSrcAddr (%s): %s", d.Id(), err)
-	}
-	if err := d.Set("defaults_from", pp.DefaultsFrom); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving DefaultsFrom to state for PersistenceProfileSrcAddr (%s): %s", d.Id(), err)
-	}
-	d.Set("match_across_pools", pp.MatchAcrossPools)
-	d.Set("match_across_services", pp.MatchAcrossServices)
-	d.Set("match_across_virtuals", pp.MatchAcrossVirtuals)
-	if err := d.Set("mirror", pp.Mirror); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving Mirror to state for PersistenceProfileSrcAddr (%s): %s", d.Id(), err)
+	if err := persistenceProfileToData(&pp.PersistenceProfile, d); err != nil {
+		return err
 	}
-	d.Set("timeout", pp.Timeout)
-	d.Set("override_conn_limit", pp.OverrideConnectionLimit)
 
 	// Specific to SourceAddrPersistenceProfile
 	if err := d.Set("hash_algorithm", pp.HashAlgorithm); err != nil {
@@ -179,21 +104,12 @@ func resourceBigipLtmPersistenceProfileSrcAddrRead(d *schema.ResourceData, meta
 }
 
 func resourceBigipLtmPersistenceProfileSrcAddrUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	pp := &bigip.SourceAddrPersistenceProfile{
-		PersistenceProfile: bigip.PersistenceProfile{
-			AppService:              d.Get("app_service").(string),
-			DefaultsFrom:            d.Get("defaults_from").(string),
-			MatchAcrossPools:        d.Get("match_across_pools").(string),
-			MatchAcrossServices:     d.Get("match_across_services").(string),
-			MatchAcrossVirtuals:     d.Get("match_across_virtuals").(string),
-			Mirror:                  d.Get("mirror").(string),
-			OverrideConnectionLimit: d.Get("override_conn_limit").(string),
-			Timeout:                 strconv.Itoa(d.Get("timeout").(int)),
-		},
+		PersistenceProfile: dataToPersistenceProfile(d),
 
 		// Specific to SourceAddrPersistenceProfile
 		HashAlgorithm: d.Get("hash_algorithm").(string),
@@ -211,7 +127,7 @@ func resourceBigipLtmPersistenceProfileSrcAddrUpdate(d *schema.ResourceData, met
 }
 
 func resourceBigipLtmPersistenceProfileSrcAddrDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Source Address Persistence Profile " + name)
@@ -225,7 +141,7 @@ func resourceBigipLtmPersistenceProfileSrcAddrDelete(d *schema.ResourceData, met
 }
 
 func resourceBigipLtmPersistenceProfileSrcAddrExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Fetching Source Address Persistence Profile " + name)
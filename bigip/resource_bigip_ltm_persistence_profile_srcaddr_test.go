@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -72,8 +71,11 @@ func TestAccBigipLtmPersistenceProfileSrcAddrImport(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testBigipLtmPersistenceProfileSrcAddrExists(TEST_PPSRCADDR_NAME, true),
 				),
-				ResourceName:      TEST_PPSRCADDR_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_PPSRCADDR_RESOURCE,
+				ResourceName:      "bigip_ltm_persistence_profile_srcaddr.test_ppsrcaddr",
+				ImportState:       true,
 				ImportStateVerify: true,
 			},
 		},
@@ -82,7 +84,7 @@ func TestAccBigipLtmPersistenceProfileSrcAddrImport(t *testing.T) {
 
 func testBigipLtmPersistenceProfileSrcAddrExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		pp, err := client.GetSourceAddrPersistenceProfile(name)
 		if err != nil {
@@ -99,7 +101,7 @@ func testBigipLtmPersistenceProfileSrcAddrExists(name string, exists bool) resou
 }
 
 func testCheckBigipLtmPersistenceProfileSrcAddrDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_persistence_profile_srcaddr" {
@@ -1,12 +1,10 @@
 package bigip
 
 import (
-	"fmt"
 	"log"
-	"strconv"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmPersistenceProfileSSL() *schema.Resource {
@@ -20,74 +18,12 @@ func resourceBigipLtmPersistenceProfileSSL() *schema.Resource {
 			State: schema.ImportStatePassthrough,
 		},
 
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Name of the persistence profile",
-				ValidateFunc: validateF5Name,
-			},
-
-			"app_service": {
-				Type:     schema.TypeString,
-				Default:  "",
-				Optional: true,
-			},
-
-			"defaults_from": {
-				Type:         schema.TypeString,
-				Required:     true,
-				Description:  "Inherit defaults from parent profile",
-				ValidateFunc: validateF5Name,
-			},
-
-			"match_across_pools": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across pools with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"match_across_services": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across services with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"match_across_virtuals": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable match across services with given persistence record",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"mirror": {
-				Type:         schema.TypeString,
-				Optional:     true,
-				Description:  "To enable _ disable",
-				ValidateFunc: validateEnabledDisabled,
-			},
-
-			"timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "Timeout for persistence of the session",
-			},
-
-			"override_conn_limit": {
-				Type:         schema.TypeString,
-				Default:      false,
-				Optional:     true,
-				Description:  "To enable _ disable that pool member connection limits are overridden for persisted clients. Per-virtual connection limits remain hard limits and are not overridden.",
-				ValidateFunc: validateEnabledDisabled,
-			},
-		},
+		Schema: persistenceProfileSchema(nil),
 	}
 }
 
 func resourceBigipLtmPersistenceProfileSSLCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	parent := d.Get("defaults_from").(string)
@@ -112,7 +48,7 @@ func resourceBigipLtmPersistenceProfileSSLCreate(d *schema.ResourceData, meta in
 }
 
 func resourceBigipLtmPersistenceProfileSSLRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -128,40 +64,20 @@ func resourceBigipLtmPersistenceProfileSSLRead(d *schema.ResourceData, meta inte
 		d.SetId("")
 		return nil
 	}
-	d.Set("name", name)
-	d.Set("defaults_from", pp.DefaultsFrom)
-	if err := d.Set("match_across_pools", pp.MatchAcrossPools); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving MatchAcrossPools to state for PersistenceProfile SSL  (%s): %s", d.Id(), err)
-	}
-	if err := d.Set("match_across_services", pp.MatchAcrossServices); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving MatchAcrossServices to state for PersistenceProfile SSL  (%s): %s", d.Id(), err)
-	}
-	if err := d.Set("match_across_virtuals", pp.MatchAcrossVirtuals); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving MatchAcrossVirtuals to state for PersistenceProfile SSL  (%s): %s", d.Id(), err)
+	if err := persistenceProfileToData(&pp.PersistenceProfile, d); err != nil {
+		return err
 	}
-	d.Set("mirror", pp.Mirror)
-	d.Set("timeout", pp.Timeout)
-	d.Set("override_conn_limit", pp.OverrideConnectionLimit)
 
 	return nil
 }
 
 func resourceBigipLtmPersistenceProfileSSLUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	pp := &bigip.SSLPersistenceProfile{
-		PersistenceProfile: bigip.PersistenceProfile{
-			AppService:              d.Get("app_service").(string),
-			DefaultsFrom:            d.Get("defaults_from").(string),
-			MatchAcrossPools:        d.Get("match_across_pools").(string),
-			MatchAcrossServices:     d.Get("match_across_services").(string),
-			MatchAcrossVirtuals:     d.Get("match_across_virtuals").(string),
-			Mirror:                  d.Get("mirror").(string),
-			OverrideConnectionLimit: d.Get("override_conn_limit").(string),
-			Timeout:                 strconv.Itoa(d.Get("timeout").(int)),
-		},
+		PersistenceProfile: dataToPersistenceProfile(d),
 	}
 
 	err := client.ModifySSLPersistenceProfile(name, pp)
@@ -174,7 +90,7 @@ func resourceBigipLtmPersistenceProfileSSLUpdate(d *schema.ResourceData, meta in
 }
 
 func resourceBigipLtmPersistenceProfileSSLDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting SSL Persistence Profile " + name)
@@ -188,7 +104,7 @@ func resourceBigipLtmPersistenceProfileSSLDelete(d *schema.ResourceData, meta in
 }
 
 func resourceBigipLtmPersistenceProfileSSLExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Fetching SSL Persistence Profile " + name)
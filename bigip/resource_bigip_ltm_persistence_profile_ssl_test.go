@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -65,8 +64,11 @@ func TestAccBigipLtmPersistenceProfileSSLImport(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testBigipLtmPersistenceProfileSSLExists(TEST_PPSSL_NAME, true),
 				),
-				ResourceName:      TEST_PPSSL_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_PPSSL_RESOURCE,
+				ResourceName:      "bigip_ltm_persistence_profile_ssl.test_ppssl",
+				ImportState:       true,
 				ImportStateVerify: true,
 			},
 		},
@@ -75,7 +77,7 @@ func TestAccBigipLtmPersistenceProfileSSLImport(t *testing.T) {
 
 func testBigipLtmPersistenceProfileSSLExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		pp, err := client.GetSSLPersistenceProfile(name)
 		if err != nil {
@@ -92,7 +94,7 @@ func testBigipLtmPersistenceProfileSSLExists(name string, exists bool) resource.
 }
 
 func testCheckBigipLtmPersistenceProfileSSLDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_persistence_profile_ssl" {
@@ -0,0 +1,181 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// testBigipLtmPersistenceProfileServer mocks the persistence profiles of
+// kind, e.g. cookie, the BigIP fills in the values inherited from parent.
+func testBigipLtmPersistenceProfileServer(kind string, name string, parent map[string]interface{}) {
+	pp := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/persistence/"+kind, func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range parent {
+			pp[k] = v
+		}
+		json.NewDecoder(r.Body).Decode(&pp)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/persistence/"+kind+"/~Common~"+name, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			json.NewDecoder(r.Body).Decode(&pp)
+		case "DELETE":
+			pp = map[string]interface{}{}
+		}
+		json.NewEncoder(w).Encode(pp)
+	})
+}
+
+func TestAccBigipLtmPersistenceProfileCookieImportAll(t *testing.T) {
+	setup()
+	testBigipLtmPersistenceProfileServer("cookie", "test-ppcookie", map[string]interface{}{
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"timeout":                 "180",
+		"method":                  "insert",
+		"alwaysSend":              "disabled",
+		"cookieName":              "",
+	})
+	defer teardown()
+	config := fmt.Sprintf(`
+		resource "bigip_ltm_persistence_profile_cookie" "test_ppcookie" {
+			name = "/Common/test-ppcookie"
+			defaults_from = "/Common/cookie"
+			match_across_services = "enabled"
+			method = "rewrite"
+			cookie_name = "ham"
+			expiration = "1:0:0"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, server.URL)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test_ppcookie", "method", "rewrite"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test_ppcookie", "timeout", "180"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test_ppcookie", "match_across_services", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test_ppcookie", "match_across_pools", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_cookie.test_ppcookie", "always_send", "disabled"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_persistence_profile_cookie.test_ppcookie",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPersistenceProfileSrcAddrImportAll(t *testing.T) {
+	setup()
+	testBigipLtmPersistenceProfileServer("source-addr", "test-ppsrcaddr", map[string]interface{}{
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"timeout":                 "180",
+		"hashAlgorithm":           "default",
+		"mapProxies":              "enabled",
+		"mask":                    "none",
+	})
+	defer teardown()
+	config := fmt.Sprintf(`
+		resource "bigip_ltm_persistence_profile_srcaddr" "test_ppsrcaddr" {
+			name = "/Common/test-ppsrcaddr"
+			defaults_from = "/Common/source_addr"
+			mask = "255.255.255.0"
+			timeout = 3600
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, server.URL)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_srcaddr.test_ppsrcaddr", "mask", "255.255.255.0"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_srcaddr.test_ppsrcaddr", "timeout", "3600"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_srcaddr.test_ppsrcaddr", "map_proxies", "enabled"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_persistence_profile_srcaddr.test_ppsrcaddr",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPersistenceProfileSSLImportAll(t *testing.T) {
+	setup()
+	testBigipLtmPersistenceProfileServer("ssl", "test-ppssl", map[string]interface{}{
+		"matchAcrossPools":        "disabled",
+		"matchAcrossServices":     "disabled",
+		"matchAcrossVirtuals":     "disabled",
+		"mirror":                  "disabled",
+		"overrideConnectionLimit": "disabled",
+		"timeout":                 "300",
+	})
+	defer teardown()
+	config := fmt.Sprintf(`
+		resource "bigip_ltm_persistence_profile_ssl" "test_ppssl" {
+			name = "/Common/test-ppssl"
+			defaults_from = "/Common/ssl"
+			mirror = "enabled"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, server.URL)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_ssl.test_ppssl", "mirror", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_persistence_profile_ssl.test_ppssl", "timeout", "300"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_persistence_profile_ssl.test_ppssl",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
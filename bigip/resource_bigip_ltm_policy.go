@@ -7,8 +7,8 @@ import (
 	"reflect"
 	"strings"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 var CONTROLS = schema.NewSet(schema.HashString, []interface{}{"caching", "compression", "classification", "forwarding", "request-adaptation", "response-adpatation", "server-ssl"})
@@ -27,16 +27,17 @@ func resourceBigipLtmPolicy() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the Policy",
-				ForceNew:    true,
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Name of the Policy",
+				ForceNew:     true,
+				ValidateFunc: validateF5NameOrFullPath,
 			},
 			"published_copy": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Description: "Publish the Policy",
-				ForceNew:    true,
+				Deprecated:  "Policies are always published, this attribute is ignored",
 			},
 
 			"controls": {
@@ -1056,27 +1057,35 @@ func resourceBigipLtmPolicy() *schema.Resource {
 }
 
 func resourceBigipLtmPolicyCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Get("name").(string)
 	log.Println("[INFO] Creating Policy " + name)
 
-	p := dataToPolicy(name, d)
-
-	d.SetId(name)
-	err := client.CreatePolicy(&p)
+	p, err := dataToPolicy(bigip.PolicyDraftName(name), d)
 	if err != nil {
 		return err
 	}
-	published_copy := d.Get("published_copy").(string)
-	t := client.PublishPolicy(name, published_copy)
-	if t != nil {
-		return t
+
+	// Policies are created as a draft which is then published. A draft left
+	// behind by a failed create would make creating the draft fail, so it
+	// is discarded first.
+	if err := discardPolicyDraft(client, name); err != nil {
+		return err
+	}
+	err = client.CreatePolicy(&p)
+	if err != nil {
+		return abandonPolicyDraft(client, name, fmt.Errorf("Error creating Policy %s: %v", name, apiErrorDetails(err)))
 	}
+	err = client.PublishPolicy(name)
+	if err != nil {
+		return abandonPolicyDraft(client, name, fmt.Errorf("Error publishing Policy %s: %v", name, apiErrorDetails(err)))
+	}
+	d.SetId(name)
 	return resourceBigipLtmPolicyRead(d, meta)
 }
 
 func resourceBigipLtmPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 
 	log.Println("[INFO] Fetching policy " + name)
@@ -1097,7 +1106,7 @@ func resourceBigipLtmPolicyRead(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipLtmPolicyExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Fetching policy " + name)
@@ -1115,21 +1124,65 @@ func resourceBigipLtmPolicyExists(d *schema.ResourceData, meta interface{}) (boo
 }
 
 func resourceBigipLtmPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	log.Println("[INFO] Updating  Policy " + name)
-	p := dataToPolicy(name, d)
-	err := client.UpdatePolicy(name, &p)
+
+	p, err := dataToPolicy(bigip.PolicyDraftName(name), d)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Retrieve Policy   (%s) (%v) ", name, err)
 		return err
 	}
+
+	// Published policies cannot be modified, changes go to a new draft which
+	// then replaces the published policy. A draft left behind by a failed
+	// update is discarded first.
+	if err := discardPolicyDraft(client, name); err != nil {
+		return err
+	}
+	err = client.CreatePolicyDraft(name)
+	if err != nil {
+		return abandonPolicyDraft(client, name, fmt.Errorf("Error creating a draft of Policy %s: %v", name, apiErrorDetails(err)))
+	}
+	err = client.UpdatePolicy(name, &p)
+	if err != nil {
+		return abandonPolicyDraft(client, name, fmt.Errorf("Error updating the draft of Policy %s: %v", name, apiErrorDetails(err)))
+	}
+	err = client.PublishPolicy(name)
+	if err != nil {
+		return abandonPolicyDraft(client, name, fmt.Errorf("Error publishing Policy %s: %v", name, apiErrorDetails(err)))
+	}
 	return resourceBigipLtmPolicyRead(d, meta)
 }
 
+// discardPolicyDraft removes the draft of the policy name, e.g. one left
+// behind by an apply that failed before publishing it. There being no draft
+// is not an error.
+func discardPolicyDraft(client *bigipClient, name string) error {
+	err := client.DeletePolicyDraft(name)
+	if err != nil && !isNotFound(err) {
+		return fmt.Errorf("Error removing the draft of Policy %s: %v", name, apiErrorDetails(err))
+	}
+	return nil
+}
+
+// abandonPolicyDraft discards the draft of the policy name after a step of
+// the draft and publish workflow failed with err, so the published policy
+// is left as it was, and returns err. A draft that can not be removed now
+// is discarded by the next apply.
+func abandonPolicyDraft(client *bigipClient, name string, err error) error {
+	if derr := discardPolicyDraft(client, name); derr != nil {
+		log.Printf("[WARN] %v", derr)
+	}
+	return err
+}
+
 func resourceBigipLtmPolicyDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
+	// A draft left behind by a failed update is removed along with the policy
+	if err := discardPolicyDraft(client, name); err != nil {
+		return err
+	}
 	err := client.DeletePolicy(name)
 	if err != nil {
 		log.Printf("[ERROR] Unable to Delete Policy   (%s) (%v) ", name, err)
@@ -1139,14 +1192,9 @@ func resourceBigipLtmPolicyDelete(d *schema.ResourceData, meta interface{}) erro
 	return nil
 }
 
-func dataToPolicy(name string, d *schema.ResourceData) bigip.Policy {
+func dataToPolicy(name string, d *schema.ResourceData) (bigip.Policy, error) {
 	var p bigip.Policy
-	values := []string{}
-	values = append(values, "Drafts/")
-	values = append(values, name)
-	// Join three strings into one.
-	result := strings.Join(values, "")
-	p.Name = result
+	p.Name = name
 	p.Strategy = d.Get("strategy").(string)
 	p.Controls = setToStringSlice(d.Get("controls").(*schema.Set))
 	p.Requires = setToStringSlice(d.Get("requires").(*schema.Set))
@@ -1160,25 +1208,31 @@ func dataToPolicy(name string, d *schema.ResourceData) bigip.Policy {
 		actionCount := d.Get(prefix + ".action.#").(int)
 		r.Actions = make([]bigip.PolicyRuleAction, actionCount, actionCount)
 		for x := 0; x < actionCount; x++ {
-			var a bigip.PolicyRuleAction
-			mapEntity(d.Get(fmt.Sprintf("%s.action.%d", prefix, x)).(map[string]interface{}), &a)
-			r.Actions[x] = a
+			err := mapToPolicyItem(d.Get(fmt.Sprintf("%s.action.%d", prefix, x)).(map[string]interface{}), &r.Actions[x])
+			if err != nil {
+				return p, fmt.Errorf("Error in action %d of rule %s: %s", x, r.Name, err)
+			}
 		}
 
 		conditionCount := d.Get(prefix + ".condition.#").(int)
 		r.Conditions = make([]bigip.PolicyRuleCondition, conditionCount, conditionCount)
 		for x := 0; x < conditionCount; x++ {
-			var c bigip.PolicyRuleCondition
-			mapEntity(d.Get(fmt.Sprintf("%s.condition.%d", prefix, x)).(map[string]interface{}), &c)
-			r.Conditions[x] = c
+			err := mapToPolicyItem(d.Get(fmt.Sprintf("%s.condition.%d", prefix, x)).(map[string]interface{}), &r.Conditions[x])
+			if err != nil {
+				return p, fmt.Errorf("Error in condition %d of rule %s: %s", x, r.Name, err)
+			}
 		}
 		p.Rules = append(p.Rules, r)
 	}
 
-	return p
+	return p, nil
 }
 
 func policyToData(p *bigip.Policy, d *schema.ResourceData) error {
+	// Keep the name as configured, imported policies are named as imported
+	if _, ok := d.GetOk("name"); !ok {
+		d.Set("name", d.Id())
+	}
 	if err := d.Set("strategy", p.Strategy); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Strategy   state for Policy (%s): %s", d.Id(), err)
 	}
@@ -1189,31 +1243,99 @@ func policyToData(p *bigip.Policy, d *schema.ResourceData) error {
 		return fmt.Errorf("[DEBUG] Error saving Requires  state for Policy (%s): %s", d.Id(), err)
 	}
 
-	for i, r := range p.Rules {
-		rule := fmt.Sprintf("rule.%d", i)
-		d.Set(fmt.Sprintf("%s.name", rule), r.FullPath)
-		for x, a := range r.Actions {
-			action := fmt.Sprintf("%s.action.%d", rule, x)
-			interfaceToResourceData(a, d, action)
+	rules := make([]interface{}, 0, len(p.Rules))
+	for _, r := range p.Rules {
+		actions := make([]interface{}, 0, len(r.Actions))
+		for _, a := range r.Actions {
+			actions = append(actions, policyItemToMap(a))
+		}
+		conditions := make([]interface{}, 0, len(r.Conditions))
+		for _, c := range r.Conditions {
+			conditions = append(conditions, policyItemToMap(c))
 		}
+		rules = append(rules, map[string]interface{}{
+			"name":      r.Name,
+			"action":    actions,
+			"condition": conditions,
+		})
+	}
+	if err := d.Set("rule", rules); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Rules  state for Policy (%s): %s", d.Id(), err)
+	}
+	return nil
+}
 
-		for x, c := range r.Conditions {
-			condition := fmt.Sprintf("%s.condition.%d", rule, x)
-			interfaceToResourceData(c, d, condition)
+// policyItemField returns the field of a rule action or condition matching
+// a schema key. Fields are matched by their JSON name, which is the key in
+// camel case, e.g. app_service is appService, except for a few fields such
+// as last_15secs which keep the key.
+func policyItemField(v reflect.Value, key string) reflect.Value {
+	parts := strings.Split(key, "_")
+	for i := 1; i < len(parts); i++ {
+		parts[i] = strings.Title(parts[i])
+	}
+	camel := strings.Join(parts, "")
+
+	for i := 0; i < v.NumField(); i++ {
+		tag := strings.Split(v.Type().Field(i).Tag.Get("json"), ",")[0]
+		if tag == key || tag == camel {
+			return v.Field(i)
+		}
+	}
+	return reflect.Value{}
+}
+
+// policyItemSchema returns the schema of rule actions or conditions.
+func policyItemSchema(obj interface{}) map[string]*schema.Schema {
+	rule := resourceBigipLtmPolicy().Schema["rule"].Elem.(*schema.Resource)
+	if _, ok := obj.(bigip.PolicyRuleAction); ok {
+		return rule.Schema["action"].Elem.(*schema.Resource).Schema
+	}
+	return rule.Schema["condition"].Elem.(*schema.Resource).Schema
+}
+
+// mapToPolicyItem copies an action or condition block into obj, a pointer to
+// a bigip.PolicyRuleAction or bigip.PolicyRuleCondition.
+func mapToPolicyItem(m map[string]interface{}, obj interface{}) error {
+	v := reflect.ValueOf(obj).Elem()
+	for key, value := range m {
+		f := policyItemField(v, key)
+		if !f.IsValid() {
+			return fmt.Errorf("%s is not supported", key)
+		}
+		if f.Kind() == reflect.Slice {
+			values := value.([]interface{})
+			s := reflect.MakeSlice(f.Type(), len(values), len(values))
+			for i, value := range values {
+				s.Index(i).Set(reflect.ValueOf(value))
+			}
+			f.Set(s)
+		} else {
+			f.Set(reflect.ValueOf(value))
 		}
 	}
 	return nil
 }
 
-func interfaceToResourceData(obj interface{}, d *schema.ResourceData, prefix string) {
+// policyItemToMap returns an action or condition as a block of the rule
+// schema.
+func policyItemToMap(obj interface{}) map[string]interface{} {
 	v := reflect.ValueOf(obj)
-	for fi := 0; fi < v.NumField(); fi++ {
-		fn := v.Type().Field(fi).Name
-		if fn != "Name" && fn != "Generation" {
-			f := v.Field(fi)
-			if (f.Kind() == reflect.Slice && f.Interface() != nil) || f.Interface() != reflect.Zero(f.Type()).Interface() {
-				d.Set(fmt.Sprintf("%s.%s%s", prefix, strings.ToLower(fn[0:1]), fn[1:]), f.Interface())
+	m := map[string]interface{}{}
+	for key := range policyItemSchema(obj) {
+		f := policyItemField(v, key)
+		if !f.IsValid() {
+			continue
+		}
+		if f.Kind() == reflect.Slice {
+			values := make([]interface{}, f.Len())
+			for i := range values {
+				values[i] = f.Index(i).Interface()
 			}
+			m[key] = values
+		} else {
+			m[key] = f.Interface()
 		}
 	}
+	return m
 }
@@ -2,7 +2,6 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"testing"
@@ -37,7 +36,6 @@ resource "bigip_ltm_policy" "test-policy" {
 	name = "` + TEST_POLICY_NAME + `"
 	strategy = "/Common/first-match"
 	requires = ["http"]
-	published_copy = "Drafts/test-policy"
 	controls = ["forwarding"]
 	rule  {
 	      name = "rule6"
@@ -81,8 +79,11 @@ func TestAccBigipLtmPolicy_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckPolicyExists(TEST_POLICY_NAME, true),
 				),
-				ResourceName:      TEST_POLICY_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_POLICY_RESOURCE,
+				ResourceName:      "bigip_ltm_policy.test-policy",
+				ImportState:       true,
 				ImportStateVerify: true,
 			},
 		},
@@ -91,7 +92,7 @@ func TestAccBigipLtmPolicy_import(t *testing.T) {
 
 func testCheckPolicyExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		policy, err := client.GetPolicy(name)
 		if err != nil {
@@ -109,7 +110,7 @@ func testCheckPolicyExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckPolicysDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_policy" {
@@ -0,0 +1,334 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipLtmPolicyServer mocks the draft and publish workflow of
+// BIG-IP 12.1 and later, requests are recorded in calls. Drafts forwarding
+// to the pool /Common/missing can not be published.
+func testBigipLtmPolicyServer(calls *[]string) {
+	var draft, published *bigip.Policy
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/policy", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if strings.Contains(string(body), `"command":"publish"`) {
+			*calls = append(*calls, "publish")
+			for _, rule := range draft.Rules {
+				for _, action := range rule.Actions {
+					if action.Pool == "/Common/missing" {
+						w.WriteHeader(http.StatusBadRequest)
+						fmt.Fprintf(w, `{"code":400,"message":"01070712:3: Pool /Common/missing does not exist."}`)
+						return
+					}
+				}
+			}
+			published, draft = draft, nil
+			published.Name = "test-policy"
+			published.FullPath = "/Common/test-policy"
+		} else {
+			if draft != nil {
+				*calls = append(*calls, "create conflict")
+				w.WriteHeader(http.StatusConflict)
+				fmt.Fprintf(w, `{"code":409,"message":"01020066:3: The requested Policy (/Common/Drafts/test-policy) already exists in partition Common."}`)
+				return
+			}
+			draft = &bigip.Policy{}
+			json.Unmarshal(body, draft)
+			*calls = append(*calls, "create "+draft.Name)
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/policy/~Common~Drafts~test-policy", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, strings.ToLower(r.Method)+" draft")
+		switch r.Method {
+		case "PUT":
+			draft = &bigip.Policy{}
+			json.NewDecoder(r.Body).Decode(draft)
+		case "DELETE":
+			if draft == nil {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Policy (/Common/Drafts/test-policy) was not found."}`)
+				return
+			}
+			draft = nil
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/policy/~Common~test-policy", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			if published == nil {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Policy (/Common/test-policy) was not found."}`)
+				return
+			}
+			json.NewEncoder(w).Encode(&bigip.Policy{
+				Name:     published.Name,
+				FullPath: published.FullPath,
+				Controls: published.Controls,
+				Requires: published.Requires,
+				Strategy: published.Strategy,
+			})
+			return
+		case "PATCH":
+			*calls = append(*calls, "create draft "+r.URL.RawQuery)
+			draft = published
+		case "DELETE":
+			*calls = append(*calls, "delete")
+			published = nil
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/policy/~Common~test-policy/rules", func(w http.ResponseWriter, r *http.Request) {
+		// Rules are not listed in order
+		rules := bigip.PolicyRules{}
+		for i := len(published.Rules) - 1; i >= 0; i-- {
+			rules.Items = append(rules.Items, bigip.PolicyRule{Name: published.Rules[i].Name, Ordinal: published.Rules[i].Ordinal})
+		}
+		json.NewEncoder(w).Encode(&rules)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/policy/~Common~test-policy/rules/", func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		rule, items := parts[len(parts)-2], parts[len(parts)-1]
+		for _, r := range published.Rules {
+			if r.Name != rule {
+				continue
+			}
+			if items == "actions" {
+				json.NewEncoder(w).Encode(&bigip.PolicyRuleActions{Items: r.Actions})
+			} else {
+				json.NewEncoder(w).Encode(&bigip.PolicyRuleConditions{Items: r.Conditions})
+			}
+		}
+	})
+}
+
+func testBigipLtmPolicyConfig(url, pool string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_policy" "test-policy" {
+			name = "test-policy"
+			strategy = "/Common/first-match"
+			requires = ["http"]
+			controls = ["forwarding"]
+			rule {
+				name = "rule-b"
+				condition {
+					http_host = true
+					host = true
+					equals = true
+					values = ["www.example.com"]
+				}
+				action {
+					tm_name = "20"
+					forward = true
+					pool = "%s"
+				}
+			}
+			rule {
+				name = "rule-a"
+				action {
+					forward = true
+					reset = true
+				}
+			}
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, pool, url)
+}
+
+func TestAccBigipLtmPolicyPublish(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPolicyServer(&calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPolicyConfig(server.URL, "/Common/pool-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.0.name", "rule-b"),
+					resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.0.condition.0.http_host", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.0.condition.0.values.0", "www.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.0.action.0.pool", "/Common/pool-1"),
+					resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.1.name", "rule-a"),
+				),
+			},
+			{
+				Config: testBigipLtmPolicyConfig(server.URL, "/Common/pool-2"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.0.action.0.pool", "/Common/pool-2"),
+			},
+			{
+				Config:            testBigipLtmPolicyConfig(server.URL, "/Common/pool-2"),
+				ResourceName:      "bigip_ltm_policy.test-policy",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		"delete draft",
+		"create /Common/Drafts/test-policy",
+		"publish",
+		"delete draft",
+		"create draft options=create-draft",
+		"put draft",
+		"publish",
+		"delete draft",
+		"delete",
+	}, calls)
+}
+
+func TestAccBigipLtmPolicyStaleDraft(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPolicyServer(&calls)
+	defer teardown()
+	// A draft left behind by a create that failed before publishing
+	client := bigip.NewSession(server.URL, "admin", "admin", nil)
+	assert.NoError(t, client.CreatePolicy(&bigip.Policy{Name: "/Common/Drafts/test-policy", Strategy: "/Common/best-match"}))
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPolicyConfig(server.URL, "/Common/pool-1"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "strategy", "/Common/first-match"),
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		"create /Common/Drafts/test-policy",
+		"delete draft",
+		"create /Common/Drafts/test-policy",
+		"publish",
+		"delete draft",
+		"delete",
+	}, calls)
+}
+
+func TestAccBigipLtmPolicyPublishFailure(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPolicyServer(&calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmPolicyConfig(server.URL, "/Common/missing"),
+				ExpectError: regexp.MustCompile("Error publishing Policy test-policy: 01070712:3: Pool /Common/missing does not exist"),
+			},
+		},
+	})
+	// The draft is discarded and the policy is not saved in the state, so
+	// there is nothing to destroy
+	assert.Equal(t, []string{
+		"delete draft",
+		"create /Common/Drafts/test-policy",
+		"publish",
+		"delete draft",
+	}, calls)
+}
+
+func TestAccBigipLtmPolicyUpdatePublishFailure(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPolicyServer(&calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPolicyConfig(server.URL, "/Common/pool-1"),
+			},
+			{
+				Config:      testBigipLtmPolicyConfig(server.URL, "/Common/missing"),
+				ExpectError: regexp.MustCompile("Error publishing Policy test-policy"),
+			},
+			{
+				// The published policy is left as it was
+				Config: testBigipLtmPolicyConfig(server.URL, "/Common/pool-1"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_policy.test-policy", "rule.0.action.0.pool", "/Common/pool-1"),
+			},
+		},
+	})
+	assert.Equal(t, []string{
+		"delete draft",
+		"create /Common/Drafts/test-policy",
+		"publish",
+		"delete draft",
+		"create draft options=create-draft",
+		"put draft",
+		"publish",
+		"delete draft",
+		"delete draft",
+		"delete",
+	}, calls)
+}
+
+func TestAccBigipLtmPolicyImport(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPolicyServer(&calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPolicyConfig(server.URL, "/Common/pool-1"),
+			},
+			{
+				Config:        testBigipLtmPolicyConfig(server.URL, "/Common/pool-1"),
+				ResourceName:  "bigip_ltm_policy.test-policy",
+				ImportState:   true,
+				ImportStateId: "/Common/test-policy",
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					if len(s) != 1 {
+						return fmt.Errorf("expected 1 state: %#v", s)
+					}
+					attrs := s[0].Attributes
+					for k, v := range map[string]string{
+						"name":                        "/Common/test-policy",
+						"rule.#":                      "2",
+						"rule.0.name":                 "rule-b",
+						"rule.0.action.0.tm_name":     "20",
+						"rule.0.condition.0.equals":   "true",
+						"rule.0.condition.0.values.#": "1",
+						"rule.1.name":                 "rule-a",
+						"rule.1.action.0.reset":       "true",
+						"rule.1.condition.#":          "0",
+					} {
+						if attrs[k] != v {
+							return fmt.Errorf("expected %s to be %q, got %q", k, v, attrs[k])
+						}
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
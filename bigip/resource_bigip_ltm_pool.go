@@ -2,10 +2,11 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 	"log"
 	"regexp"
+	"sort"
 	"strings"
 )
 
@@ -28,42 +29,48 @@ func resourceBigipLtmPool() *schema.Resource {
 				Required:     true,
 				Description:  "Name of the pool",
 				ForceNew:     true,
-				ValidateFunc: validateF5Name,
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("pool"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the pool, for referencing it from virtual servers and pool attachments",
 			},
 			"nodes": &schema.Schema{
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Set:         schema.HashString,
 				Optional:    true,
-				Description: "Nodes to add to the pool. Format node_name:port. e.g. node01:443",
+				Description: "Nodes to add to the pool. Format node_name:port. e.g. node01:443. Leave unset when members are managed with bigip_ltm_pool_attachment",
 			},
 			"monitors": {
 				Type:        schema.TypeSet,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Set:         schema.HashString,
 				Optional:    true,
-				Description: "Assign monitors to a pool.",
+				Description: "Assign monitors to a pool, by name for the monitors in the default partition or by full path.",
 			},
 
 			"allow_nat": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     true,
-				Description: "Allow NAT",
+				Default:     "yes",
+				Description: "Allow NAT, yes or no",
 			},
 
 			"allow_snat": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Default:     true,
-				Description: "Allow SNAT",
+				Default:     "yes",
+				Description: "Allow SNAT, yes or no",
 			},
 
 			"load_balancing_mode": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "round-robin",
-				Description: "Possible values: round-robin, ...",
+				Description: "Possible values: round-robin, ratio-member, least-connections-member, observed-member, predictive-member, ratio-node, least-connections-node, fastest-node, observed-node, predictive-node, dynamic-ratio-node, fastest-app-response, least-sessions, dynamic-ratio-member, weighted-least-connections-member, weighted-least-connections-node, ratio-session, ratio-least-connections-member, ratio-least-connections-node",
 			},
 
 			"slow_ramp_time": {
@@ -74,10 +81,11 @@ func resourceBigipLtmPool() *schema.Resource {
 			},
 
 			"service_down_action": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Default:     "none",
-				Description: "Possible values: none, reset, reselect, drop",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "none",
+				Description:  "Action taken on the connections of a member marked down: none, reset, reselect or drop",
+				ValidateFunc: validateStringValue([]string{"none", "reset", "reselect", "drop"}),
 			},
 
 			"reselect_tries": {
@@ -91,14 +99,17 @@ func resourceBigipLtmPool() *schema.Resource {
 }
 
 func resourceBigipLtmPoolCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
-	name := d.Get("name").(string)
+	name, err := resourceFullPath(d, client, "Pool")
+	if err != nil {
+		return err
+	}
 	d.SetId(name)
 	log.Println("[INFO] Creating pool " + name)
-	err := client.CreatePool(name)
+	err = client.CreatePool(name)
 	if err != nil {
-		return fmt.Errorf("Error retrieving pool (%s): %s", name, err)
+		return fmt.Errorf("Error creating pool (%s): %s", name, err)
 	}
 
 	err = resourceBigipLtmPoolUpdate(d, meta)
@@ -111,10 +122,9 @@ func resourceBigipLtmPoolCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipLtmPoolRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
-	d.Set("name", name)
 	log.Println("[INFO] Reading pool " + name)
 
 	pool, err := client.GetPool(name)
@@ -126,22 +136,11 @@ func resourceBigipLtmPoolRead(d *schema.ResourceData, meta interface{}) error {
 		d.SetId("")
 		return nil
 	}
-	nodes, err := client.PoolMembers(name)
-	if err != nil {
-		return err
-	}
 
-	if nodes == nil {
-		log.Printf("[WARN] Pool Member (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
 	}
 
-	nodeNames := make([]string, 0, len(nodes.PoolMembers))
-
-	for _, node := range nodes.PoolMembers {
-		nodeNames = append(nodeNames, node.FullPath)
-	}
 	if err := d.Set("allow_nat", pool.AllowNAT); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving AllowNAT to state for Pool  (%s): %s", d.Id(), err)
 	}
@@ -161,7 +160,11 @@ func resourceBigipLtmPoolRead(d *schema.ResourceData, meta interface{}) error {
 		return fmt.Errorf("[DEBUG] ERror saving ReselectTries to state for Pool  (%s): %s", d.Id(), err)
 	}
 
-	monitors := strings.Split(strings.TrimSpace(pool.Monitor), " and ")
+	monitors := []string{}
+	if monitor := strings.TrimSpace(pool.Monitor); monitor != "" {
+		monitors = strings.Split(monitor, " and ")
+	}
+	monitors = configuredMonitors(d.Get("monitors").(*schema.Set), monitors, client.config.partition())
 	if err := d.Set("monitors", makeStringSet(&monitors)); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Monitors to state for Pool  (%s): %s", d.Id(), err)
 	}
@@ -170,7 +173,7 @@ func resourceBigipLtmPoolRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceBigipLtmPoolExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	log.Println("[INFO] Checking pool " + name + " exists.")
 
@@ -189,16 +192,18 @@ func resourceBigipLtmPoolExists(d *schema.ResourceData, meta interface{}) (bool,
 }
 
 func resourceBigipLtmPoolUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	//monitors
 	var monitors []string
 	if m, ok := d.GetOk("monitors"); ok {
+		partition := client.config.partition()
 		for _, monitor := range m.(*schema.Set).List() {
-			monitors = append(monitors, monitor.(string))
+			monitors = append(monitors, monitorFullPath(partition, monitor.(string)))
 		}
+		sort.Strings(monitors)
 	}
 
 	pool := &bigip.Pool{
@@ -217,37 +222,38 @@ func resourceBigipLtmPoolUpdate(d *schema.ResourceData, meta interface{}) error
 		return err
 	}
 
-	//members
-	nodes, err := client.PoolMembers(name)
-	if err != nil {
-		return err
-	}
+	//members, left alone unless nodes changed so members added by
+	//bigip_ltm_pool_attachment are kept
+	if d.HasChange("nodes") {
+		nodes, err := client.PoolMembers(name)
+		if err != nil {
+			return err
+		}
 
-	nodeNames := make([]string, 0, len(nodes.PoolMembers))
+		nodeNames := make([]string, 0, len(nodes.PoolMembers))
 
-	for _, node := range nodes.PoolMembers {
-		nodeNames = append(nodeNames, node.Name)
-	}
+		for _, node := range nodes.PoolMembers {
+			nodeNames = append(nodeNames, node.Name)
+		}
 
-	existing := makeStringSet(&nodeNames)
-	incoming := d.Get("nodes").(*schema.Set)
-	delete := existing.Difference(incoming)
-	add := incoming.Difference(existing)
-	if delete.Len() > 0 {
-		for _, d := range delete.List() {
-			client.DeletePoolMember(name, d.(string))
+		existing := makeStringSet(&nodeNames)
+		incoming := d.Get("nodes").(*schema.Set)
+		for _, member := range existing.Difference(incoming).List() {
+			if err := client.DeletePoolMember(name, member.(string)); err != nil {
+				return fmt.Errorf("Failure removing node %s from pool %s: %s", member, name, err)
+			}
 		}
-	}
-	if add.Len() > 0 {
-		for _, d := range add.List() {
-			client.AddPoolMember(name, d.(string))
+		for _, member := range incoming.Difference(existing).List() {
+			if err := client.AddPoolMember(name, member.(string)); err != nil {
+				return fmt.Errorf("Failure adding node %s to pool %s: %s", member, name, err)
+			}
 		}
 	}
 	return resourceBigipLtmPoolRead(d, meta)
 }
 
 func resourceBigipLtmPoolDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting pool " + name)
@@ -260,3 +266,20 @@ func resourceBigipLtmPoolDelete(d *schema.ResourceData, meta interface{}) error
 	d.SetId("")
 	return nil
 }
+
+// configuredMonitors returns the monitors of a pool read from the BigIP by
+// their full path in the form they are configured, so that a monitor
+// configured as http does not show up as a change from /Common/http. Monitors
+// configured without a partition are the ones in partition.
+func configuredMonitors(configured *schema.Set, monitors []string, partition string) []string {
+	byFullPath := make(map[string]string, configured.Len())
+	for _, m := range configured.List() {
+		byFullPath[monitorFullPath(partition, m.(string))] = m.(string)
+	}
+	for i, m := range monitors {
+		if c, ok := byFullPath[strings.TrimSpace(m)]; ok {
+			monitors[i] = c
+		}
+	}
+	return monitors
+}
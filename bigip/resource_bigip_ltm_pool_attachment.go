@@ -3,18 +3,23 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmPoolAttachment() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipLtmPoolAttachmentCreate,
 		Read:   resourceBigipLtmPoolAttachmentRead,
+		Update: resourceBigipLtmPoolAttachmentUpdate,
 		Delete: resourceBigipLtmPoolAttachmentDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: resourceBigipLtmPoolAttachmentImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -27,39 +32,163 @@ func resourceBigipLtmPoolAttachment() *schema.Resource {
 			},
 
 			"node": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Node to add/remove to/from the pool. Format /partition/node_name:port. e.g. /Common/node01:443",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "Node to add/remove to/from the pool. Format /partition/node_name:port. e.g. /Common/node01:443, or /partition/node_name when port is set",
+				DiffSuppressFunc: samePoolAttachmentMember,
+			},
+
+			"port": {
+				Type:             schema.TypeInt,
+				Optional:         true,
+				ForceNew:         true,
+				Description:      "Port of the pool member, when not part of node",
+				DiffSuppressFunc: samePoolAttachmentMember,
+			},
+
+			"connection_limit": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Maximum number of concurrent connections allowed for the pool member",
+			},
+
+			"dynamic_ratio": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Dynamic ratio number of the pool member, from 1 to 100, used for dynamic ratio load balancing",
+				ValidateFunc: validateIntRange(1, 100),
+			},
+
+			"priority_group": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Priority group of the pool member, used with priority group activation of the pool",
 			},
 		},
 	}
 }
 
 func resourceBigipLtmPoolAttachmentCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	poolName := d.Get("pool").(string)
-	nodeName := d.Get("node").(string)
+	nodeName := poolAttachmentMember(d)
+
+	member, err := client.GetPoolMember(poolName, nodeName)
+	if err != nil {
+		return fmt.Errorf("Error retrieving member %s of pool %s: %s", nodeName, poolName, err)
+	}
+	if member != nil {
+		log.Printf("[INFO] Node %s is already a member of pool %s", nodeName, poolName)
+	}
 
-	log.Printf("[INFO] Adding node %s to pool: %s", nodeName, poolName)
-	err := client.AddPoolMember(poolName, nodeName)
+	// The node may be created in the same apply without a dependency on it,
+	// give it a moment to appear
+	var addErr error
+	err = waitForCondition(time.Second, poolAttachmentNodeTimeout, func() (bool, error) {
+		// The error of adding the member is wrapped, or comes from committing
+		// the transaction, so whether the node is missing is decided on the
+		// error of the BigIP
+		nodeMissing := false
+		addErr = withTransaction(client, func(client *bigipClient) error {
+			if member == nil {
+				log.Printf("[INFO] Adding node %s to pool: %s", nodeName, poolName)
+				err := client.AddPoolMember(poolName, nodeName)
+				if err != nil {
+					nodeMissing = isNodeNotFound(err)
+					return fmt.Errorf("Failure adding node %s to pool %s: %s", nodeName, poolName, err)
+				}
+			}
+			return modifyPoolAttachment(client, d)
+		})
+		if nodeMissing || isNodeNotFound(addErr) {
+			log.Printf("[WARN] Node %s does not exist yet, retrying: %v", nodeName, addErr)
+			return false, nil
+		}
+		return true, addErr
+	})
+	if err == errWaitTimeout {
+		return fmt.Errorf("%v. Create the node first, e.g. by referencing the full_path of its bigip_ltm_node in node or with depends_on", addErr)
+	}
 	if err != nil {
-		return fmt.Errorf("Failure adding node %s to pool %s: %s", nodeName, poolName, err)
+		return err
 	}
 
-	d.SetId(fmt.Sprintf("%s-%s", poolName, nodeName))
+	d.SetId(fmt.Sprintf("%s/%s", poolName, nodeName))
+
+	return resourceBigipLtmPoolAttachmentRead(d, meta)
+}
 
+func resourceBigipLtmPoolAttachmentUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	if err := modifyPoolAttachment(client, d); err != nil {
+		return err
+	}
+
+	return resourceBigipLtmPoolAttachmentRead(d, meta)
+}
+
+// poolAttachmentNodeTimeout is how long adding a member waits for its node to
+// be created.
+const poolAttachmentNodeTimeout = 30 * time.Second
+
+// nodeNotFoundRegex matches the message of the BigIP for a node that does not
+// exist, e.g. "01020036:3: The requested Node (/Common/web1) was not found."
+var nodeNotFoundRegex = regexp.MustCompile(`(?i)the requested node \(.*\) was not found`)
+
+// isNodeNotFound reports whether err is the BigIP answering that a node does
+// not exist. Other objects that are not found, such as the pool, do not
+// match.
+func isNodeNotFound(err error) bool {
+	apiErr, ok := err.(*bigip.APIError)
+	return ok && nodeNotFoundRegex.MatchString(apiErr.Message)
+}
+
+// modifyPoolAttachment sets the pool member settings of the attachment. New
+// attachments send the settings that are configured, existing ones those
+// that changed, so settings of the member managed elsewhere are kept.
+func modifyPoolAttachment(client *bigipClient, d *schema.ResourceData) error {
+	poolName := d.Get("pool").(string)
+	nodeName := poolAttachmentMember(d)
+
+	setting := func(key string) *int {
+		if d.IsNewResource() {
+			if _, ok := d.GetOk(key); !ok {
+				return nil
+			}
+		} else if !d.HasChange(key) {
+			return nil
+		}
+		v := d.Get(key).(int)
+		return &v
+	}
+	member := &bigip.PoolMember{
+		FullPath:        nodeName,
+		ConnectionLimit: setting("connection_limit"),
+		DynamicRatio:    setting("dynamic_ratio"),
+		PriorityGroup:   setting("priority_group"),
+	}
+
+	log.Printf("[INFO] Modifying member %s of pool: %s", nodeName, poolName)
+	err := client.PatchPoolMember(poolName, member)
+	if err != nil {
+		return fmt.Errorf("Failure modifying member %s of pool %s: %s", nodeName, poolName, err)
+	}
 	return nil
 }
 
 func resourceBigipLtmPoolAttachmentRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	poolName := d.Get("pool").(string)
 
 	// only add the instance that was previously defined for this resource
-	expected := d.Get("node").(string)
+	expected := poolAttachmentMember(d)
 
 	pool, err := client.GetPool(poolName)
 	if err != nil {
@@ -86,7 +215,9 @@ func resourceBigipLtmPoolAttachmentRead(d *schema.ResourceData, meta interface{}
 	found := false
 	for _, node := range nodes.PoolMembers {
 		if expected == node.FullPath {
-			d.Set("node", expected)
+			d.Set("connection_limit", reportedPoolMemberInt(node.ConnectionLimit))
+			d.Set("dynamic_ratio", reportedPoolMemberInt(node.DynamicRatio))
+			d.Set("priority_group", reportedPoolMemberInt(node.PriorityGroup))
 			found = true
 			break
 		}
@@ -101,10 +232,10 @@ func resourceBigipLtmPoolAttachmentRead(d *schema.ResourceData, meta interface{}
 }
 
 func resourceBigipLtmPoolAttachmentDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	poolName := d.Get("pool").(string)
-	nodeName := d.Get("node").(string)
+	nodeName := poolAttachmentMember(d)
 
 	log.Printf("[INFO] Removing node %s from pool: %s", nodeName, poolName)
 
@@ -116,3 +247,57 @@ func resourceBigipLtmPoolAttachmentDelete(d *schema.ResourceData, meta interface
 	d.SetId("")
 	return nil
 }
+
+// Import an attachment from an id of the form /partition/pool/node:port,
+// e.g. /Common/my-pool//Common/node01:443
+func resourceBigipLtmPoolAttachmentImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(strings.TrimPrefix(d.Id(), "/"), "/", 3)
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("Unexpected format of pool attachment id (%s), expected /partition/pool/node:port", d.Id())
+	}
+	sep := strings.LastIndex(parts[2], ":")
+	if sep < 0 {
+		return nil, fmt.Errorf("Unexpected format of pool attachment id (%s), expected /partition/pool/node:port", d.Id())
+	}
+	if _, err := strconv.Atoi(parts[2][sep+1:]); err != nil {
+		return nil, fmt.Errorf("Invalid port in pool attachment id (%s): %s", d.Id(), err)
+	}
+
+	// node is set to the member as the BigIP reports it, configurations
+	// giving the port separately match it through samePoolAttachmentMember
+	d.Set("pool", fmt.Sprintf("/%s/%s", parts[0], parts[1]))
+	d.Set("node", parts[2])
+	return []*schema.ResourceData{d}, nil
+}
+
+// poolAttachmentMember returns the name of the pool member, node:port
+func poolAttachmentMember(d *schema.ResourceData) string {
+	return poolMemberName(d.Get("node").(string), d.Get("port").(int))
+}
+
+// poolMemberName returns the name of the pool member of node and port, node
+// itself when port is 0 as node then includes the port.
+func poolMemberName(node string, port int) string {
+	if port == 0 {
+		return node
+	}
+	return fmt.Sprintf("%s:%d", node, port)
+}
+
+// samePoolAttachmentMember suppresses the diff of node and port when both
+// name the same pool member, e.g. node /Common/node01:80 and node
+// /Common/node01 with port 80.
+func samePoolAttachmentMember(k, old, new string, d *schema.ResourceData) bool {
+	oldNode, newNode := d.GetChange("node")
+	oldPort, newPort := d.GetChange("port")
+	return poolMemberName(oldNode.(string), oldPort.(int)) == poolMemberName(newNode.(string), newPort.(int))
+}
+
+// reportedPoolMemberInt returns a setting of a pool member as reported by the
+// BigIP, which leaves out settings that are 0.
+func reportedPoolMemberInt(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -81,9 +80,67 @@ func TestAccBigipLtmPool_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckPoolExists(TEST_POOL_NAME, true),
 				),
-				ResourceName:      TEST_POOL_RESOURCE,
-				ImportState:       false,
+			},
+			{
+				ResourceName:      "bigip_ltm_pool.test-pool",
+				ImportState:       true,
+				ImportStateId:     TEST_POOL_NAME,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+var TEST_POOL_ATTACHMENT_PORT_RESOURCE = `
+resource "bigip_ltm_node" "test-node" {
+	name = "` + TEST_NODE_NAME + `"
+	address = "10.10.10.10"
+}
+
+resource "bigip_ltm_pool" "test-pool" {
+	name = "` + TEST_POOL_NAME + `"
+	load_balancing_mode = "round-robin"
+}
+
+resource "bigip_ltm_pool_attachment" "test-pool_test-node" {
+	pool = "${bigip_ltm_pool.test-pool.name}"
+	node = "${bigip_ltm_node.test-node.name}"
+	port = 80
+	connection_limit = 100
+	priority_group = 10
+}
+`
+
+func TestAccBigipLtmPoolAttachment_port(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck: func() {
+			testAcctPreCheck(t)
+		},
+		Providers:    testAccProviders,
+		CheckDestroy: testCheckPoolsDestroyed,
+		Steps: []resource.TestStep{
+			{
+				Config: TEST_POOL_ATTACHMENT_PORT_RESOURCE,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "node", TEST_POOLNODE_NAME),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "port", "80"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "connection_limit", "100"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "priority_group", "10"),
+				),
+			},
+			{
+				ResourceName:      "bigip_ltm_pool_attachment.test-pool_test-node",
+				ImportState:       true,
+				ImportStateId:     TEST_POOL_NAME + "/" + TEST_POOLNODE_NAME + ":80",
 				ImportStateVerify: true,
+				// The member is imported as node, the configuration gives its port separately
+				ImportStateVerifyIgnore: []string{"node", "port"},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if node := states[0].Attributes["node"]; node != TEST_POOLNODE_NAME+":80" {
+						return fmt.Errorf("Imported node %s, expected %s:80", node, TEST_POOLNODE_NAME)
+					}
+					return nil
+				},
 			},
 		},
 	})
@@ -93,7 +150,7 @@ func TestAccBigipLtmPool_import(t *testing.T) {
 
 func testCheckPoolExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		p, err := client.GetPool(name)
 		if err != nil {
@@ -110,7 +167,7 @@ func testCheckPoolExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckPoolsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_pool" {
@@ -0,0 +1,514 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/config"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func testBigipLtmPoolServer(calls *[]string) {
+	pool := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" pool")
+		json.NewDecoder(r.Body).Decode(&pool)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Tenant1~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			pool["partition"] = "Tenant1"
+			pool["fullPath"] = "/Tenant1/test-pool"
+			json.NewEncoder(w).Encode(pool)
+			return
+		case "PUT":
+			json.NewDecoder(r.Body).Decode(&pool)
+		}
+		*calls = append(*calls, r.Method+" pool")
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Tenant1~test-pool/members", func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" members")
+		fmt.Fprintf(w, `{"items":[{"name":"10.10.10.10:80","fullPath":"/Common/10.10.10.10:80"}]}`)
+	})
+}
+
+func testBigipLtmPoolConfig(url, name, lbMode string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_pool" "test-pool" {
+			name = "%s"
+			partition = "Tenant1"
+			load_balancing_mode = "%s"
+			monitors = ["/Common/http"]
+			slow_ramp_time = 5
+			service_down_action = "reset"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, name, lbMode, url)
+}
+
+func TestAccBigipLtmPoolPartition(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPoolServer(&calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPoolConfig(server.URL, "test-pool", "round-robin"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "id", "/Tenant1/test-pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "name", "test-pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "partition", "Tenant1"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "allow_nat", "yes"),
+				),
+			},
+			{
+				Config: testBigipLtmPoolConfig(server.URL, "test-pool", "least-connections-member"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "load_balancing_mode", "least-connections-member"),
+			},
+		},
+	})
+	// Members belong to bigip_ltm_pool_attachment and are not touched
+	assert.Equal(t, []string{"POST pool", "PUT pool", "PUT pool", "DELETE pool"}, calls)
+}
+
+func TestAccBigipLtmPoolImport(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPoolServer(&calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPoolConfig(server.URL, "/Tenant1/test-pool", "round-robin"),
+			},
+			{
+				Config:            testBigipLtmPoolConfig(server.URL, "/Tenant1/test-pool", "round-robin"),
+				ResourceName:      "bigip_ltm_pool.test-pool",
+				ImportState:       true,
+				ImportStateId:     "/Tenant1/test-pool",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPoolServiceDownAction(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPoolServer(&calls)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: regexp.MustCompile(`"reset"`).ReplaceAllString(testBigipLtmPoolConfig(server.URL, "test-pool", "round-robin"), `"reselect"
+			reselect_tries = 2`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "service_down_action", "reselect"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "reselect_tries", "2"),
+				),
+			},
+		},
+	})
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      regexp.MustCompile(`"reset"`).ReplaceAllString(testBigipLtmPoolConfig(server.URL, "test-pool", "round-robin"), `"reset-connections"`),
+				ExpectError: regexp.MustCompile(`service_down_action`),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPoolMonitorNames(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPoolServer(&calls)
+	defer teardown()
+	config := strings.Replace(testBigipLtmPoolConfig(server.URL, "test-pool", "round-robin"),
+		`monitors = ["/Common/http"]`, `monitors = ["http", "/Common/tcp", "/Tenant1/custom"]`, 1)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool", "monitors.#", "3"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool",
+						fmt.Sprintf("monitors.%d", schema.HashString("http")), "http"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool",
+						fmt.Sprintf("monitors.%d", schema.HashString("/Common/tcp")), "/Common/tcp"),
+					func(s *terraform.State) error {
+						pool, err := bigip.NewSession(server.URL, "admin", "admin", nil).GetPool("/Tenant1/test-pool")
+						if err != nil {
+							return err
+						}
+						assert.Equal(t, "/Common/http and /Common/tcp and /Tenant1/custom", pool.Monitor)
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+			{
+				Config: strings.Replace(config, `"http"`, `"/Common/http"`, 1),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool",
+					fmt.Sprintf("monitors.%d", schema.HashString("/Common/http")), "/Common/http"),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmPoolMonitorDefaultPartition(t *testing.T) {
+	setup()
+	var calls []string
+	testBigipLtmPoolServer(&calls)
+	defer teardown()
+	config := strings.Replace(testBigipLtmPoolConfig(server.URL, "test-pool", "round-robin"),
+		`monitors = ["/Common/http"]`, `monitors = ["custom", "/Common/http"]`, 1)
+	config = strings.Replace(config, `password = "admin"`, `password = "admin"
+			default_partition = "Tenant1"`, 1)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool.test-pool",
+						fmt.Sprintf("monitors.%d", schema.HashString("custom")), "custom"),
+					func(s *terraform.State) error {
+						pool, err := bigip.NewSession(server.URL, "admin", "admin", nil).GetPool("/Tenant1/test-pool")
+						if err != nil {
+							return err
+						}
+						// Monitors without a partition are the ones in the default partition
+						assert.Equal(t, "/Common/http and /Tenant1/custom", pool.Monitor)
+						return nil
+					},
+				),
+			},
+			{
+				Config:   config,
+				PlanOnly: true,
+			},
+		},
+	})
+}
+
+func testBigipLtmPoolAttachmentServer(nodeCreatedAfter int, posts *int) {
+	member := false
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-pool","partition":"Common"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			*posts++
+			if *posts <= nodeCreatedAfter {
+				w.WriteHeader(http.StatusBadRequest)
+				fmt.Fprintf(w, `{"code":400,"message":"01020036:3: The requested node (/Common/test-node) was not found."}`)
+				return
+			}
+			member = true
+		}
+		if member {
+			fmt.Fprintf(w, `{"items":[{"name":"test-node:80","fullPath":"/Common/test-node:80"}]}`)
+		} else {
+			fmt.Fprintf(w, `{"items":[]}`)
+		}
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~test-node:80", func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "DELETE":
+			member = false
+		case r.Method == "GET" && !member:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"not found"}`)
+			return
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+}
+
+func testBigipLtmPoolAttachmentConfig(url string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_pool_attachment" "test-pool_test-node" {
+			pool = "/Common/test-pool"
+			node = "/Common/test-node:80"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, url)
+}
+
+func TestAccBigipLtmPoolAttachmentWaitsForNode(t *testing.T) {
+	setup()
+	posts := 0
+	testBigipLtmPoolAttachmentServer(2, &posts)
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testBigipLtmPoolAttachmentConfig(server.URL),
+					Check:  resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "id", "/Common/test-pool//Common/test-node:80"),
+				},
+			},
+		})
+		assert.Equal(t, 3, posts)
+		assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, c.sleeps)
+	})
+}
+
+func TestAccBigipLtmPoolAttachmentNodeNotFound(t *testing.T) {
+	setup()
+	posts := 0
+	testBigipLtmPoolAttachmentServer(100, &posts)
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipLtmPoolAttachmentConfig(server.URL),
+					ExpectError: regexp.MustCompile(`was not found.*Create the node first`),
+				},
+			},
+		})
+	})
+}
+
+func TestAccBigipLtmPoolAttachmentPoolNotFound(t *testing.T) {
+	setup()
+	posts := 0
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			posts++
+		}
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Pool (/Common/test-pool) was not found."}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~test-node:80", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Pool (/Common/test-pool) was not found."}`)
+	})
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipLtmPoolAttachmentConfig(server.URL),
+					ExpectError: regexp.MustCompile(`Failure adding node /Common/test-node:80 to pool /Common/test-pool: 01020036:3: The requested Pool \(/Common/test-pool\) was not found\.\s*$`),
+				},
+			},
+		})
+		// A missing pool is not waited for like a missing node
+		assert.Equal(t, 1, posts)
+		assert.Empty(t, c.sleeps)
+	})
+}
+
+func TestIsNodeNotFound(t *testing.T) {
+	assert.True(t, isNodeNotFound(&bigip.APIError{StatusCode: 400, RequestError: bigip.RequestError{Message: "01020036:3: The requested Node (/Common/web1) was not found."}}))
+	assert.False(t, isNodeNotFound(&bigip.APIError{StatusCode: 404, RequestError: bigip.RequestError{Message: "01020036:3: The requested Pool (/Common/web) was not found."}}))
+	assert.False(t, isNodeNotFound(fmt.Errorf("Failure adding node /Common/web1 to pool /Common/web: 01020036:3: The requested Pool (/Common/web) was not found.")))
+	assert.False(t, isNodeNotFound(nil))
+}
+
+// testBigipLtmPoolAttachmentSettingsServer mocks an existing node added to a
+// pool, the bodies of the requests modifying the member are recorded in
+// bodies. Settings that are 0 are left out of the member, as by the BigIP.
+func testBigipLtmPoolAttachmentSettingsServer(bodies *[]string) {
+	var member map[string]interface{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"test-pool","partition":"Common"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			member = map[string]interface{}{"name": "test-node:80", "fullPath": "/Common/test-node:80", "dynamicRatio": 1}
+		}
+		items := []interface{}{}
+		if member != nil {
+			items = append(items, member)
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"items": items})
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/pool/~Common~test-pool/members/~Common~test-node:80", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PATCH":
+			body, _ := ioutil.ReadAll(r.Body)
+			*bodies = append(*bodies, strings.TrimSpace(string(body)))
+			var settings map[string]interface{}
+			json.Unmarshal(body, &settings)
+			for k, v := range settings {
+				if v == float64(0) {
+					delete(member, k)
+				} else {
+					member[k] = v
+				}
+			}
+		case "DELETE":
+			member = nil
+		case "GET":
+			if member == nil {
+				w.WriteHeader(http.StatusNotFound)
+				fmt.Fprintf(w, `{"code":404,"message":"not found"}`)
+				return
+			}
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+}
+
+func testBigipLtmPoolAttachmentSettingsConfig(url, settings string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_pool_attachment" "test-pool_test-node" {
+			pool = "/Common/test-pool"
+			node = "/Common/test-node:80"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, settings, url)
+}
+
+func TestAccBigipLtmPoolAttachmentResetSettings(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmPoolAttachmentSettingsServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmPoolAttachmentSettingsConfig(server.URL, `
+					connection_limit = 100
+					priority_group = 2`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "connection_limit", "100"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "priority_group", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "dynamic_ratio", "1"),
+				),
+			},
+			{
+				Config: testBigipLtmPoolAttachmentSettingsConfig(server.URL, `
+					connection_limit = 0
+					priority_group = 2`),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "connection_limit", "0"),
+			},
+			{
+				Config: testBigipLtmPoolAttachmentSettingsConfig(server.URL, `
+					connection_limit = 0
+					priority_group = 2`),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmPoolAttachmentSettingsConfig(server.URL, `
+					connection_limit = 0
+					priority_group = 0`),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_pool_attachment.test-pool_test-node", "priority_group", "0"),
+			},
+		},
+	})
+	// Only the settings that are configured, then those that changed, are sent
+	assert.Equal(t, []string{
+		`{"connectionLimit":100,"priorityGroup":2}`,
+		`{"connectionLimit":0}`,
+		`{"priorityGroup":0}`,
+	}, bodies)
+}
+
+func TestAccBigipLtmPoolAttachmentDynamicRatioRange(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmPoolAttachmentSettingsConfig("http://localhost", "dynamic_ratio = 101"),
+				ExpectError: regexp.MustCompile(`"dynamic_ratio" must be between 1 and 100, got 101`),
+			},
+		},
+	})
+}
+
+func TestBigipLtmPoolAttachmentImportPlansNoChanges(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmPoolAttachmentSettingsServer(&bodies)
+	defer teardown()
+	client := &bigipClient{bigip.NewSession(server.URL, "admin", "admin", nil), &Config{}}
+	assert.Nil(t, client.AddPoolMember("/Common/test-pool", "/Common/test-node:80"))
+
+	r := resourceBigipLtmPoolAttachment()
+	// The port may be part of node or given separately
+	for _, attachment := range []map[string]interface{}{
+		{"pool": "/Common/test-pool", "node": "/Common/test-node:80"},
+		{"pool": "/Common/test-pool", "node": "/Common/test-node", "port": 80},
+	} {
+		d := r.Data(nil)
+		d.SetId("/Common/test-pool//Common/test-node:80")
+		imported, err := r.Importer.State(d, client)
+		assert.Nil(t, err)
+		state, err := r.Refresh(imported[0].State(), client)
+		assert.Nil(t, err)
+		assert.Equal(t, "/Common/test-node:80", state.Attributes["node"])
+
+		raw, err := config.NewRawConfig(attachment)
+		assert.Nil(t, err)
+		diff, err := r.Diff(state, terraform.NewResourceConfig(raw), client)
+		assert.Nil(t, err)
+		assert.True(t, diff.Empty(), "Unexpected diff after import for %v: %v", attachment, diff)
+	}
+}
@@ -0,0 +1,264 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func resourceBigipLtmProfileClientSsl() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmProfileClientSslCreate,
+		Update: resourceBigipLtmProfileClientSslUpdate,
+		Read:   resourceBigipLtmProfileClientSslRead,
+		Delete: resourceBigipLtmProfileClientSslDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the client SSL profile, either a full path such as /Common/my-clientssl or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("profile"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the profile, for referencing it from virtual servers",
+			},
+			"defaults_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent client SSL profile",
+				ValidateFunc: validateF5Name,
+			},
+			"server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Host name the profile is selected for by the server name indication (SNI) of clients",
+			},
+			"sni_default": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Use the profile for clients that send no or an unknown server name, one profile per virtual server",
+			},
+			"sni_require": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Reject clients that send no server name, requires sni_default",
+			},
+			"cert_key_chain": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Computed:    true,
+				Description: "Certificates and keys of the profile, one per key type, e.g. RSA and ECDSA",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Computed:    true,
+							Description: "Name of the entry, defaults to the name of the certificate without its .crt extension",
+						},
+						"cert": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Full path of the certificate",
+							ValidateFunc: validateF5Name,
+						},
+						"key": {
+							Type:         schema.TypeString,
+							Required:     true,
+							Description:  "Full path of the key of the certificate",
+							ValidateFunc: validateF5Name,
+						},
+						"chain": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Description:  "Full path of the bundle of intermediate certificates sent with the certificate",
+							ValidateFunc: validateF5Name,
+						},
+						"passphrase": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Passphrase of an encrypted key",
+						},
+					},
+				},
+			},
+		},
+
+		CustomizeDiff: func(d *schema.ResourceDiff, meta interface{}) error {
+			if d.Get("sni_require").(bool) && !d.Get("sni_default").(bool) {
+				return fmt.Errorf("sni_require is only allowed when sni_default is true")
+			}
+			return nil
+		},
+	}
+}
+
+// certKeyChainName returns the name of a cert_key_chain entry without one,
+// the name of its certificate without the .crt extension.
+func certKeyChainName(cert string) string {
+	_, name := parseF5Identifier(cert)
+	return strings.TrimSuffix(name, ".crt")
+}
+
+func dataToClientSsl(d *schema.ResourceData) *bigip.ClientSSLProfile {
+	profile := &bigip.ClientSSLProfile{
+		DefaultsFrom: d.Get("defaults_from").(string),
+		ServerName:   d.Get("server_name").(string),
+		SniDefault:   fmt.Sprintf("%t", d.Get("sni_default").(bool)),
+		SniRequire:   fmt.Sprintf("%t", d.Get("sni_require").(bool)),
+	}
+	for _, c := range d.Get("cert_key_chain").([]interface{}) {
+		chain := c.(map[string]interface{})
+		name := chain["name"].(string)
+		if name == "" {
+			name = certKeyChainName(chain["cert"].(string))
+		}
+		profile.CertKeyChain = append(profile.CertKeyChain, bigip.CertKeyChain{
+			Name:       name,
+			Cert:       chain["cert"].(string),
+			Key:        chain["key"].(string),
+			Chain:      chain["chain"].(string),
+			Passphrase: chain["passphrase"].(string),
+		})
+	}
+	return profile
+}
+
+// flattenCertKeyChains returns the cert_key_chain entries reported by the
+// BigIP in a stable order: the configured entries first, in their order, then
+// any other entry sorted by name. The BigIP reports the passphrases
+// encrypted, the configured ones are kept.
+func flattenCertKeyChains(reported []bigip.CertKeyChain, configured []interface{}) []interface{} {
+	byName := make(map[string]bigip.CertKeyChain, len(reported))
+	for _, chain := range reported {
+		byName[chain.Name] = chain
+	}
+	flatten := func(chain bigip.CertKeyChain, passphrase string) map[string]interface{} {
+		if chain.Chain == "none" {
+			chain.Chain = ""
+		}
+		return map[string]interface{}{
+			"name":       chain.Name,
+			"cert":       chain.Cert,
+			"key":        chain.Key,
+			"chain":      chain.Chain,
+			"passphrase": passphrase,
+		}
+	}
+
+	chains := []interface{}{}
+	for _, c := range configured {
+		entry, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name := entry["name"].(string)
+		if name == "" {
+			name = certKeyChainName(entry["cert"].(string))
+		}
+		if chain, ok := byName[name]; ok {
+			chains = append(chains, flatten(chain, entry["passphrase"].(string)))
+			delete(byName, name)
+		}
+	}
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		chains = append(chains, flatten(byName[name], ""))
+	}
+	return chains
+}
+
+func resourceBigipLtmProfileClientSslCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "client SSL profile")
+	if err != nil {
+		return err
+	}
+	log.Println("[INFO] Creating client SSL profile " + name)
+
+	config := dataToClientSsl(d)
+	config.Name = name
+	err = client.AddClientSSLProfile(config)
+	if err != nil {
+		return fmt.Errorf("Error creating client SSL profile (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipLtmProfileClientSslRead(d, meta)
+}
+
+func resourceBigipLtmProfileClientSslUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Updating client SSL profile " + name)
+
+	err := client.ModifyClientSSLProfile(name, dataToClientSsl(d))
+	if err != nil {
+		return fmt.Errorf("Error modifying client SSL profile (%s): %v", name, apiErrorDetails(err))
+	}
+	return resourceBigipLtmProfileClientSslRead(d, meta)
+}
+
+func resourceBigipLtmProfileClientSslRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+	name := d.Id()
+	obj, err := client.GetClientSSLProfile(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving client SSL profile (%s): %v", name, err)
+	}
+	if obj == nil {
+		log.Printf("[WARN] Client SSL profile (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	d.Set("defaults_from", obj.DefaultsFrom)
+	if obj.ServerName == "none" {
+		obj.ServerName = ""
+	}
+	d.Set("server_name", obj.ServerName)
+	d.Set("sni_default", obj.SniDefault == "true")
+	d.Set("sni_require", obj.SniRequire == "true")
+	if err := d.Set("cert_key_chain", flattenCertKeyChains(obj.CertKeyChain, d.Get("cert_key_chain").([]interface{}))); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving CertKeyChain to state for client SSL profile  (%s): %s", d.Id(), err)
+	}
+	return nil
+}
+
+func resourceBigipLtmProfileClientSslDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting client SSL profile " + name)
+
+	err := client.DeleteClientSSLProfile(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting client SSL profile (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
@@ -0,0 +1,172 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipLtmProfileClientSslServer mocks a client SSL profile, the BigIP
+// reports the cert_key_chain entries sorted by name, their passphrases
+// encrypted and no chain as none.
+func testBigipLtmProfileClientSslServer() {
+	var profile *bigip.ClientSSLProfile
+	save := func(r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&profile)
+		profile.FullPath = "/Common/test-clientssl"
+		if profile.DefaultsFrom == "" {
+			profile.DefaultsFrom = "/Common/clientssl"
+		}
+		sort.Slice(profile.CertKeyChain, func(i, j int) bool {
+			return profile.CertKeyChain[i].Name < profile.CertKeyChain[j].Name
+		})
+		for i := range profile.CertKeyChain {
+			if profile.CertKeyChain[i].Passphrase != "" {
+				profile.CertKeyChain[i].Passphrase = "$M$Zq$encrypted"
+			}
+			if profile.CertKeyChain[i].Chain == "" {
+				profile.CertKeyChain[i].Chain = "none"
+			}
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/client-ssl", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/client-ssl/~Common~test-clientssl", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			profile = nil
+			save(r)
+		case "DELETE":
+			profile = nil
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if profile == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested profile (/Common/test-clientssl) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileClientSslConfig(args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_client_ssl" "test-clientssl" {
+			name = "/Common/test-clientssl"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, server.URL)
+}
+
+func TestAccBigipLtmProfileClientSslCertKeyChains(t *testing.T) {
+	setup()
+	testBigipLtmProfileClientSslServer()
+	defer teardown()
+	config := testBigipLtmProfileClientSslConfig(`
+		server_name = "www.example.com"
+		sni_default = true
+		cert_key_chain {
+			cert = "/Common/www.example.com-rsa.crt"
+			key = "/Common/www.example.com-rsa.key"
+			chain = "/Common/intermediate.crt"
+			passphrase = "secret"
+		}
+		cert_key_chain {
+			name = "ecdsa"
+			cert = "/Common/www.example.com-ecdsa.crt"
+			key = "/Common/www.example.com-ecdsa.key"
+		}
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				// The step fails when the order or passphrases reported by
+				// the BigIP cause a diff
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "full_path", "/Common/test-clientssl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "defaults_from", "/Common/clientssl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "server_name", "www.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "cert_key_chain.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "cert_key_chain.0.name", "www.example.com-rsa"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "cert_key_chain.0.passphrase", "secret"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "cert_key_chain.1.name", "ecdsa"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_client_ssl.test-clientssl", "cert_key_chain.1.chain", ""),
+				),
+			},
+			{
+				// Imports get the entries sorted by name and no passphrases
+				Config:        config,
+				ResourceName:  "bigip_ltm_profile_client_ssl.test-clientssl",
+				ImportState:   true,
+				ImportStateId: "/Common/test-clientssl",
+				ImportStateCheck: func(s []*terraform.InstanceState) error {
+					attributes := s[0].Attributes
+					assert.Equal(t, "ecdsa", attributes["cert_key_chain.0.name"])
+					assert.Equal(t, "www.example.com-rsa", attributes["cert_key_chain.1.name"])
+					assert.Equal(t, "/Common/intermediate.crt", attributes["cert_key_chain.1.chain"])
+					assert.Equal(t, "", attributes["cert_key_chain.1.passphrase"])
+					assert.Equal(t, "true", attributes["sni_default"])
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmProfileClientSslSniRequire(t *testing.T) {
+	setup()
+	testBigipLtmProfileClientSslServer()
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmProfileClientSslConfig(`sni_require = true`),
+				ExpectError: regexp.MustCompile("sni_require is only allowed when sni_default is true"),
+			},
+		},
+	})
+}
+
+func TestFlattenCertKeyChains(t *testing.T) {
+	reported := []bigip.CertKeyChain{
+		{Name: "b", Cert: "/Common/b.crt", Key: "/Common/b.key", Chain: "none", Passphrase: "$M$encrypted"},
+		{Name: "c", Cert: "/Common/c.crt", Key: "/Common/c.key"},
+		{Name: "a", Cert: "/Common/a.crt", Key: "/Common/a.key", Chain: "/Common/bundle.crt"},
+	}
+	configured := []interface{}{
+		map[string]interface{}{"name": "", "cert": "/Common/c.crt", "key": "/Common/c.key", "chain": "", "passphrase": "secret"},
+	}
+	chains := flattenCertKeyChains(reported, configured)
+	var names []string
+	for _, c := range chains {
+		names = append(names, c.(map[string]interface{})["name"].(string))
+	}
+	assert.Equal(t, []string{"c", "a", "b"}, names)
+	assert.Equal(t, "secret", chains[0].(map[string]interface{})["passphrase"])
+	assert.Equal(t, "", chains[2].(map[string]interface{})["passphrase"])
+	assert.Equal(t, "", chains[2].(map[string]interface{})["chain"])
+}
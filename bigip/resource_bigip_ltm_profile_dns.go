@@ -0,0 +1,142 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func resourceBigipLtmProfileDns() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmProfileDnsCreate,
+		Update: resourceBigipLtmProfileDnsUpdate,
+		Read:   resourceBigipLtmProfileDnsRead,
+		Delete: resourceBigipLtmProfileDnsDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the DNS Profile",
+				ValidateFunc: validateF5Name,
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "name of partition",
+			},
+			"defaults_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent dns profile",
+				ValidateFunc: validateF5Name,
+			},
+
+			// The BigIP takes yes and no for the switches of DNS profiles
+			"enable_dns_express": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Answer queries for the zones of DNS Express from memory, yes or no",
+				ValidateFunc: validateStringValue([]string{"yes", "no"}),
+			},
+			"process_recursion_desired": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Process the recursion desired flag of queries, yes or no",
+				ValidateFunc: validateStringValue([]string{"yes", "no"}),
+			},
+			"unhandled_query_action": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Action for queries the profile does not answer: allow, drop, hint, noerror or reject",
+				ValidateFunc: validateStringValue([]string{"allow", "drop", "hint", "noerror", "reject"}),
+			},
+		},
+	}
+}
+
+func dataToDns(d *schema.ResourceData) *bigip.DnsProfile {
+	return &bigip.DnsProfile{
+		Name:                 d.Get("name").(string),
+		Partition:            d.Get("partition").(string),
+		DefaultsFrom:         d.Get("defaults_from").(string),
+		EnableDnsExpress:     d.Get("enable_dns_express").(string),
+		ProcessRd:            d.Get("process_recursion_desired").(string),
+		UnhandledQueryAction: d.Get("unhandled_query_action").(string),
+	}
+}
+
+func resourceBigipLtmProfileDnsCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Get("name").(string)
+	log.Println("[INFO] Creating DNS profile " + name)
+
+	err := client.AddDnsProfile(dataToDns(d))
+	if err != nil {
+		return fmt.Errorf("Error creating profile dns (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipLtmProfileDnsRead(d, meta)
+}
+
+func resourceBigipLtmProfileDnsUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Updating DNS profile " + name)
+
+	err := client.ModifyDnsProfile(name, dataToDns(d))
+	if err != nil {
+		return fmt.Errorf("Error modifying profile dns (%s): %v", name, apiErrorDetails(err))
+	}
+	return resourceBigipLtmProfileDnsRead(d, meta)
+}
+
+func resourceBigipLtmProfileDnsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+	name := d.Id()
+	obj, err := client.GetDnsProfile(name)
+	if err != nil {
+		log.Printf("[ERROR] Unable to retrieve dns Profile (%s) (%v)", name, err)
+		return err
+	}
+	if obj == nil {
+		log.Printf("[WARN] dns Profile (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", name)
+	d.Set("partition", obj.Partition)
+	d.Set("defaults_from", obj.DefaultsFrom)
+	d.Set("enable_dns_express", obj.EnableDnsExpress)
+	d.Set("process_recursion_desired", obj.ProcessRd)
+	d.Set("unhandled_query_action", obj.UnhandledQueryAction)
+
+	return nil
+}
+
+func resourceBigipLtmProfileDnsDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting Dns Profile " + name)
+
+	err := client.DeleteDnsProfile(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting profile dns (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
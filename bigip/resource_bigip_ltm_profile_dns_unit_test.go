@@ -0,0 +1,122 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmProfileDnsServer mocks a DNS profile inheriting from dns, the
+// requests are recorded in bodies.
+func testBigipLtmProfileDnsServer(bodies *[]string) {
+	profile := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &profile)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/dns", func(w http.ResponseWriter, r *http.Request) {
+		json.Unmarshal([]byte(`{"partition":"Common","defaultsFrom":"/Common/dns","enableDnsExpress":"yes","processRd":"yes","unhandledQueryAction":"allow"}`), &profile)
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/dns/~Common~dns-cache", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = map[string]interface{}{}
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if len(profile) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested profile (/Common/dns-cache) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileDnsConfig(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_dns" "dns-cache" {
+			name = "/Common/dns-cache"
+			enable_dns_express = "no"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+func TestAccBigipLtmProfileDns(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileDnsServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmProfileDnsConfig(server.URL, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_dns.dns-cache", "id", "/Common/dns-cache"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_dns.dns-cache", "defaults_from", "/Common/dns"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_dns.dns-cache", "enable_dns_express", "no"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_dns.dns-cache", "process_recursion_desired", "yes"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_dns.dns-cache", "unhandled_query_action", "allow"),
+				),
+			},
+			{
+				Config:   testBigipLtmProfileDnsConfig(server.URL, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmProfileDnsConfig(server.URL, `process_recursion_desired = "no"
+					unhandled_query_action = "reject"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_dns.dns-cache", "process_recursion_desired", "no"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_dns.dns-cache", "unhandled_query_action", "reject"),
+				),
+			},
+			{
+				Config: testBigipLtmProfileDnsConfig(server.URL, `process_recursion_desired = "no"
+					unhandled_query_action = "reject"`),
+				ResourceName:      "bigip_ltm_profile_dns.dns-cache",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"enableDnsExpress":"no"`)
+	assert.NotContains(t, bodies[0], `processRd`)
+	assert.Contains(t, bodies[1], `"processRd":"no"`)
+	assert.Contains(t, bodies[1], `"unhandledQueryAction":"reject"`)
+}
+
+func TestAccBigipLtmProfileDnsInvalidAction(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmProfileDnsConfig("http://localhost", `unhandled_query_action = "ignore"`),
+				ExpectError: regexp.MustCompile(`unhandled_query_action`),
+			},
+		},
+	})
+}
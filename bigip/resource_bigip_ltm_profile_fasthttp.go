@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmProfileFasthttp() *schema.Resource {
@@ -95,7 +95,7 @@ func resourceBigipLtmProfileFasthttp() *schema.Resource {
 }
 
 func resourceBigipLtmProfileFasthttpCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	defaultsFrom := d.Get("defaults_from").(string)
@@ -133,7 +133,7 @@ func resourceBigipLtmProfileFasthttpCreate(d *schema.ResourceData, meta interfac
 }
 
 func resourceBigipLtmProfileFasthttpUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -163,7 +163,7 @@ func resourceBigipLtmProfileFasthttpUpdate(d *schema.ResourceData, meta interfac
 }
 
 func resourceBigipLtmProfileFasthttpRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	obj, err := client.GetFasthttp(name)
 	if err != nil {
@@ -211,7 +211,7 @@ func resourceBigipLtmProfileFasthttpRead(d *schema.ResourceData, meta interface{
 }
 
 func resourceBigipLtmProfileFasthttpDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Fasthttp Profile " + name)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -77,7 +76,7 @@ func TestAccBigipLtmProfilefasthttp_import(t *testing.T) {
 
 func testCheckfasthttpProfileExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		p, err := client.GetFasthttp(name)
 		if err != nil {
@@ -94,7 +93,7 @@ func testCheckfasthttpProfileExists(name string, exists bool) resource.TestCheck
 }
 
 func testCheckfasthttpsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_profile_fasthttp" {
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmProfileFastl4() *schema.Resource {
@@ -20,91 +20,123 @@ func resourceBigipLtmProfileFastl4() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the Fastl4 Profile",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Fastl4 Profile",
+				ValidateFunc: validateF5Name,
 			},
 			"partition": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "name of partition",
 			},
 			"defaults_from": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent Fastl4 profile",
+				ValidateFunc: validateF5Name,
 			},
 			"client_timeout": {
 				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
+				Computed:    true,
+				Description: "Seconds a client has to send enough data to select a server when late binding is enabled",
 			},
 			"explicitflow_migration": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Let iRules control when flows move from software to hardware",
+				ValidateFunc: validateEnabledDisabled,
 			},
 			"hardware_syncookie": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
-			},
-			"idle_timeout": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Hardware SYN cookie support when PVA10 is present",
+				ValidateFunc: validateEnabledDisabled,
 			},
+
+			"idle_timeout": tcpTimeoutSchema("Seconds a connection is idle before it is eligible for deletion"),
+
+			"tcp_handshake_timeout": tcpTimeoutSchema("Seconds a connection may take to complete the TCP handshake"),
+
 			"iptos_toclient": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
+				Computed:    true,
+				Description: "IP ToS of packets sent to clients, a number or pass-through",
 			},
 			"iptos_toserver": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
+				Computed:    true,
+				Description: "IP ToS of packets sent to servers, a number or pass-through",
 			},
 			"keepalive_interval": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Use the parent Fastl4 profile",
+				Computed:    true,
+				Description: "Keep alive probe interval in seconds, or disabled",
+			},
+			"loose_initiation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Initialize a connection when any segment is received, not only a SYN",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"loose_close": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Close a connection when the first FIN is received, not only when both sides sent one",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"reset_on_timeout": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Reset connections that time out instead of dropping them silently",
+				ValidateFunc: validateEnabledDisabled,
 			},
 		},
 	}
 
 }
 
+func dataToFastl4(d *schema.ResourceData) *bigip.Fastl4 {
+	return &bigip.Fastl4{
+		Name:                  d.Get("name").(string),
+		Partition:             d.Get("partition").(string),
+		DefaultsFrom:          d.Get("defaults_from").(string),
+		ClientTimeout:         d.Get("client_timeout").(int),
+		ExplicitFlowMigration: d.Get("explicitflow_migration").(string),
+		HardwareSynCookie:     d.Get("hardware_syncookie").(string),
+		IdleTimeout:           d.Get("idle_timeout").(string),
+		TcpHandshakeTimeout:   d.Get("tcp_handshake_timeout").(string),
+		IpTosToClient:         d.Get("iptos_toclient").(string),
+		IpTosToServer:         d.Get("iptos_toserver").(string),
+		KeepAliveInterval:     d.Get("keepalive_interval").(string),
+		LooseInitiation:       d.Get("loose_initiation").(string),
+		LooseClose:            d.Get("loose_close").(string),
+		ResetOnTimeout:        d.Get("reset_on_timeout").(string),
+	}
+}
+
 func resourceBigipProfileLtmFastl4Create(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
-	partition := d.Get("partition").(string)
-	defaultsFrom := d.Get("defaults_from").(string)
-	clientTimeout := d.Get("client_timeout").(int)
-	explicitFlowMigration := d.Get("explicitflow_migration").(string)
-	hardwareSynCookie := d.Get("hardware_syncookie").(string)
-	idleTimeout := d.Get("idle_timeout").(string)
-	ipTosToClient := d.Get("iptos_toclient").(string)
-	ipTosToServer := d.Get("iptos_toserver").(string)
-	keepAliveInterval := d.Get("keepalive_interval").(string)
-
-	log.Println("[INFO] Creating Fastl4 profile")
-
-	err := client.CreateFastl4(
-		name,
-		partition,
-		defaultsFrom,
-		clientTimeout,
-		explicitFlowMigration,
-		hardwareSynCookie,
-		idleTimeout,
-		ipTosToClient,
-		ipTosToServer,
-		keepAliveInterval,
-	)
+	log.Println("[INFO] Creating Fastl4 profile " + name)
+
+	err := client.AddFastl4(dataToFastl4(d))
 	if err != nil {
 		log.Printf("[ERROR] Unable to Create FastL4  (%s) (%v) ", name, err)
-		return fmt.Errorf("Error retrieving profile fastl4 (%s): %s", name, err)
+		return fmt.Errorf("Error creating profile fastl4 (%s): %s", name, apiErrorDetails(err))
 	}
 
 	d.SetId(name)
@@ -112,35 +144,21 @@ func resourceBigipProfileLtmFastl4Create(d *schema.ResourceData, meta interface{
 }
 
 func resourceBigipLtmProfileFastl4Update(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
+	log.Println("[INFO] Updating Fastl4 profile " + name)
 
-	//log.Println("[INFO] Updating Route " + description)
-
-	r := &bigip.Fastl4{
-		Name:                  name,
-		Partition:             d.Get("partition").(string),
-		DefaultsFrom:          d.Get("defaults_from").(string),
-		ClientTimeout:         d.Get("client_timeout").(int),
-		ExplicitFlowMigration: d.Get("explicitflow_migration").(string),
-		HardwareSynCookie:     d.Get("hardware_syncookie").(string),
-		IdleTimeout:           d.Get("idle_timeout").(string),
-		IpTosToClient:         d.Get("iptos_toclient").(string),
-		IpTosToServer:         d.Get("iptos_toserver").(string),
-		KeepAliveInterval:     d.Get("keepalive_interval").(string),
-	}
-
-	err := client.ModifyFastl4(name, r)
+	err := client.ModifyFastl4(name, dataToFastl4(d))
 	if err != nil {
 		log.Printf("[ERROR] Unable to Modify FastL4  (%s) (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error modifying profile fastl4 (%s): %s", name, apiErrorDetails(err))
 	}
 	return resourceBigipLtmProfileFastl4Read(d, meta)
 }
 
 func resourceBigipLtmProfileFastl4Read(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	obj, err := client.GetFastl4(name)
 	if err != nil {
@@ -163,22 +181,26 @@ func resourceBigipLtmProfileFastl4Read(d *schema.ResourceData, meta interface{})
 	}
 	d.Set("hardware_syncookie", obj.HardwareSynCookie)
 	d.Set("idle_timeout", obj.IdleTimeout)
+	d.Set("tcp_handshake_timeout", obj.TcpHandshakeTimeout)
 	d.Set("iptos_toclient", obj.IpTosToClient)
 	d.Set("iptos_toserver", obj.IpTosToServer)
 	d.Set("keepalive_interval", obj.KeepAliveInterval)
+	d.Set("loose_initiation", obj.LooseInitiation)
+	d.Set("loose_close", obj.LooseClose)
+	d.Set("reset_on_timeout", obj.ResetOnTimeout)
 
 	return nil
 }
 
 func resourceBigipLtmProfileFastl4Delete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Fastl4 Profile " + name)
 
 	err := client.DeleteFastl4(name)
 	if err != nil {
-		log.Printf("[ERROR] Unable to retrieve node (%s) (%v)", name, err)
+		log.Printf("[ERROR] Unable to Delete FastL4 (%s) (%v)", name, err)
 		return err
 	}
 	d.SetId("")
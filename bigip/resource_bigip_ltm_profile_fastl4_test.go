@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -78,7 +77,7 @@ func TestAccBigipLtmProfileFastl4_import(t *testing.T) {
 
 func testCheckfastl4Exists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.GetFastl4(name)
 		if err != nil {
 			return err
@@ -94,7 +93,7 @@ func testCheckfastl4Exists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckfastl4sDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_profile_fastl4" {
@@ -0,0 +1,115 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmProfileFastl4Server mocks a FastL4 profile inheriting from
+// fastL4, which reports its timeouts as strings. The requests are recorded in
+// bodies.
+func testBigipLtmProfileFastl4Server(bodies *[]string) {
+	profile := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &profile)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/fastl4", func(w http.ResponseWriter, r *http.Request) {
+		json.Unmarshal([]byte(`{"partition":"Common","defaultsFrom":"/Common/fastL4","clientTimeout":30,"explicitFlowMigration":"disabled","hardwareSynCookie":"enabled","idleTimeout":"300","tcpHandshakeTimeout":"5","ipTosToClient":"pass-through","ipTosToServer":"pass-through","keepAliveInterval":"disabled","looseClose":"disabled","looseInitialization":"disabled","resetOnTimeout":"enabled"}`), &profile)
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/fastl4/~Common~test-fastl4", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = map[string]interface{}{}
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileFastl4Config(url, idleTimeout string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_fastl4" "test-fastl4" {
+			name = "/Common/test-fastl4"
+			idle_timeout = "%s"
+			tcp_handshake_timeout = "immediate"
+			loose_initiation = "enabled"
+			loose_close = "enabled"
+			reset_on_timeout = "disabled"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, idleTimeout, url)
+}
+
+func TestAccBigipLtmProfileFastl4Tuning(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileFastl4Server(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmProfileFastl4Config(server.URL, "indefinite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "idle_timeout", "indefinite"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "tcp_handshake_timeout", "immediate"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "loose_initiation", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "loose_close", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "reset_on_timeout", "disabled"),
+					// Settings left out are inherited from the parent profile
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "defaults_from", "/Common/fastL4"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "client_timeout", "30"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "hardware_syncookie", "enabled"),
+				),
+			},
+			{
+				Config: testBigipLtmProfileFastl4Config(server.URL, "600"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_profile_fastl4.test-fastl4", "idle_timeout", "600"),
+			},
+			{
+				Config:            testBigipLtmProfileFastl4Config(server.URL, "600"),
+				ResourceName:      "bigip_ltm_profile_fastl4.test-fastl4",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"idleTimeout":"indefinite"`)
+	assert.Contains(t, bodies[0], `"tcpHandshakeTimeout":"immediate"`)
+	assert.Contains(t, bodies[0], `"looseInitialization":"enabled"`)
+	assert.Contains(t, bodies[0], `"resetOnTimeout":"disabled"`)
+	assert.Contains(t, bodies[1], `"idleTimeout":"600"`)
+}
+
+func TestAccBigipLtmProfileFastl4InvalidTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmProfileFastl4Config("http://localhost", "forever"),
+				ExpectError: regexp.MustCompile("must be a number of seconds, immediate or indefinite"),
+			},
+		},
+	})
+}
@@ -0,0 +1,141 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func resourceBigipLtmProfileFtp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmProfileFtpCreate,
+		Update: resourceBigipLtmProfileFtpUpdate,
+		Read:   resourceBigipLtmProfileFtpRead,
+		Delete: resourceBigipLtmProfileFtpDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the FTP Profile",
+				ValidateFunc: validateF5Name,
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "name of partition",
+			},
+			"defaults_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent ftp profile",
+				ValidateFunc: validateF5Name,
+			},
+
+			"translate_extended": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Translate the extended passive and port commands of IPv6 clients for IPv4 servers and back",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"data_port": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Port the data channel of active mode FTP is opened from",
+				ValidateFunc: validateIntRange(1, 65535),
+			},
+			"inherit_parent_profile": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the settings of the parent profile for the data channel instead of those of the virtual server",
+				ValidateFunc: validateEnabledDisabled,
+			},
+		},
+	}
+}
+
+func dataToFtp(d *schema.ResourceData) *bigip.FtpProfile {
+	return &bigip.FtpProfile{
+		Name:                 d.Get("name").(string),
+		Partition:            d.Get("partition").(string),
+		DefaultsFrom:         d.Get("defaults_from").(string),
+		TranslateExtended:    d.Get("translate_extended").(string),
+		Port:                 d.Get("data_port").(int),
+		InheritParentProfile: d.Get("inherit_parent_profile").(string),
+	}
+}
+
+func resourceBigipLtmProfileFtpCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Get("name").(string)
+	log.Println("[INFO] Creating FTP profile " + name)
+
+	err := client.AddFtpProfile(dataToFtp(d))
+	if err != nil {
+		return fmt.Errorf("Error creating profile ftp (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipLtmProfileFtpRead(d, meta)
+}
+
+func resourceBigipLtmProfileFtpUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Updating FTP profile " + name)
+
+	err := client.ModifyFtpProfile(name, dataToFtp(d))
+	if err != nil {
+		return fmt.Errorf("Error modifying profile ftp (%s): %v", name, apiErrorDetails(err))
+	}
+	return resourceBigipLtmProfileFtpRead(d, meta)
+}
+
+func resourceBigipLtmProfileFtpRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+	name := d.Id()
+	obj, err := client.GetFtpProfile(name)
+	if err != nil {
+		log.Printf("[ERROR] Unable to retrieve ftp Profile (%s) (%v)", name, err)
+		return err
+	}
+	if obj == nil {
+		log.Printf("[WARN] ftp Profile (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", name)
+	d.Set("partition", obj.Partition)
+	d.Set("defaults_from", obj.DefaultsFrom)
+	d.Set("translate_extended", obj.TranslateExtended)
+	d.Set("data_port", obj.Port)
+	d.Set("inherit_parent_profile", obj.InheritParentProfile)
+
+	return nil
+}
+
+func resourceBigipLtmProfileFtpDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting Ftp Profile " + name)
+
+	err := client.DeleteFtpProfile(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting profile ftp (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
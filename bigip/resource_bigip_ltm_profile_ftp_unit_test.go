@@ -0,0 +1,122 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmProfileFtpServer mocks an FTP profile inheriting from ftp, the
+// requests are recorded in bodies.
+func testBigipLtmProfileFtpServer(bodies *[]string) {
+	profile := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &profile)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/ftp", func(w http.ResponseWriter, r *http.Request) {
+		json.Unmarshal([]byte(`{"partition":"Common","defaultsFrom":"/Common/ftp","port":20,"translateExtended":"enabled","inheritParentProfile":"disabled"}`), &profile)
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/ftp/~Common~ftp-active", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = map[string]interface{}{}
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if len(profile) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested profile (/Common/ftp-active) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileFtpConfig(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_ftp" "ftp-active" {
+			name = "/Common/ftp-active"
+			translate_extended = "disabled"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+func TestAccBigipLtmProfileFtp(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileFtpServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmProfileFtpConfig(server.URL, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_ftp.ftp-active", "id", "/Common/ftp-active"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_ftp.ftp-active", "defaults_from", "/Common/ftp"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_ftp.ftp-active", "translate_extended", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_ftp.ftp-active", "data_port", "20"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_ftp.ftp-active", "inherit_parent_profile", "disabled"),
+				),
+			},
+			{
+				Config:   testBigipLtmProfileFtpConfig(server.URL, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmProfileFtpConfig(server.URL, `data_port = 2020
+					inherit_parent_profile = "enabled"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_ftp.ftp-active", "data_port", "2020"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_ftp.ftp-active", "inherit_parent_profile", "enabled"),
+				),
+			},
+			{
+				Config: testBigipLtmProfileFtpConfig(server.URL, `data_port = 2020
+					inherit_parent_profile = "enabled"`),
+				ResourceName:      "bigip_ltm_profile_ftp.ftp-active",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"translateExtended":"disabled"`)
+	assert.NotContains(t, bodies[0], `"port"`)
+	assert.Contains(t, bodies[1], `"port":2020`)
+	assert.Contains(t, bodies[1], `"inheritParentProfile":"enabled"`)
+}
+
+func TestAccBigipLtmProfileFtpInvalidPort(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmProfileFtpConfig("http://localhost", `data_port = 70000`),
+				ExpectError: regexp.MustCompile(`"data_port" must be between 1 and 65535, got 70000`),
+			},
+		},
+	})
+}
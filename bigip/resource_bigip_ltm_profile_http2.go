@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmProfileHttp2() *schema.Resource {
@@ -60,7 +60,7 @@ func resourceBigipLtmProfileHttp2() *schema.Resource {
 }
 
 func resourceBigipLtmProfileHttp2Create(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	defaultsFrom := d.Get("defaults_from").(string)
@@ -87,7 +87,7 @@ func resourceBigipLtmProfileHttp2Create(d *schema.ResourceData, meta interface{}
 }
 
 func resourceBigipLtmProfileHttp2Update(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -110,7 +110,7 @@ func resourceBigipLtmProfileHttp2Update(d *schema.ResourceData, meta interface{}
 }
 
 func resourceBigipLtmProfileHttp2Read(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	obj, err := client.GetHttp2(name)
 	if err != nil {
@@ -138,7 +138,7 @@ func resourceBigipLtmProfileHttp2Read(d *schema.ResourceData, meta interface{})
 }
 
 func resourceBigipLtmProfileHttp2Delete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Http2 Profile " + name)
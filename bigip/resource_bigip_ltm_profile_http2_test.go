@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -68,7 +67,7 @@ func TestAccBigipLtmProfileHttp2_import(t *testing.T) {
 
 func testCheckHttp2Exists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.GetHttp2(name)
 		if err != nil {
 			return err
@@ -84,7 +83,7 @@ func testCheckHttp2Exists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckHttp2sDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_profile_http2" {
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmProfileHttpcompress() *schema.Resource {
@@ -20,15 +20,25 @@ func resourceBigipLtmProfileHttpcompress() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Httpcompress Profile",
+				ValidateFunc: validateF5Name,
+			},
+			"partition": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the Httpcompress Profile",
+				Optional:    true,
+				Computed:    true,
+				Description: "name of partition",
 			},
 
 			"defaults_from": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Use the parent Httpcompress profile",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent Httpcompress profile",
+				ValidateFunc: validateF5Name,
 			},
 
 			"uri_exclude": {
@@ -36,66 +46,82 @@ func resourceBigipLtmProfileHttpcompress() *schema.Resource {
 				Set:         schema.HashString,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				Description: "Servers Address",
+				Description: "Regular expressions of the request URIs whose responses are not compressed",
 			},
 			"uri_include": {
 				Type:        schema.TypeSet,
 				Set:         schema.HashString,
 				Elem:        &schema.Schema{Type: schema.TypeString},
 				Optional:    true,
-				Description: "Servers Address",
+				Description: "Regular expressions of the request URIs whose responses are compressed",
+			},
+			"content_type_exclude": {
+				Type:        schema.TypeSet,
+				Set:         schema.HashString,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Regular expressions of the content types of the responses that are not compressed",
+			},
+			"content_type_include": {
+				Type:        schema.TypeSet,
+				Set:         schema.HashString,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "Regular expressions of the content types of the responses that are compressed",
+			},
+			"gzip_compression_level": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Compression level of gzip, from 1 for the fastest to 9 for the smallest",
+				ValidateFunc: validateIntRange(1, 9),
 			},
 		},
 	}
 }
 
+func dataToHttpcompress(d *schema.ResourceData) *bigip.Httpcompress {
+	return &bigip.Httpcompress{
+		Name:               d.Get("name").(string),
+		Partition:          d.Get("partition").(string),
+		DefaultsFrom:       d.Get("defaults_from").(string),
+		UriExclude:         setToStringSlice(d.Get("uri_exclude").(*schema.Set)),
+		UriInclude:         setToStringSlice(d.Get("uri_include").(*schema.Set)),
+		ContentTypeExclude: setToStringSlice(d.Get("content_type_exclude").(*schema.Set)),
+		ContentTypeInclude: setToStringSlice(d.Get("content_type_include").(*schema.Set)),
+		GzipLevel:          d.Get("gzip_compression_level").(int),
+	}
+}
+
 func resourceBigipLtmProfileHttpcompressCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
-	defaultsFrom := d.Get("defaults_from").(string)
-	uriExclude := setToStringSlice(d.Get("uri_exclude").(*schema.Set))
-	uriInclude := setToStringSlice(d.Get("uri_include").(*schema.Set))
-
-	log.Println("[INFO] Creating Httpcompress profile")
-
-	err := client.CreateHttpcompress(
-		name,
-		defaultsFrom,
-		uriExclude,
-		uriInclude,
-	)
+	log.Println("[INFO] Creating Httpcompress profile " + name)
 
+	err := client.AddHttpcompress(dataToHttpcompress(d))
 	if err != nil {
-		return fmt.Errorf("Error retrieving profile Http compress (%s): %s", name, err)
+		return fmt.Errorf("Error creating profile Http compress (%s): %v", name, apiErrorDetails(err))
 	}
 	d.SetId(name)
 	return resourceBigipLtmProfileHttpcompressRead(d, meta)
 }
 
 func resourceBigipLtmProfileHttpcompressUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
+	log.Println("[INFO] Updating Httpcompress profile " + name)
 
-	//log.Println("[INFO] Updating Route " + description)
-
-	r := &bigip.Httpcompress{
-		Name:         name,
-		DefaultsFrom: d.Get("defaults_from").(string),
-		UriExclude:   setToStringSlice(d.Get("uri_exclude").(*schema.Set)),
-		UriInclude:   setToStringSlice(d.Get("uri_include").(*schema.Set)),
-	}
-
-	err := client.ModifyHttpcompress(name, r)
+	err := client.ModifyHttpcompress(name, dataToHttpcompress(d))
 	if err != nil {
-		return fmt.Errorf("Error modifying  profile Http compress (%s): %s", name, err)
+		return fmt.Errorf("Error modifying profile Http compress (%s): %v", name, apiErrorDetails(err))
 	}
 	return resourceBigipLtmProfileHttpcompressRead(d, meta)
 }
 
 func resourceBigipLtmProfileHttpcompressRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	obj, err := client.GetHttpcompress(name)
 	if err != nil {
@@ -108,26 +134,34 @@ func resourceBigipLtmProfileHttpcompressRead(d *schema.ResourceData, meta interf
 		return nil
 	}
 	d.Set("name", name)
+	d.Set("partition", obj.Partition)
+	d.Set("defaults_from", obj.DefaultsFrom)
+	d.Set("gzip_compression_level", obj.GzipLevel)
 	if err := d.Set("uri_include", obj.UriInclude); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving UriInclude to state for Http Compress profile  (%s): %s", d.Id(), err)
 	}
 	if err := d.Set("uri_exclude", obj.UriExclude); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving UriExclude to state for Http Compress profile  (%s): %s", d.Id(), err)
 	}
+	if err := d.Set("content_type_include", obj.ContentTypeInclude); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving ContentTypeInclude to state for Http Compress profile  (%s): %s", d.Id(), err)
+	}
+	if err := d.Set("content_type_exclude", obj.ContentTypeExclude); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving ContentTypeExclude to state for Http Compress profile  (%s): %s", d.Id(), err)
+	}
 
 	return nil
 }
 
 func resourceBigipLtmProfileHttpcompressDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Httpcompress Profile " + name)
 
 	err := client.DeleteHttpcompress(name)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Delete Httpcompress  (%s) (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error deleting profile Http compress (%s): %v", name, apiErrorDetails(err))
 	}
 	d.SetId("")
 	return nil
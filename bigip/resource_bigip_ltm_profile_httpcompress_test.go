@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -72,7 +71,7 @@ func TestAccBigipLtmProfileHttpcompress_import(t *testing.T) {
 
 func testCheckHttpcompressExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.GetHttpcompress(name)
 		if err != nil {
 			return err
@@ -88,7 +87,7 @@ func testCheckHttpcompressExists(name string, exists bool) resource.TestCheckFun
 }
 
 func testCheckHttpcompresssDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_profile_httpcompress" {
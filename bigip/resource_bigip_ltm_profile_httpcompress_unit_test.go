@@ -0,0 +1,129 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmProfileHttpcompressServer mocks an HTTP compression profile
+// inheriting from httpcompression, the requests are recorded in bodies. The
+// lists are reported back reversed, as the BigIP does not keep their order.
+func testBigipLtmProfileHttpcompressServer(bodies *[]string) {
+	profile := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &profile)
+		for _, value := range profile {
+			if list, ok := value.([]interface{}); ok {
+				for i, j := 0, len(list)-1; i < j; i, j = i+1, j-1 {
+					list[i], list[j] = list[j], list[i]
+				}
+			}
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/http-compression", func(w http.ResponseWriter, r *http.Request) {
+		json.Unmarshal([]byte(`{"partition":"Common","defaultsFrom":"/Common/httpcompression","gzipLevel":1}`), &profile)
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/http-compression/~Common~compress", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = map[string]interface{}{}
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if len(profile) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested profile (/Common/compress) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileHttpcompressConfig(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_http_compression" "compress" {
+			name = "/Common/compress"
+			content_type_include = ["text/", "application/json"]
+			uri_exclude = ["/download/.*", "/video/.*"]
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+func TestAccBigipLtmProfileHttpcompress(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileHttpcompressServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmProfileHttpcompressConfig(server.URL, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.compress", "id", "/Common/compress"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.compress", "defaults_from", "/Common/httpcompression"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.compress", "gzip_compression_level", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.compress", "content_type_include.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.compress",
+						fmt.Sprintf("content_type_include.%d", schema.HashString("application/json")), "application/json"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.compress",
+						fmt.Sprintf("uri_exclude.%d", schema.HashString("/video/.*")), "/video/.*"),
+				),
+			},
+			{
+				Config:   testBigipLtmProfileHttpcompressConfig(server.URL, ""),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmProfileHttpcompressConfig(server.URL, `gzip_compression_level = 6`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_profile_http_compression.compress", "gzip_compression_level", "6"),
+			},
+			{
+				Config:            testBigipLtmProfileHttpcompressConfig(server.URL, `gzip_compression_level = 6`),
+				ResourceName:      "bigip_ltm_profile_http_compression.compress",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"contentTypeInclude":[`)
+	assert.NotContains(t, bodies[0], `gzipLevel`)
+	assert.Contains(t, bodies[1], `"gzipLevel":6`)
+}
+
+func TestAccBigipLtmProfileHttpcompressInvalidLevel(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmProfileHttpcompressConfig("http://localhost", `gzip_compression_level = 10`),
+				ExpectError: regexp.MustCompile(`"gzip_compression_level" must be between 1 and 9, got 10`),
+			},
+		},
+	})
+}
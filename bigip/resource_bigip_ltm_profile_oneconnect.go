@@ -3,9 +3,11 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"net"
+	"regexp"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmProfileOneconnect() *schema.Resource {
@@ -20,51 +22,69 @@ func resourceBigipLtmProfileOneconnect() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the Oneconnect Profile",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the Oneconnect Profile, either a full path such as /Common/my-oneconnect or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
 			},
-			"partition": {
+			"partition": partitionSchema("profile"),
+			"full_path": {
 				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "name of partition",
+				Computed:    true,
+				Description: "Full path of the profile, for referencing it from virtual servers",
 			},
 			"defaults_from": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Use the parent oneconnect profile",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent oneconnect profile",
+				ValidateFunc: validateF5Name,
 			},
 
 			"idle_timeout_override": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "idleTimeoutOverride can be enabled or disabled",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Idle timeout of the reused server side connections: disabled to use the one of the protocol profile, indefinite or a number of seconds",
+				ValidateFunc: validateOneconnectIdleTimeout,
 			},
 
 			"share_pools": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "sharePools can be enabled or disabled",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "sharePools can be enabled or disabled",
+				ValidateFunc: validateEnabledDisabled,
 			},
 			"source_mask": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "source_mask can be 255.255.255.255",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Mask applied to the client address to find a server side connection to reuse, e.g. 255.255.255.255 or any",
+				ValidateFunc: validateIPMask,
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					// The BigIP reports a mask of 0.0.0.0 as any
+					return oneconnectSourceMask(old) == oneconnectSourceMask(new)
+				},
 			},
 
 			"max_age": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 				Description: "max_age has integer value typical 3600 sec",
 			},
 			"max_reuse": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 				Description: "max_reuse has integer value typical 1000 sec",
 			},
 			"max_size": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 				Description: "max_size has integer value typical 1000 sec",
 			},
 		},
@@ -72,71 +92,71 @@ func resourceBigipLtmProfileOneconnect() *schema.Resource {
 
 }
 
-func resourceBigipLtmProfileOneconnectCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
-
-	name := d.Get("name").(string)
-	partition := d.Get("partition").(string)
-	defaultsFrom := d.Get("defaults_from").(string)
-	sharePools := d.Get("share_pools").(string)
-	maxAge := d.Get("max_age").(int)
-	maxReuse := d.Get("max_reuse").(int)
-	maxSize := d.Get("max_size").(int)
-	sourceMask := d.Get("source_mask").(string)
-	idleTimeoutOverride := d.Get("idle_timeout_override").(string)
-
-	log.Println("[INFO] Creating OneConnect profile")
-
-	err := client.CreateOneconnect(
-		name,
-		idleTimeoutOverride,
-		partition,
-		defaultsFrom,
-		sharePools,
-		sourceMask,
-		maxAge,
-		maxReuse,
-		maxSize,
-	)
+var oneconnectIdleTimeoutRegex = regexp.MustCompile(`^(disabled|indefinite|[0-9]+)$`)
 
-	if err != nil {
-		return fmt.Errorf("Error create profile oneConnect (%s): %s", name, err)
+func validateOneconnectIdleTimeout(value interface{}, field string) (ws []string, errors []error) {
+	if !oneconnectIdleTimeoutRegex.MatchString(value.(string)) {
+		errors = append(errors, fmt.Errorf("%q must be disabled, indefinite or a number of seconds, got %q", field, value))
 	}
-	d.SetId(name)
-	return resourceBigipLtmProfileOneconnectRead(d, meta)
+	return
 }
 
-func resourceBigipLtmProfileOneconnectUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
-
-	name := d.Id()
-
-	//log.Println("[INFO] Updating Route " + description)
+// oneconnectSourceMask returns the source mask as the BigIP reports it, the
+// masks 0.0.0.0 and :: are any.
+func oneconnectSourceMask(mask string) string {
+	if ip := net.ParseIP(mask); ip != nil && ip.IsUnspecified() {
+		return "any"
+	}
+	return mask
+}
 
-	r := &bigip.Oneconnect{
-		Name:                name,
-		IdleTimeoutOverride: d.Get("idle_timeout_override").(string),
-		Partition:           d.Get("partition").(string),
+func dataToOneconnect(d *schema.ResourceData) *bigip.Oneconnect {
+	return &bigip.Oneconnect{
 		DefaultsFrom:        d.Get("defaults_from").(string),
+		IdleTimeoutOverride: d.Get("idle_timeout_override").(string),
 		SharePools:          d.Get("share_pools").(string),
 		SourceMask:          d.Get("source_mask").(string),
 		MaxAge:              d.Get("max_age").(int),
-		MaxSize:             d.Get("max_size").(int),
 		MaxReuse:            d.Get("max_reuse").(int),
+		MaxSize:             d.Get("max_size").(int),
 	}
+}
+
+func resourceBigipLtmProfileOneconnectCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
 
-	err := client.ModifyOneconnect(name, r)
+	name, err := resourceFullPath(d, client, "OneConnect profile")
 	if err != nil {
+		return err
+	}
+	log.Println("[INFO] Creating OneConnect profile " + name)
 
-		log.Printf("[ERROR] Unable to Modify OneConnect profile   (%s) (%v) ", name, err)
+	config := dataToOneconnect(d)
+	config.Name = name
+	err = client.AddOneconnect(config)
+	if err != nil {
+		return fmt.Errorf("Error create profile oneConnect (%s): %s", name, err)
+	}
+	d.SetId(name)
+	return resourceBigipLtmProfileOneconnectRead(d, meta)
+}
+
+func resourceBigipLtmProfileOneconnectUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
 
+	name := d.Id()
+	log.Println("[INFO] Updating OneConnect profile " + name)
+
+	err := client.ModifyOneconnect(name, dataToOneconnect(d))
+	if err != nil {
+		log.Printf("[ERROR] Unable to Modify OneConnect profile   (%s) (%v) ", name, err)
 		return err
 	}
 	return resourceBigipLtmProfileOneconnectRead(d, meta)
 }
 
 func resourceBigipLtmProfileOneconnectRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	obj, err := client.GetOneconnect(name)
 	if err != nil {
@@ -147,8 +167,9 @@ func resourceBigipLtmProfileOneconnectRead(d *schema.ResourceData, meta interfac
 		d.SetId("")
 		return nil
 	}
-	d.Set("name", name)
-	d.Set("partition", obj.Partition)
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
 	if err := d.Set("defaults_from", obj.DefaultsFrom); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving DefaultsFrom to state for Onceconnect profile  (%s): %s", d.Id(), err)
 	}
@@ -169,7 +190,7 @@ func resourceBigipLtmProfileOneconnectRead(d *schema.ResourceData, meta interfac
 }
 
 func resourceBigipLtmProfileOneconnectDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting OneConnect Profile " + name)
@@ -178,10 +199,6 @@ func resourceBigipLtmProfileOneconnectDelete(d *schema.ResourceData, meta interf
 	if err != nil {
 		return fmt.Errorf("Error Deleting profile oneConnect (%s): %s", name, err)
 	}
-	if err == nil {
-		log.Printf("[WARN] OneConnect profile  (%s) not found, removing from state", d.Id())
-		d.SetId("")
-		return nil
-	}
+	d.SetId("")
 	return nil
 }
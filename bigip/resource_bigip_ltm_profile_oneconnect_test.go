@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -65,8 +64,10 @@ func TestAccBigipLtmProfileoneconnect_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckoneconnectExists(TEST_ONECONNECT_NAME, true),
 				),
-				ResourceName:      TEST_ONECONNECT_NAME,
-				ImportState:       false,
+			},
+			{
+				ResourceName:      "bigip_ltm_profile_oneconnect.test-oneconnect",
+				ImportState:       true,
 				ImportStateVerify: true,
 			},
 		},
@@ -75,7 +76,7 @@ func TestAccBigipLtmProfileoneconnect_import(t *testing.T) {
 
 func testCheckoneconnectExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.GetOneconnect(name)
 		if err != nil {
 			return err
@@ -92,7 +93,7 @@ func testCheckoneconnectExists(name string, exists bool) resource.TestCheckFunc
 }
 
 func testCheckoneconnectsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_profile_oneconnect" {
@@ -0,0 +1,162 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmProfileOneconnectServer mocks a OneConnect profile inheriting
+// from oneconnect, the requests are recorded in bodies.
+func testBigipLtmProfileOneconnectServer(bodies *[]string) {
+	profile := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &profile)
+		if profile["sourceMask"] == "0.0.0.0" {
+			profile["sourceMask"] = "any"
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/one-connect", func(w http.ResponseWriter, r *http.Request) {
+		json.Unmarshal([]byte(`{"partition":"Common","defaultsFrom":"/Common/oneconnect","idleTimeoutOverride":"disabled","maxAge":86400,"maxReuse":1000,"maxSize":10000,"sharePools":"disabled","sourceMask":"any"}`), &profile)
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/one-connect/~Common~test-oneconnect", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = map[string]interface{}{}
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileOneconnectConfig(args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_oneconnect" "test-oneconnect" {
+			name = "/Common/test-oneconnect"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, server.URL)
+}
+
+func TestAccBigipLtmProfileOneconnectDefaults(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileOneconnectServer(&bodies)
+	defer teardown()
+	config := testBigipLtmProfileOneconnectConfig(`source_mask = "0.0.0.0"`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				// The step fails when the values inherited from the parent
+				// profile or the mask reported as any cause a diff
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "defaults_from", "/Common/oneconnect"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "full_path", "/Common/test-oneconnect"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "source_mask", "any"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "idle_timeout_override", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "max_age", "86400"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "max_reuse", "1000"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "share_pools", "disabled"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_profile_oneconnect.test-oneconnect",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testBigipLtmProfileOneconnectConfig(`
+					source_mask = "255.255.255.0"
+					idle_timeout_override = "indefinite"
+					max_reuse = 500
+					share_pools = "enabled"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "source_mask", "255.255.255.0"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "max_reuse", "500"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "max_age", "86400"),
+				),
+			},
+		},
+	})
+	// Unset values are left to the parent profile on create
+	assert.JSONEq(t, `{"name":"/Common/test-oneconnect","sourceMask":"0.0.0.0"}`, bodies[0])
+}
+
+func TestAccBigipLtmProfileOneconnectPartition(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileOneconnectServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "bigip_ltm_profile_oneconnect" "test-oneconnect" {
+						name = "test-oneconnect"
+						partition = "Common"
+					}
+					provider "bigip" {
+						address = "` + server.URL + `"
+						username = "admin"
+						password = "admin"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "name", "test-oneconnect"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_oneconnect.test-oneconnect", "full_path", "/Common/test-oneconnect"),
+				),
+			},
+		},
+	})
+	assert.JSONEq(t, `{"name":"/Common/test-oneconnect"}`, bodies[0])
+}
+
+func TestAccBigipLtmProfileOneconnectInvalid(t *testing.T) {
+	for args, expected := range map[string]string{
+		`source_mask = "255.0.255.0"`:       `"source_mask" must be a contiguous IP mask such as 255.255.255.0, got "255.0.255.0"`,
+		`source_mask = "/24"`:               `"source_mask" must be an IP mask such as 255.255.255.0 or any, got "/24"`,
+		`idle_timeout_override = "forever"`: `"idle_timeout_override" must be disabled, indefinite or a number of seconds, got "forever"`,
+		`share_pools = "yes"`:               `share_pools`,
+	} {
+		setup()
+		var bodies []string
+		testBigipLtmProfileOneconnectServer(&bodies)
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipLtmProfileOneconnectConfig(args),
+					ExpectError: regexp.MustCompile(regexp.QuoteMeta(expected)),
+				},
+			},
+		})
+		teardown()
+	}
+}
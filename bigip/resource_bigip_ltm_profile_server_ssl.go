@@ -0,0 +1,197 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func resourceBigipLtmProfileServerSsl() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmProfileServerSslCreate,
+		Update: resourceBigipLtmProfileServerSslUpdate,
+		Read:   resourceBigipLtmProfileServerSslRead,
+		Delete: resourceBigipLtmProfileServerSslDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the server SSL profile, either a full path such as /Common/my-serverssl or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("profile"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the profile, for referencing it from virtual servers",
+			},
+			"defaults_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent server SSL profile",
+				ValidateFunc: validateF5Name,
+			},
+			"ciphers": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "OpenSSL cipher string of the ciphers offered to servers, e.g. ECDHE+AES-GCM:!SSLv3, kept exactly as written",
+			},
+			"server_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "Host name sent to servers as server name indication (SNI)",
+			},
+			"secure_renegotiation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Secure renegotiation with servers: require, require-strict or request",
+				ValidateFunc: validateStringValue([]string{"require", "require-strict", "request"}),
+			},
+			"ssl_forward_proxy": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "SSL forward proxy, enabled or disabled",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"cert": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Full path of the certificate the BigIP authenticates with to servers requesting a client certificate",
+				ValidateFunc: validateF5Name,
+			},
+			"key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Full path of the key of cert",
+				ValidateFunc: validateF5Name,
+			},
+			"passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passphrase of an encrypted key",
+			},
+		},
+	}
+}
+
+// dataToServerSsl returns the profile as configured, cert and key are only
+// checked here as key is unknown at plan time when only cert is configured.
+func dataToServerSsl(d *schema.ResourceData) (*bigip.ServerSSLProfile, error) {
+	if (d.Get("cert").(string) == "") != (d.Get("key").(string) == "") {
+		return nil, fmt.Errorf("cert and key must be set together")
+	}
+	return &bigip.ServerSSLProfile{
+		DefaultsFrom:        d.Get("defaults_from").(string),
+		Ciphers:             d.Get("ciphers").(string),
+		ServerName:          d.Get("server_name").(string),
+		SecureRenegotiation: d.Get("secure_renegotiation").(string),
+		SslForwardProxy:     d.Get("ssl_forward_proxy").(string),
+		Cert:                d.Get("cert").(string),
+		Key:                 d.Get("key").(string),
+		Passphrase:          d.Get("passphrase").(string),
+	}, nil
+}
+
+// reportedNone returns the value reported by the BigIP, with none, the value
+// of unset references, as empty.
+func reportedNone(value string) string {
+	if value == "none" {
+		return ""
+	}
+	return value
+}
+
+func resourceBigipLtmProfileServerSslCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "server SSL profile")
+	if err != nil {
+		return err
+	}
+	log.Println("[INFO] Creating server SSL profile " + name)
+
+	config, err := dataToServerSsl(d)
+	if err != nil {
+		return err
+	}
+	config.Name = name
+	err = client.AddServerSSLProfile(config)
+	if err != nil {
+		return fmt.Errorf("Error creating server SSL profile (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipLtmProfileServerSslRead(d, meta)
+}
+
+func resourceBigipLtmProfileServerSslUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Updating server SSL profile " + name)
+
+	config, err := dataToServerSsl(d)
+	if err != nil {
+		return err
+	}
+	err = client.ModifyServerSSLProfile(name, config)
+	if err != nil {
+		return fmt.Errorf("Error modifying server SSL profile (%s): %v", name, apiErrorDetails(err))
+	}
+	return resourceBigipLtmProfileServerSslRead(d, meta)
+}
+
+func resourceBigipLtmProfileServerSslRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+	name := d.Id()
+	obj, err := client.GetServerSSLProfile(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving server SSL profile (%s): %v", name, err)
+	}
+	if obj == nil {
+		log.Printf("[WARN] Server SSL profile (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	d.Set("defaults_from", obj.DefaultsFrom)
+	// The cipher string is stored as reported, the BigIP keeps it as sent
+	d.Set("ciphers", obj.Ciphers)
+	d.Set("server_name", reportedNone(obj.ServerName))
+	d.Set("secure_renegotiation", obj.SecureRenegotiation)
+	d.Set("ssl_forward_proxy", obj.SslForwardProxy)
+	d.Set("cert", reportedNone(obj.Cert))
+	d.Set("key", reportedNone(obj.Key))
+	// The BigIP reports the passphrase encrypted, the configured one is kept
+	return nil
+}
+
+func resourceBigipLtmProfileServerSslDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting server SSL profile " + name)
+
+	err := client.DeleteServerSSLProfile(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting server SSL profile (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
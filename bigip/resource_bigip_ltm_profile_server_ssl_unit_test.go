@@ -0,0 +1,158 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipLtmProfileServerSslServer mocks a server SSL profile, the BigIP
+// reports unset references as none and the passphrase encrypted. The
+// profiles sent are recorded in sent.
+func testBigipLtmProfileServerSslServer(sent *[]bigip.ServerSSLProfile) {
+	var profile *bigip.ServerSSLProfile
+	save := func(r *http.Request) {
+		profile = nil
+		json.NewDecoder(r.Body).Decode(&profile)
+		*sent = append(*sent, *profile)
+		profile.FullPath = "/Common/test-serverssl"
+		if profile.DefaultsFrom == "" {
+			profile.DefaultsFrom = "/Common/serverssl"
+		}
+		if profile.Ciphers == "" {
+			profile.Ciphers = "DEFAULT"
+		}
+		if profile.SecureRenegotiation == "" {
+			profile.SecureRenegotiation = "require-strict"
+		}
+		if profile.SslForwardProxy == "" {
+			profile.SslForwardProxy = "disabled"
+		}
+		for _, ref := range []*string{&profile.ServerName, &profile.Cert, &profile.Key} {
+			if *ref == "" {
+				*ref = "none"
+			}
+		}
+		if profile.Passphrase != "" {
+			profile.Passphrase = "$M$Zq$encrypted"
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/server-ssl", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/server-ssl/~Common~test-serverssl", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = nil
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if profile == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested profile (/Common/test-serverssl) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileServerSslConfig(args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_server_ssl" "test-serverssl" {
+			name = "/Common/test-serverssl"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, server.URL)
+}
+
+func TestAccBigipLtmProfileServerSsl(t *testing.T) {
+	setup()
+	var sent []bigip.ServerSSLProfile
+	testBigipLtmProfileServerSslServer(&sent)
+	defer teardown()
+	ciphers := "ECDHE-RSA-AES128-GCM-SHA256:ECDHE+AES-GCM:!SSLv3:!RC4:@STRENGTH"
+	tuned := testBigipLtmProfileServerSslConfig(fmt.Sprintf(`
+		ciphers = "%s"
+		server_name = "backend.example.com"
+		secure_renegotiation = "require"
+		ssl_forward_proxy = "enabled"
+		cert = "/Common/backend-client.crt"
+		key = "/Common/backend-client.key"
+		passphrase = "secret"
+	`, ciphers))
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmProfileServerSslConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "full_path", "/Common/test-serverssl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "defaults_from", "/Common/serverssl"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "ciphers", "DEFAULT"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "server_name", ""),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "cert", ""),
+				),
+			},
+			{
+				Config: tuned,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "ciphers", ciphers),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "server_name", "backend.example.com"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "secure_renegotiation", "require"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "ssl_forward_proxy", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "cert", "/Common/backend-client.crt"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "key", "/Common/backend-client.key"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_server_ssl.test-serverssl", "passphrase", "secret"),
+				),
+			},
+			{
+				// Imports read all the tuned settings, the passphrase is reported encrypted
+				Config:                  tuned,
+				ResourceName:            "bigip_ltm_profile_server_ssl.test-serverssl",
+				ImportState:             true,
+				ImportStateId:           "/Common/test-serverssl",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"passphrase"},
+			},
+		},
+	})
+	// The cipher string is sent as written
+	assert.Equal(t, ciphers, sent[1].Ciphers)
+	assert.Equal(t, "secret", sent[1].Passphrase)
+}
+
+func TestAccBigipLtmProfileServerSslCertWithoutKey(t *testing.T) {
+	setup()
+	var sent []bigip.ServerSSLProfile
+	testBigipLtmProfileServerSslServer(&sent)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmProfileServerSslConfig(`cert = "/Common/backend-client.crt"`),
+				ExpectError: regexp.MustCompile(`cert and key must be set together`),
+			},
+		},
+	})
+	assert.Empty(t, sent)
+}
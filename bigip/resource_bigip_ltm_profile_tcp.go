@@ -3,11 +3,15 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"strconv"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
+// tcpTimeoutIndefinite is how the BigIP reports an indefinite timeout.
+const tcpTimeoutIndefinite = "4294967295"
+
 func resourceBigipLtmProfileTcp() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipLtmProfileTcpCreate,
@@ -20,94 +24,140 @@ func resourceBigipLtmProfileTcp() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the TCP Profile",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the TCP Profile",
+				ValidateFunc: validateF5Name,
 			},
 			"partition": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "name of partition",
 			},
 			"defaults_from": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Use the parent tcp profile",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent tcp profile",
+				ValidateFunc: validateF5Name,
 			},
 
-			"idle_timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "idle_timeout can be given value",
-			},
+			"idle_timeout": tcpTimeoutSchema("Seconds a connection is idle before it is eligible for deletion"),
 
-			"close_wait_timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "close wait timer integer",
-			},
+			"close_wait_timeout": tcpTimeoutSchema("Seconds a connection remains in the LAST-ACK state before quitting"),
 
-			"finwait_2timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "timer integer",
-			},
+			"finwait_2timeout": tcpTimeoutSchema("Seconds a connection remains in the FIN-WAIT-2 state before quitting"),
 
-			"finwait_timeout": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "fin wait timer integer",
-			},
+			"finwait_timeout": tcpTimeoutSchema("Seconds a connection remains in the FIN-WAIT-1 or closing state before quitting"),
 
 			"keepalive_interval": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 				Description: "keepalive_interval timer integer",
 			},
 
 			"deferred_accept": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Defferred accept",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Defferred accept",
+				ValidateFunc: validateEnabledDisabled,
 			},
 			"fast_open": {
-				Type:        schema.TypeString,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "fast_open value ",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"nagle": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Delay sending small segments until earlier ones are acknowledged: enabled, disabled or auto",
+				ValidateFunc: validateStringValue([]string{"enabled", "disabled", "auto"}),
+			},
+			"proxy_buffer_high": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "Bytes of the proxy buffer above which the system stops reading from the peer",
+			},
+			"proxy_buffer_low": {
+				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "fast_open value ",
+				Computed:    true,
+				Description: "Bytes of the proxy buffer below which the system resumes reading from the peer",
 			},
 		},
 	}
 
 }
 
+// tcpTimeoutSchema returns the schema of a timeout of the TCP, FastL4 or UDP
+// profile, a number of seconds or one of the immediate and indefinite
+// keywords.
+func tcpTimeoutSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		Description:  description + ", or immediate or indefinite",
+		ValidateFunc: validateTcpTimeout,
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			return tcpTimeout(old) == tcpTimeout(new)
+		},
+	}
+}
+
+// tcpTimeout returns the number of seconds the BigIP reports for a timeout of
+// the TCP profile.
+func tcpTimeout(timeout string) string {
+	switch timeout {
+	case "immediate":
+		return "0"
+	case "indefinite":
+		return tcpTimeoutIndefinite
+	}
+	return timeout
+}
+
+func validateTcpTimeout(value interface{}, field string) (ws []string, errors []error) {
+	v := tcpTimeout(value.(string))
+	if _, err := strconv.ParseUint(v, 10, 32); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a number of seconds, immediate or indefinite, got %q", field, value))
+	}
+	return
+}
+
+func dataToTcp(d *schema.ResourceData) *bigip.Tcp {
+	return &bigip.Tcp{
+		Name:              d.Get("name").(string),
+		Partition:         d.Get("partition").(string),
+		DefaultsFrom:      d.Get("defaults_from").(string),
+		IdleTimeout:       tcpTimeout(d.Get("idle_timeout").(string)),
+		CloseWaitTimeout:  tcpTimeout(d.Get("close_wait_timeout").(string)),
+		FinWait_2Timeout:  tcpTimeout(d.Get("finwait_2timeout").(string)),
+		FinWaitTimeout:    tcpTimeout(d.Get("finwait_timeout").(string)),
+		KeepAliveInterval: d.Get("keepalive_interval").(int),
+		DeferredAccept:    d.Get("deferred_accept").(string),
+		FastOpen:          d.Get("fast_open").(string),
+		Nagle:             d.Get("nagle").(string),
+		ProxyBufferHigh:   d.Get("proxy_buffer_high").(int),
+		ProxyBufferLow:    d.Get("proxy_buffer_low").(int),
+	}
+}
+
 func resourceBigipLtmProfileTcpCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
-	partition := d.Get("partition").(string)
-	defaultsFrom := d.Get("defaults_from").(string)
-	idleTimeout := d.Get("idle_timeout").(int)
-	closeWaitTimeout := d.Get("close_wait_timeout").(int)
-	finWait_2Timeout := d.Get("finwait_2timeout").(int)
-	finWaitTimeout := d.Get("finwait_timeout").(int)
-	keepAliveInterval := d.Get("keepalive_interval").(int)
-	deferredAccept := d.Get("deferred_accept").(string)
-	fastOpen := d.Get("fast_open").(string)
-	log.Println("[INFO] Creating TCP profile")
-
-	err := client.CreateTcp(
-		name,
-		partition,
-		defaultsFrom,
-		idleTimeout,
-		closeWaitTimeout,
-		finWait_2Timeout,
-		finWaitTimeout,
-		keepAliveInterval,
-		deferredAccept,
-		fastOpen,
-	)
+	log.Println("[INFO] Creating TCP profile " + name)
 
+	err := client.AddTcp(dataToTcp(d))
 	if err != nil {
 		log.Printf("[ERROR] Unable to Create tcp Profile  (%s) (%v)", name, err)
 		return err
@@ -117,34 +167,20 @@ func resourceBigipLtmProfileTcpCreate(d *schema.ResourceData, meta interface{})
 }
 
 func resourceBigipLtmProfileTcpUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
+	log.Println("[INFO] Updating TCP profile " + name)
 
-	//log.Println("[INFO] Updating Route " + description)
-
-	r := &bigip.Tcp{
-		Name:              name,
-		Partition:         d.Get("partition").(string),
-		DefaultsFrom:      d.Get("defaults_from").(string),
-		IdleTimeout:       d.Get("idle_timeout").(int),
-		CloseWaitTimeout:  d.Get("close_wait_timeout").(int),
-		FinWait_2Timeout:  d.Get("finwait_2timeout").(int),
-		FinWaitTimeout:    d.Get("finwait_timeout").(int),
-		KeepAliveInterval: d.Get("keepalive_interval").(int),
-		DeferredAccept:    d.Get("deferred_accept").(string),
-		FastOpen:          d.Get("fast_open").(string),
-	}
-
-	err := client.ModifyTcp(name, r)
+	err := client.ModifyTcp(name, dataToTcp(d))
 	if err != nil {
-		return fmt.Errorf("Error create profile tcp (%s): %s", name, err)
+		return fmt.Errorf("Error modifying profile tcp (%s): %s", name, err)
 	}
-	return resourceBigipLtmProfileOneconnectRead(d, meta)
+	return resourceBigipLtmProfileTcpRead(d, meta)
 }
 
 func resourceBigipLtmProfileTcpRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	obj, err := client.GetTcp(name)
 	if err != nil {
@@ -181,12 +217,15 @@ func resourceBigipLtmProfileTcpRead(d *schema.ResourceData, meta interface{}) er
 		return fmt.Errorf("[DEBUG] Error saving DeferredAccept to state for tcp profile  (%s): %s", d.Id(), err)
 	}
 	d.Set("fast_open", obj.FastOpen)
+	d.Set("nagle", obj.Nagle)
+	d.Set("proxy_buffer_high", obj.ProxyBufferHigh)
+	d.Set("proxy_buffer_low", obj.ProxyBufferLow)
 
 	return nil
 }
 
 func resourceBigipLtmProfileTcpDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Tcp Profile " + name)
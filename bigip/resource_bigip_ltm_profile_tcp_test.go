@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -15,7 +14,7 @@ var TEST_TCP_RESOURCE = `
 resource "bigip_ltm_profile_tcp" "test-tcp"
 
         {
-            name = "/Common/sanjose-tcp-wan-profile"
+            name = "` + TEST_TCP_NAME + `"
             defaults_from = "/Common/tcp-wan-optimized"
 						partition = "Common"
             idle_timeout = 300
@@ -40,7 +39,7 @@ func TestAccBigipLtmProfileTcp_create(t *testing.T) {
 				Config: TEST_TCP_RESOURCE,
 				Check: resource.ComposeTestCheckFunc(
 					testCheckTcpExists(TEST_TCP_NAME, true),
-					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "name", "/Common/sanjose-tcp-wan-profile"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "name", TEST_TCP_NAME),
 					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "defaults_from", "/Common/tcp-wan-optimized"),
 					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "idle_timeout", "300"),
 					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "partition", "Common"),
@@ -69,8 +68,11 @@ func TestAccBigipLtmProfileTcp_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckTcpExists(TEST_TCP_NAME, true),
 				),
-				ResourceName:      TEST_TCP_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_TCP_RESOURCE,
+				ResourceName:      "bigip_ltm_profile_tcp.test-tcp",
+				ImportState:       true,
 				ImportStateVerify: true,
 			},
 		},
@@ -79,12 +81,12 @@ func TestAccBigipLtmProfileTcp_import(t *testing.T) {
 
 func testCheckTcpExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.GetTcp(name)
 		if err != nil {
 			return err
 		}
-		if exists && p != nil {
+		if exists && p == nil {
 			return fmt.Errorf("tcp %s was not created.", name)
 		}
 		if !exists && p != nil {
@@ -95,7 +97,7 @@ func testCheckTcpExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckTcpsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_profile_tcp" {
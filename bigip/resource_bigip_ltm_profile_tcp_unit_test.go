@@ -0,0 +1,113 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmProfileTcpServer mocks a TCP profile inheriting from tcp, the
+// requests are recorded in bodies.
+func testBigipLtmProfileTcpServer(bodies *[]string) {
+	profile := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &profile)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/tcp", func(w http.ResponseWriter, r *http.Request) {
+		json.Unmarshal([]byte(`{"partition":"Common","defaultsFrom":"/Common/tcp","idleTimeout":300,"closeWaitTimeout":5,"finWait_2Timeout":300,"finWaitTimeout":5,"keepAliveInterval":1800,"deferredAccept":"disabled","fastOpen":"enabled","nagle":"auto","proxyBufferHigh":49152,"proxyBufferLow":32768}`), &profile)
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/tcp/~Common~test-tcp", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = map[string]interface{}{}
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileTcpConfig(url, idleTimeout string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_tcp" "test-tcp" {
+			name = "/Common/test-tcp"
+			idle_timeout = "%s"
+			close_wait_timeout = "immediate"
+			nagle = "disabled"
+			proxy_buffer_high = 131072
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, idleTimeout, url)
+}
+
+func TestAccBigipLtmProfileTcpTimeouts(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileTcpServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmProfileTcpConfig(server.URL, "indefinite"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "idle_timeout", "4294967295"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "close_wait_timeout", "0"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "finwait_timeout", "5"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "defaults_from", "/Common/tcp"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "nagle", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "proxy_buffer_high", "131072"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "proxy_buffer_low", "32768"),
+				),
+			},
+			{
+				Config: testBigipLtmProfileTcpConfig(server.URL, "600"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_profile_tcp.test-tcp", "idle_timeout", "600"),
+			},
+			{
+				Config:            testBigipLtmProfileTcpConfig(server.URL, "600"),
+				ResourceName:      "bigip_ltm_profile_tcp.test-tcp",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+	// Keywords are sent as numbers, an immediate timeout of 0 included
+	assert.Contains(t, bodies[0], `"idleTimeout":4294967295`)
+	assert.Contains(t, bodies[0], `"closeWaitTimeout":0`)
+	assert.NotContains(t, bodies[0], `finWaitTimeout`)
+	assert.Contains(t, bodies[1], `"idleTimeout":600`)
+}
+
+func TestAccBigipLtmProfileTcpInvalidTimeout(t *testing.T) {
+	for _, timeout := range []string{"forever", "-1", "4294967296"} {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipLtmProfileTcpConfig("http://localhost", timeout),
+					ExpectError: regexp.MustCompile("must be a number of seconds, immediate or indefinite"),
+				},
+			},
+		})
+	}
+}
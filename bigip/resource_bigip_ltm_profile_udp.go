@@ -0,0 +1,139 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func resourceBigipLtmProfileUdp() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipLtmProfileUdpCreate,
+		Update: resourceBigipLtmProfileUdpUpdate,
+		Read:   resourceBigipLtmProfileUdpRead,
+		Delete: resourceBigipLtmProfileUdpDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the UDP Profile",
+				ValidateFunc: validateF5Name,
+			},
+			"partition": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				Description: "name of partition",
+			},
+			"defaults_from": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Use the parent udp profile",
+				ValidateFunc: validateF5Name,
+			},
+
+			"idle_timeout": tcpTimeoutSchema("Seconds a connection is idle before it is eligible for deletion"),
+
+			"datagram_load_balancing": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Load balance each datagram on its own instead of all the datagrams of a connection to the same pool member",
+				ValidateFunc: validateEnabledDisabled,
+			},
+			"allow_no_payload": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Pass datagrams without payload on instead of dropping them",
+				ValidateFunc: validateEnabledDisabled,
+			},
+		},
+	}
+}
+
+// dataToUdp returns the UDP profile as configured. The BigIP takes the
+// immediate and indefinite idle timeouts as they are and reports them back
+// the same, unlike the TCP profile.
+func dataToUdp(d *schema.ResourceData) *bigip.UdpProfile {
+	return &bigip.UdpProfile{
+		Name:                  d.Get("name").(string),
+		Partition:             d.Get("partition").(string),
+		DefaultsFrom:          d.Get("defaults_from").(string),
+		IdleTimeout:           d.Get("idle_timeout").(string),
+		DatagramLoadBalancing: d.Get("datagram_load_balancing").(string),
+		AllowNoPayload:        d.Get("allow_no_payload").(string),
+	}
+}
+
+func resourceBigipLtmProfileUdpCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Get("name").(string)
+	log.Println("[INFO] Creating UDP profile " + name)
+
+	err := client.AddUdpProfile(dataToUdp(d))
+	if err != nil {
+		return fmt.Errorf("Error creating profile udp (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId(name)
+	return resourceBigipLtmProfileUdpRead(d, meta)
+}
+
+func resourceBigipLtmProfileUdpUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Updating UDP profile " + name)
+
+	err := client.ModifyUdpProfile(name, dataToUdp(d))
+	if err != nil {
+		return fmt.Errorf("Error modifying profile udp (%s): %v", name, apiErrorDetails(err))
+	}
+	return resourceBigipLtmProfileUdpRead(d, meta)
+}
+
+func resourceBigipLtmProfileUdpRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+	name := d.Id()
+	obj, err := client.GetUdpProfile(name)
+	if err != nil {
+		log.Printf("[ERROR] Unable to retrive udp Profile  (%s) (%v)", name, err)
+		return err
+	}
+	if obj == nil {
+		log.Printf("[WARN] udp  Profile (%s) not found, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	d.Set("name", name)
+	d.Set("partition", obj.Partition)
+	d.Set("defaults_from", obj.DefaultsFrom)
+	d.Set("idle_timeout", obj.IdleTimeout)
+	d.Set("datagram_load_balancing", obj.DatagramLoadBalancing)
+	d.Set("allow_no_payload", obj.AllowNoPayload)
+
+	return nil
+}
+
+func resourceBigipLtmProfileUdpDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting Udp Profile " + name)
+
+	err := client.DeleteUdpProfile(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting profile udp (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
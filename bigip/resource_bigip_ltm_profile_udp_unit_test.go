@@ -0,0 +1,106 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmProfileUdpServer mocks a UDP profile inheriting from udp, the
+// requests are recorded in bodies.
+func testBigipLtmProfileUdpServer(bodies *[]string) {
+	profile := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		*bodies = append(*bodies, string(body))
+		json.Unmarshal(body, &profile)
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/udp", func(w http.ResponseWriter, r *http.Request) {
+		json.Unmarshal([]byte(`{"partition":"Common","defaultsFrom":"/Common/udp","idleTimeout":"60","datagramLoadBalancing":"disabled","allowNoPayload":"disabled"}`), &profile)
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/profile/udp/~Common~dns-udp", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			profile = map[string]interface{}{}
+		}
+		json.NewEncoder(w).Encode(profile)
+	})
+}
+
+func testBigipLtmProfileUdpConfig(url, idleTimeout string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_profile_udp" "dns-udp" {
+			name = "/Common/dns-udp"
+			idle_timeout = "%s"
+			datagram_load_balancing = "enabled"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, idleTimeout, url)
+}
+
+func TestAccBigipLtmProfileUdp(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipLtmProfileUdpServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmProfileUdpConfig(server.URL, "immediate"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_profile_udp.dns-udp", "id", "/Common/dns-udp"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_udp.dns-udp", "idle_timeout", "immediate"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_udp.dns-udp", "defaults_from", "/Common/udp"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_udp.dns-udp", "datagram_load_balancing", "enabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_profile_udp.dns-udp", "allow_no_payload", "disabled"),
+				),
+			},
+			{
+				Config: testBigipLtmProfileUdpConfig(server.URL, "indefinite"),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_profile_udp.dns-udp", "idle_timeout", "indefinite"),
+			},
+			{
+				Config:            testBigipLtmProfileUdpConfig(server.URL, "indefinite"),
+				ResourceName:      "bigip_ltm_profile_udp.dns-udp",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+	assert.Contains(t, bodies[0], `"idleTimeout":"immediate"`)
+	assert.Contains(t, bodies[0], `"datagramLoadBalancing":"enabled"`)
+	assert.NotContains(t, bodies[0], `allowNoPayload`)
+	assert.Contains(t, bodies[1], `"idleTimeout":"indefinite"`)
+}
+
+func TestAccBigipLtmProfileUdpInvalidTimeout(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmProfileUdpConfig("http://localhost", "forever"),
+				ExpectError: regexp.MustCompile("must be a number of seconds, immediate or indefinite"),
+			},
+		},
+	})
+}
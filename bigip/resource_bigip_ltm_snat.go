@@ -3,9 +3,11 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"net"
+	"strings"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmSnat() *schema.Resource {
@@ -17,96 +19,170 @@ func resourceBigipLtmSnat() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
+		SchemaVersion: 1,
+		MigrateState:  resourceBigipLtmSnatMigrateState,
 
 		Schema: map[string]*schema.Schema{
 
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Snat list Name",
-			},
-			"partition": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Which partition on BIG-IP",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the SNAT, either a full path such as /Common/my-snat or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
 			},
+			"partition": partitionSchema("SNAT"),
 
 			"full_path": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Fullpath ",
+				Computed:    true,
+				Description: "Full path of the SNAT",
+				Deprecated:  "full_path is computed from name and partition",
 			},
 
 			"autolasthop": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "Specifies whether to automatically map last hop for pools or not. The default is to use next level's defaul",
 			},
 			"mirror": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Enables or disables mirroring of SNAT connections.",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				Description:  "Enables or disables mirroring of SNAT connections.",
+				ValidateFunc: validateEnabledDisabled,
 			},
 			"sourceport": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "Specifies whether the system preserves the source port of the connection. ",
 			},
 			"translation": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Specifies the name of a translated IP address.",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Full path of the SNAT translation address the origins are translated to",
+				ConflictsWith: []string{"snatpool", "automap"},
 			},
 			"snatpool": {
-				Type:        schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Full path of the SNAT pool whose addresses the origins are translated to",
+				ConflictsWith: []string{"translation", "automap"},
+			},
+			"automap": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Description:   "Translate the origins to the self IP addresses of the egress VLAN",
+				ConflictsWith: []string{"translation", "snatpool"},
+			},
+			"vlans": {
+				Type:        schema.TypeSet,
 				Optional:    true,
-				Description: "Specifies the name of a SNAT pool. You can only use this option when automap and translation are not used",
+				Description: "VLANs the SNAT is enabled on, or disabled on with vlansdisabled. The SNAT applies to all VLANs when empty.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Set:         schema.HashString,
 			},
 			"vlansdisabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
-				Description: "Disables the SNAT on all VLANs.",
+				Description: "Disables the SNAT on the VLANs of vlans instead of enabling it on them",
 			},
 
 			"origins": {
-				Type:     schema.TypeList,
-				Required: true,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"name": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "Name of origin",
-						},
-						"app_service": {
-							Type:        schema.TypeString,
-							Optional:    true,
-							Description: "app service",
-						},
-					},
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Description: "Addresses or networks in CIDR notation whose connections are translated, e.g. 10.1.1.1 or 10.1.0.0/16",
+				Elem: &schema.Schema{
+					Type:         schema.TypeString,
+					ValidateFunc: validateSnatOrigin,
 				},
+				Set: schema.HashString,
 			},
 		},
 	}
 }
 
+// normalizeSnatOrigin returns an origin as a network in CIDR notation, a
+// single address as a /32 or /128 network.
+func normalizeSnatOrigin(origin string) (string, error) {
+	match := selfIPAddressRegex.FindStringSubmatch(origin)
+	if match == nil {
+		address, routeDomain, err := parseNodeAddress(origin)
+		if err != nil || net.ParseIP(address) == nil {
+			return "", fmt.Errorf("%q is not an address or a network in CIDR notation", origin)
+		}
+		prefix := "/32"
+		if strings.Contains(address, ":") {
+			prefix = "/128"
+		}
+		if routeDomain != 0 {
+			return fmt.Sprintf("%s%%%d%s", address, routeDomain, prefix), nil
+		}
+		return address + prefix, nil
+	}
+	if _, _, err := net.ParseCIDR(match[1] + match[3]); err != nil {
+		return "", fmt.Errorf("%q is not an address or a network in CIDR notation", origin)
+	}
+	return origin, nil
+}
+
+func validateSnatOrigin(value interface{}, field string) (ws []string, errors []error) {
+	if _, err := normalizeSnatOrigin(value.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an address or a network in CIDR notation, e.g. 10.1.1.1 or 10.1.0.0/16: %v", field, err))
+	}
+	return
+}
+
+// flattenSnatOrigins returns the origins reported by the BigIP, in the form
+// they are configured when they are the same, so that an address is not
+// replaced by its /32 network.
+func flattenSnatOrigins(reported []bigip.Originsrecord, configured *schema.Set) *schema.Set {
+	forms := map[string]string{}
+	for _, c := range configured.List() {
+		if normalized, err := normalizeSnatOrigin(c.(string)); err == nil {
+			forms[normalized] = c.(string)
+		}
+	}
+	origins := schema.NewSet(schema.HashString, nil)
+	for _, r := range reported {
+		origin := r.Name
+		if normalized, err := normalizeSnatOrigin(r.Name); err == nil {
+			if form, ok := forms[normalized]; ok {
+				origin = form
+			}
+		}
+		origins.Add(origin)
+	}
+	return origins
+}
+
 func resourceBigipLtmSnatCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
-	name := d.Get("name").(string)
-	log.Println("[INFO] Creating Snat" + name)
+	client := meta.(*bigipClient)
 
-	p := dataToSnat(name, d)
-	d.SetId(name)
-	err := client.CreateSnat(&p)
+	name, err := resourceFullPath(d, client, "SNAT")
+	if err != nil {
+		return err
+	}
+	log.Println("[INFO] Creating Snat " + name)
+
+	p, err := dataToSnat(name, d)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Create Snat  (%s) (%v) ", name, err)
 		return err
 	}
+	err = client.CreateSnat(p)
+	if err != nil {
+		return fmt.Errorf("Error creating SNAT (%s): %v", name, apiErrorDetails(err))
+	}
+	d.SetId(name)
 	return resourceBigipLtmSnatRead(d, meta)
 }
 
 func resourceBigipLtmSnatRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 
 	log.Println("[INFO] Fetching Ltm Snat " + name)
@@ -120,99 +196,82 @@ func resourceBigipLtmSnatRead(d *schema.ResourceData, meta interface{}) error {
 		d.SetId("")
 		return nil
 	}
-	d.Set("partition", p.Partition)
-	if err := d.Set("full_path", p.FullPath); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving FullPath to state for Snat  (%s): %s", d.Id(), err)
-	}
-	if err := d.Set("autolasthop", p.AutoLasthop); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving AutoLasthop to state for Snat  (%s): %s", d.Id(), err)
-	}
-	d.Set("mirror", p.Mirror)
-	if err := d.Set("sourceport", p.SourcePort); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving SourcePort to state for Snat  (%s): %s", d.Id(), err)
-	}
-	if err := d.Set("translation", p.Translation); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving Translation to state for Snat  (%s): %s", d.Id(), err)
-	}
-
-	if err := d.Set("snatpool", p.Snatpool); err != nil {
-		return fmt.Errorf("[DEBUG] Error saving Snatpool to state for Snat  (%s): %s", d.Id(), err)
-	}
-	d.Set("vlansdisabled", p.VlansDisabled)
 
-	if err != nil {
+	if err := setNameAndPartition(d, client, name); err != nil {
 		return err
 	}
-
 	return SnatToData(p, d)
 }
 
 func resourceBigipLtmSnatUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	log.Println("[INFO] Updating LtmSnat " + name)
-	p := dataToSnat(name, d)
-	err := client.UpdateSnat(name, &p)
+	p, err := dataToSnat(name, d)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Retrieve Snat  (%s) (%v) ", name, err)
 		return err
 	}
+	err = client.UpdateSnat(name, p)
+	if err != nil {
+		return fmt.Errorf("Error modifying SNAT (%s): %v", name, apiErrorDetails(err))
+	}
 	return resourceBigipLtmSnatRead(d, meta)
 }
 
 func resourceBigipLtmSnatDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	err := client.DeleteSnat(name)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Delete Snat  (%s) (%v) ", name, err)
-		return err
+		return fmt.Errorf("Error deleting SNAT (%s): %v", name, apiErrorDetails(err))
 	}
 	d.SetId("")
 	return nil
 }
 
-func dataToSnat(name string, d *schema.ResourceData) bigip.Snat {
-	var p bigip.Snat
-
-	p.Name = name
-	p.Partition = d.Get("partition").(string)
-	p.FullPath = d.Get("full_path").(string)
-	p.AutoLasthop = d.Get("autolasthop").(string)
-	p.Mirror = d.Get("mirror").(string)
-	p.SourcePort = d.Get("sourceport").(string)
-	p.Translation = d.Get("translation").(string)
-	p.Snatpool = d.Get("snatpool").(string)
-	p.VlansDisabled = d.Get("vlansdisabled").(bool)
-
-	originsCount := d.Get("origins.#").(int)
-	p.Origins = make([]bigip.Originsrecord, 0, originsCount)
-	for i := 0; i < originsCount; i++ {
-		var r bigip.Originsrecord
-		log.Println("I am in dattosnat policy ", p, originsCount, i)
-		prefix := fmt.Sprintf("origins.%d", i)
-		r.Name = d.Get(prefix + ".name").(string)
-		p.Origins = append(p.Origins, r)
+// dataToSnat returns the SNAT as configured, exactly one of translation,
+// snatpool and automap has to be set.
+func dataToSnat(name string, d *schema.ResourceData) (*bigip.Snat, error) {
+	p := &bigip.Snat{
+		Name:        name,
+		AutoLasthop: d.Get("autolasthop").(string),
+		Mirror:      d.Get("mirror").(string),
+		SourcePort:  d.Get("sourceport").(string),
+		Translation: d.Get("translation").(string),
+		Snatpool:    d.Get("snatpool").(string),
+		Automap:     d.Get("automap").(bool),
+		Vlans:       setToStringSlice(d.Get("vlans").(*schema.Set)),
+	}
+	if p.Translation == "" && p.Snatpool == "" && !p.Automap {
+		return nil, fmt.Errorf("SNAT %s needs one of translation, snatpool or automap = true", name)
+	}
+	// No VLANs enabled is the same as disabled on none, enabled on all
+	if len(p.Vlans) > 0 && !d.Get("vlansdisabled").(bool) {
+		p.VlansEnabled = true
+	} else {
+		p.VlansDisabled = true
 	}
 
-	log.Println("I am in DatatoSnat value of p                                                   ", p)
-
-	return p
+	for _, origin := range d.Get("origins").(*schema.Set).List() {
+		p.Origins = append(p.Origins, bigip.Originsrecord{Name: origin.(string)})
+	}
+	return p, nil
 }
 
 func SnatToData(p *bigip.Snat, d *schema.ResourceData) error {
-	d.Set("partition", p.Partition)
-	d.Set("full_path", p.FullPath)
 	d.Set("autolasthop", p.AutoLasthop)
 	d.Set("mirror", p.Mirror)
 	d.Set("sourceport", p.SourcePort)
 	d.Set("translation", p.Translation)
 	d.Set("snatpool", p.Snatpool)
-	d.Set("vlansdisabled", p.VlansDisabled)
+	d.Set("automap", p.Automap)
+	if err := d.Set("vlans", p.Vlans); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Vlans to state for Snat  (%s): %s", d.Id(), err)
+	}
+	d.Set("vlansdisabled", p.VlansDisabled && len(p.Vlans) > 0)
 
-	for i, r := range p.Origins {
-		origins := fmt.Sprintf("origins.%d", i)
-		d.Set(fmt.Sprintf("%s.name", origins), r.Name)
+	if err := d.Set("origins", flattenSnatOrigins(p.Origins, d.Get("origins").(*schema.Set))); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving Origins to state for Snat  (%s): %s", d.Id(), err)
 	}
 	return nil
 }
@@ -0,0 +1,52 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+// resourceBigipLtmSnatMigrateState upgrades the state of SNATs written by
+// earlier versions of the provider.
+func resourceBigipLtmSnatMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found bigip_ltm_snat state v0; migrating to v1")
+		return migrateBigipLtmSnatStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
+	}
+}
+
+// migrateBigipLtmSnatStateV0toV1 turns the list of origin blocks, with a name
+// and an unused app_service each, into the set of origin names.
+func migrateBigipLtmSnatStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		log.Println("[DEBUG] Empty InstanceState; nothing to migrate.")
+		return is, nil
+	}
+
+	var names []string
+	for k, v := range is.Attributes {
+		if !strings.HasPrefix(k, "origins.") {
+			continue
+		}
+		if strings.HasSuffix(k, ".name") && v != "" {
+			names = append(names, v)
+		}
+		delete(is.Attributes, k)
+	}
+
+	origins := schema.NewSet(schema.HashString, nil)
+	for _, name := range names {
+		origins.Add(name)
+	}
+	for _, name := range origins.List() {
+		is.Attributes[fmt.Sprintf("origins.%d", schema.HashString(name))] = name.(string)
+	}
+	is.Attributes["origins.#"] = fmt.Sprintf("%d", origins.Len())
+	return is, nil
+}
@@ -0,0 +1,37 @@
+package bigip
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBigipLtmSnatMigrateState(t *testing.T) {
+	is := &terraform.InstanceState{
+		ID: "/Common/outbound",
+		Attributes: map[string]string{
+			"name":                  "/Common/outbound",
+			"translation":           "/Common/10.2.0.1",
+			"origins.#":             "2",
+			"origins.0.name":        "10.1.1.1",
+			"origins.0.app_service": "",
+			"origins.1.name":        "10.1.0.0/16",
+			"origins.1.app_service": "",
+		},
+	}
+	is, err := resourceBigipLtmSnatMigrateState(0, is, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"name":        "/Common/outbound",
+		"translation": "/Common/10.2.0.1",
+		"origins.#":   "2",
+		fmt.Sprintf("origins.%d", schema.HashString("10.1.1.1")):    "10.1.1.1",
+		fmt.Sprintf("origins.%d", schema.HashString("10.1.0.0/16")): "10.1.0.0/16",
+	}, is.Attributes)
+
+	_, err = resourceBigipLtmSnatMigrateState(1, is, nil)
+	assert.Error(t, err)
+}
@@ -2,8 +2,8 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
 	"testing"
 )
@@ -14,8 +14,7 @@ var TEST_SNAT_RESOURCE = `
 resource "bigip_ltm_snat" "test-snat" {
  name = "` + TEST_SNAT_NAME + `"
  translation = "/Common/136.1.1.1"
- origins = { name = "2.2.2.2" }
- origins = { name = "3.3.3.3" }
+ origins = ["2.2.2.2", "3.3.3.3"]
  vlansdisabled = true
  autolasthop = "default"
  mirror = "disabled"
@@ -37,8 +36,9 @@ func TestAccBigipLtmsnat_create(t *testing.T) {
 					testCheckSnatExists(TEST_SNAT_NAME, true),
 					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "name", TEST_SNAT_NAME),
 					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "translation", "/Common/136.1.1.1"),
-					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "origins.0.name", "2.2.2.2"),
-					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "origins.1.name", "3.3.3.3"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "origins.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", fmt.Sprintf("origins.%d", schema.HashString("2.2.2.2")), "2.2.2.2"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", fmt.Sprintf("origins.%d", schema.HashString("3.3.3.3")), "3.3.3.3"),
 					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "vlansdisabled", "true"),
 					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "autolasthop", "default"),
 					resource.TestCheckResourceAttr("bigip_ltm_snat.test-snat", "mirror", "disabled"),
@@ -73,7 +73,7 @@ func TestAccBigipLtmsnat_import(t *testing.T) {
 
 func testCheckSnatExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.GetSnat(name)
 		if err != nil {
 			return err
@@ -89,7 +89,7 @@ func testCheckSnatExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testChecksnatsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_snat" {
@@ -0,0 +1,198 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipLtmSnatServer mocks a BigIP reporting single address origins as
+// /32 networks, the bodies sent are recorded in bodies.
+func testBigipLtmSnatServer(bodies *[]map[string]interface{}) {
+	snat := map[string]interface{}{}
+	save := func(r *http.Request) {
+		body := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&body)
+		*bodies = append(*bodies, body)
+		snat = map[string]interface{}{}
+		for k, v := range body {
+			snat[k] = v
+		}
+		var origins []interface{}
+		for _, o := range body["origins"].([]interface{}) {
+			name := o.(map[string]interface{})["name"].(string)
+			if !strings.Contains(name, "/") {
+				name += "/32"
+			}
+			origins = append(origins, map[string]interface{}{"name": name})
+		}
+		snat["origins"] = origins
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/snat", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/snat/~Tenant1~outbound", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		json.NewEncoder(w).Encode(snat)
+	})
+}
+
+func testBigipLtmSnatConfig(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_snat" "outbound" {
+			name = "outbound"
+			partition = "Tenant1"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+func TestAccBigipLtmSnatCreate(t *testing.T) {
+	setup()
+	var bodies []map[string]interface{}
+	testBigipLtmSnatServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmSnatConfig(server.URL, `
+					origins = ["10.1.1.1", "10.2.0.0/16"]
+					snatpool = "/Common/outbound-pool"
+					vlans = ["/Common/internal"]
+					mirror = "enabled"
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "id", "/Tenant1/outbound"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "full_path", "/Tenant1/outbound"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "origins.#", "2"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", fmt.Sprintf("origins.%d", schema.HashString("10.1.1.1")), "10.1.1.1"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", fmt.Sprintf("origins.%d", schema.HashString("10.2.0.0/16")), "10.2.0.0/16"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "snatpool", "/Common/outbound-pool"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "automap", "false"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "vlans.#", "1"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "vlansdisabled", "false"),
+				),
+			},
+			{
+				// Reordered origins are the same set
+				Config: testBigipLtmSnatConfig(server.URL, `
+					origins = ["10.2.0.0/16", "10.1.1.1"]
+					snatpool = "/Common/outbound-pool"
+					vlans = ["/Common/internal"]
+					mirror = "enabled"
+				`),
+				PlanOnly: true,
+			},
+			{
+				Config: testBigipLtmSnatConfig(server.URL, `
+					origins = ["10.2.0.0/16", "10.1.1.1"]
+					automap = true
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "automap", "true"),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "snatpool", ""),
+					resource.TestCheckResourceAttr("bigip_ltm_snat.outbound", "vlans.#", "0"),
+				),
+			},
+			{
+				Config: testBigipLtmSnatConfig(server.URL, `
+					origins = ["10.2.0.0/16", "10.1.1.1"]
+					automap = true
+				`),
+				ResourceName:            "bigip_ltm_snat.outbound",
+				ImportState:             true,
+				ImportStateId:           "/Tenant1/outbound",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name", "origins"},
+			},
+		},
+	})
+	if !assert.Len(t, bodies, 2) {
+		return
+	}
+	assert.Equal(t, true, bodies[0]["vlansEnabled"])
+	assert.Equal(t, []interface{}{"/Common/internal"}, bodies[0]["vlans"])
+	assert.Equal(t, "/Common/outbound-pool", bodies[0]["snatpool"])
+	assert.Equal(t, true, bodies[1]["automap"])
+	assert.Equal(t, true, bodies[1]["vlansDisabled"])
+	assert.Nil(t, bodies[1]["snatpool"])
+}
+
+func TestAccBigipLtmSnatNoTranslation(t *testing.T) {
+	setup()
+	var bodies []map[string]interface{}
+	testBigipLtmSnatServer(&bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipLtmSnatConfig(server.URL, `origins = ["10.1.1.1"]`),
+				ExpectError: regexp.MustCompile(`needs one of translation, snatpool or automap = true`),
+			},
+		},
+	})
+	assert.Empty(t, bodies)
+}
+
+func TestAccBigipLtmSnatInvalidOrigin(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmSnatConfig("https://127.0.0.1", `
+					origins = ["10.1.1.300"]
+					automap = true
+				`),
+				ExpectError: regexp.MustCompile(`must be an address or a network in CIDR notation`),
+			},
+		},
+	})
+}
+
+func TestNormalizeSnatOrigin(t *testing.T) {
+	for origin, expected := range map[string]string{
+		"10.1.1.1":      "10.1.1.1/32",
+		"10.1.1.1%2":    "10.1.1.1%2/32",
+		"10.1.0.0/16":   "10.1.0.0/16",
+		"10.1.0.0%2/16": "10.1.0.0%2/16",
+		"2001:db8::1":   "2001:db8::1/128",
+		"f5.com":        "",
+		"10.1.1.300":    "",
+		"10.1.0.0/33":   "",
+	} {
+		normalized, err := normalizeSnatOrigin(origin)
+		if expected == "" {
+			assert.NotNil(t, err, origin)
+			continue
+		}
+		assert.Nil(t, err, origin)
+		assert.Equal(t, expected, normalized, origin)
+	}
+}
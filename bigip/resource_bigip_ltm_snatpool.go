@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmSnatpool() *schema.Resource {
@@ -23,8 +23,16 @@ func resourceBigipLtmSnatpool() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				ForceNew:     true,
-				Description:  "SNAT Pool list Name, format /partition/name. e.g. /Common/snat_pool",
-				ValidateFunc: validateF5Name,
+				Description:  "Name of the SNAT pool, either a full path such as /Common/snat_pool or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+
+			"partition": partitionSchema("SNAT pool"),
+
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the SNAT pool, for referencing it from virtual servers",
 			},
 
 			"members": {
@@ -40,14 +48,17 @@ func resourceBigipLtmSnatpool() *schema.Resource {
 }
 
 func resourceBigipLtmSnatpoolCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
-	name := d.Get("name").(string)
+	name, err := resourceFullPath(d, client, "SNAT pool")
+	if err != nil {
+		return err
+	}
 	members := setToStringSlice(d.Get("members").(*schema.Set))
 
 	log.Println("[INFO] Creating SNAT Pool " + name)
 
-	err := client.CreateSnatPool(name, members)
+	err = client.CreateSnatPool(name, members)
 	if err != nil {
 		log.Printf("[ERROR] Unable to Create Snat Pool  (%s) (%v) ", name, err)
 		return err
@@ -59,14 +70,13 @@ func resourceBigipLtmSnatpoolCreate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceBigipLtmSnatpoolUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	log.Println("[INFO] Updating SNAT Pool " + name)
 
 	r := &bigip.SnatPool{
-		Name:    d.Get("name").(string),
 		Members: setToStringSlice(d.Get("members").(*schema.Set)),
 	}
 
@@ -80,7 +90,7 @@ func resourceBigipLtmSnatpoolUpdate(d *schema.ResourceData, meta interface{}) er
 }
 
 func resourceBigipLtmSnatpoolRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -96,8 +106,13 @@ func resourceBigipLtmSnatpoolRead(d *schema.ResourceData, meta interface{}) erro
 		d.SetId("")
 		return nil
 	}
-	d.Set("name", name)
-	if err := d.Set("members", snatpool.Members); err != nil {
+
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+
+	members := snatpoolMembers(snatpool.Members, d.Get("members").(*schema.Set))
+	if err := d.Set("members", makeStringSet(&members)); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Members to state for SNAT Pool (%s): %s", d.Id(), err)
 	}
 
@@ -105,8 +120,22 @@ func resourceBigipLtmSnatpoolRead(d *schema.ResourceData, meta interface{}) erro
 
 }
 
+// snatpoolMembers returns the members of a SNAT pool as configured. The BigIP
+// reports members as full paths, members configured without a partition
+// are kept that way.
+func snatpoolMembers(members []string, configured *schema.Set) []string {
+	result := make([]string, len(members))
+	for i, member := range members {
+		result[i] = member
+		if _, address := parseF5Identifier(member); configured.Contains(address) {
+			result[i] = address
+		}
+	}
+	return result
+}
+
 func resourceBigipLtmSnatpoolDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
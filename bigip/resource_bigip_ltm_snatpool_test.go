@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -58,8 +57,12 @@ func TestAccBigipLtmsnatpool_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testChecksnatpoolExists(TEST_SNATPOOL_NAME, true),
 				),
-				ResourceName:      TEST_SNATPOOL_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_SNATPOOL_RESOURCE,
+				ResourceName:      "bigip_ltm_snatpool.test-snatpool",
+				ImportState:       true,
+				ImportStateId:     TEST_SNATPOOL_NAME,
 				ImportStateVerify: true,
 			},
 		},
@@ -68,7 +71,7 @@ func TestAccBigipLtmsnatpool_import(t *testing.T) {
 
 func testChecksnatpoolExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.Snatpools(name)
 		if err != nil {
 			return err
@@ -84,7 +87,7 @@ func testChecksnatpoolExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testChecksnatpoolsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_snatpool" {
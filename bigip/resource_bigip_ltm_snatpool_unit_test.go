@@ -0,0 +1,97 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func testBigipLtmSnatpoolConfig(url, members string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_snatpool" "test-snatpool" {
+			name = "test-snatpool"
+			partition = "Tenant1"
+			members = [%s]
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, members, url)
+}
+
+func TestAccBigipLtmSnatpoolPartition(t *testing.T) {
+	setup()
+	var calls []string
+	var members []string
+	saveMembers := func(r *http.Request) {
+		var snatpool bigip.SnatPool
+		json.NewDecoder(r.Body).Decode(&snatpool)
+		calls = append(calls, r.Method+" "+snatpool.Name)
+		// The BigIP reports members as full paths
+		members = nil
+		for _, m := range snatpool.Members {
+			if _, address := parseF5Identifier(m); address == m {
+				m = "/Tenant1/" + m
+			}
+			members = append(members, m)
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/snatpool", func(w http.ResponseWriter, r *http.Request) {
+		saveMembers(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/snatpool/~Tenant1~test-snatpool", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			saveMembers(r)
+		case "DELETE":
+			calls = append(calls, "DELETE")
+		}
+		json.NewEncoder(w).Encode(bigip.SnatPool{Name: "test-snatpool", Partition: "Tenant1", FullPath: "/Tenant1/test-snatpool", Members: members})
+	})
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmSnatpoolConfig(server.URL, `"10.0.0.1", "/Common/10.0.0.2"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool", "id", "/Tenant1/test-snatpool"),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool", "full_path", "/Tenant1/test-snatpool"),
+					resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool", "members.#", "2"),
+				),
+			},
+			{
+				Config: testBigipLtmSnatpoolConfig(server.URL, `"/Common/10.0.0.2", "10.0.0.1", "10.0.0.3"`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_snatpool.test-snatpool", "members.#", "3"),
+			},
+			{
+				Config:            testBigipLtmSnatpoolConfig(server.URL, `"/Common/10.0.0.2", "10.0.0.1", "10.0.0.3"`),
+				ResourceName:      "bigip_ltm_snatpool.test-snatpool",
+				ImportState:       true,
+				ImportStateId:     "/Tenant1/test-snatpool",
+				ImportStateVerify: true,
+				// Imported members are full paths
+				ImportStateVerifyIgnore: []string{"name", "members"},
+			},
+		},
+	})
+	assert.Equal(t, []string{"POST /Tenant1/test-snatpool", "PUT ", "DELETE"}, calls)
+}
+
+func TestSnatpoolMembers(t *testing.T) {
+	configured := makeStringSet(&[]string{"10.0.0.1", "/Common/10.0.0.2"})
+	assert.Equal(t, []string{"10.0.0.1", "/Common/10.0.0.2", "/Common/10.0.0.3"},
+		snatpoolMembers([]string{"/Common/10.0.0.1", "/Common/10.0.0.2", "/Common/10.0.0.3"}, configured))
+}
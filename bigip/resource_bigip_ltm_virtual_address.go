@@ -3,9 +3,10 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"strings"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmVirtualAddress() *schema.Resource {
@@ -14,7 +15,6 @@ func resourceBigipLtmVirtualAddress() *schema.Resource {
 		Read:   resourceBigipLtmVirtualAddressRead,
 		Update: resourceBigipLtmVirtualAddressUpdate,
 		Delete: resourceBigipLtmVirtualAddressDelete,
-		Exists: resourceBigipLtmVirtualAddressExists,
 		Importer: &schema.ResourceImporter{
 			State: schema.ImportStatePassthrough,
 		},
@@ -23,10 +23,26 @@ func resourceBigipLtmVirtualAddress() *schema.Resource {
 			"name": {
 				Type:         schema.TypeString,
 				Required:     true,
+				ForceNew:     true,
 				Description:  "Name of the virtual address",
 				ValidateFunc: validateF5Name,
 			},
 
+			"address": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ForceNew:     true,
+				Description:  "IP address of the virtual address with an optional %route_domain suffix, defaults to the name",
+				ValidateFunc: validateVirtualAddress,
+			},
+
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "User defined description of the virtual address",
+			},
+
 			"arp": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -62,64 +78,89 @@ func resourceBigipLtmVirtualAddress() *schema.Resource {
 				Description: "Enable/Disable ICMP response to the virtual address",
 			},
 
+			"route_advertisement": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Advertise the route of the address to dynamic routing protocols: enabled, disabled, selective, always, any or all",
+				ValidateFunc:  validateStringValue([]string{"enabled", "disabled", "selective", "always", "any", "all"}),
+				ConflictsWith: []string{"advertize_route"},
+			},
+
 			"advertize_route": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Enabled dynamic routing of the address",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Computed:      true,
+				Description:   "Enabled dynamic routing of the address",
+				Deprecated:    "Use route_advertisement instead",
+				ConflictsWith: []string{"route_advertisement"},
 			},
 
 			"traffic_group": {
 				Type:         schema.TypeString,
 				Optional:     true,
 				Default:      "/Common/traffic-group-1",
-				Description:  "Specify the partition and traffic group",
+				Description:  "Full path of an existing traffic group the virtual address fails over with",
 				ValidateFunc: validateF5Name,
 			},
 		},
 	}
 }
 
+func validateVirtualAddress(value interface{}, field string) (ws []string, errors []error) {
+	address := value.(string)
+	if address != "any" && address != "any6" && !nodeAddressRegex.MatchString(address) {
+		errors = append(errors, fmt.Errorf("%q must be an IPv4 or IPv6 address, any or any6, got %q", field, address))
+	}
+	return
+}
+
 func resourceBigipLtmVirtualAddressCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	log.Println("[INFO] Creating virtual address " + name)
 
-	client.CreateVirtualAddress(name, hydrateVirtualAddress(d))
+	if err := checkTrafficGroup(client, d.Get("traffic_group").(string)); err != nil {
+		return err
+	}
+
+	err := client.CreateVirtualAddress(name, hydrateVirtualAddress(d))
+	if err != nil {
+		return fmt.Errorf("Error creating virtual address %s: %v", name, apiErrorDetails(err))
+	}
 
 	d.SetId(name)
 	return resourceBigipLtmVirtualAddressRead(d, meta)
 }
 
 func resourceBigipLtmVirtualAddressRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	log.Println("[INFO] Fetching virtual address " + name)
 
-	var va bigip.VirtualAddress
-	vas, err := client.VirtualAddresses()
+	va, err := client.GetVirtualAddress(name)
 	if err != nil {
 		log.Printf("[ERROR] Unable to Retrieve Virtual Address (%s) (%v) ", name, err)
 		return err
 	}
-	if vas == nil {
+	if va == nil {
 		log.Printf("[WARN] VirtualAddress (%s) not found, removing from state", d.Id())
 		d.SetId("")
 		return nil
 	}
-	for _, va = range vas.VirtualAddresses {
-		if va.FullPath == name {
-			break
-		}
-	}
-	if va.FullPath != name {
-		return fmt.Errorf("virtual address %s not found", name)
-	}
 
 	d.Set("name", name)
+	// The route domain of the address is kept only when the configuration
+	// sets one
+	address := va.Address
+	if i := strings.Index(address, "%"); i >= 0 && !strings.Contains(d.Get("address").(string), "%") {
+		address = address[:i]
+	}
+	d.Set("address", address)
+	d.Set("description", va.Description)
 	if err := d.Set("arp", va.ARP); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving ARP to state for Virtual Address  (%s): %s", d.Id(), err)
 	}
@@ -127,9 +168,10 @@ func resourceBigipLtmVirtualAddressRead(d *schema.ResourceData, meta interface{}
 	d.Set("conn_limit", va.ConnectionLimit)
 	d.Set("enabled", va.Enabled)
 	d.Set("icmp_echo", va.ICMPEcho)
-	if err := d.Set("advertize_route", va.RouteAdvertisement); err != nil {
+	if err := d.Set("route_advertisement", va.RouteAdvertisement); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving RouteAdvertisement to state for Virtual Address  (%s): %s", d.Id(), err)
 	}
+	d.Set("advertize_route", va.RouteAdvertisement != "" && va.RouteAdvertisement != "disabled")
 	if err := d.Set("traffic_group", va.TrafficGroup); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving TrafficGroup to state for Virtual Address  (%s): %s", d.Id(), err)
 	}
@@ -137,65 +179,59 @@ func resourceBigipLtmVirtualAddressRead(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceBigipLtmVirtualAddressExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+func resourceBigipLtmVirtualAddressUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
 
 	name := d.Id()
-	log.Println("[INFO] Fetching virtual address " + name)
 
-	var va *bigip.VirtualAddress
-	vas, err := client.VirtualAddresses()
-	if err != nil {
-		log.Printf("[ERROR] Unable to Retrieve Virtual Address  (%s) (%v) ", name, err)
-		return false, err
-	}
-	for _, cand := range vas.VirtualAddresses {
-		if cand.FullPath == name {
-			va = &cand
-			break
+	if d.HasChange("traffic_group") {
+		if err := checkTrafficGroup(client, d.Get("traffic_group").(string)); err != nil {
+			return err
 		}
 	}
 
-	if &va == nil {
-		d.SetId("")
-	}
-
-	return va != nil, nil
-}
-
-func resourceBigipLtmVirtualAddressUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
-
-	name := d.Id()
-
 	va := hydrateVirtualAddress(d)
 
 	err := client.ModifyVirtualAddress(name, va)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Retrieve Virtual Address  (%s) (%v)", name, err)
+		log.Printf("[ERROR] Unable to Modify Virtual Address  (%s) (%v)", name, err)
 		return err
 	}
 
 	return resourceBigipLtmVirtualAddressRead(d, meta)
 }
 
+// virtualAddressRouteAdvertisement returns the route advertisement to send to
+// the BigIP, advertize_route only applies when it was changed.
+func virtualAddressRouteAdvertisement(d *schema.ResourceData) string {
+	if d.HasChange("advertize_route") && !d.HasChange("route_advertisement") {
+		if d.Get("advertize_route").(bool) {
+			return "enabled"
+		}
+		return "disabled"
+	}
+	return d.Get("route_advertisement").(string)
+}
+
 func hydrateVirtualAddress(d *schema.ResourceData) *bigip.VirtualAddress {
 	return &bigip.VirtualAddress{
 		Name:               d.Id(),
+		Address:            d.Get("address").(string),
+		Description:        d.Get("description").(string),
 		ARP:                d.Get("arp").(bool),
 		ConnectionLimit:    d.Get("conn_limit").(int),
 		Enabled:            d.Get("enabled").(bool),
 		ICMPEcho:           d.Get("icmp_echo").(bool),
-		RouteAdvertisement: d.Get("advertize_route").(bool),
+		RouteAdvertisement: virtualAddressRouteAdvertisement(d),
 		TrafficGroup:       d.Get("traffic_group").(string),
 		AutoDelete:         d.Get("auto_delete").(bool),
 	}
 }
 
 func resourceBigipLtmVirtualAddressDelete(d *schema.ResourceData, meta interface{}) error {
-	name := d.Get("name").(string)
-	log.Printf("[INFO] Deleting virtual address " + name)
-	client := meta.(*bigip.BigIP)
+	name := d.Id()
+	log.Println("[INFO] Deleting virtual address " + name)
+	client := meta.(*bigipClient)
 	err := client.DeleteVirtualAddress(name)
 	if err != nil {
 		log.Printf("[ERROR] Unable to Delete Virtual Address  (%s) (%v)", name, err)
@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -51,8 +50,12 @@ func TestAccBigipLtmVA_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckVAExists(TEST_VA_NAME, true),
 				),
-				ResourceName:      TEST_VA_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_VA_RESOURCE,
+				ResourceName:      "bigip_ltm_virtual_address.test-va",
+				ImportState:       true,
+				ImportStateId:     TEST_VA_NAME,
 				ImportStateVerify: true,
 			},
 		},
@@ -61,7 +64,7 @@ func TestAccBigipLtmVA_import(t *testing.T) {
 
 func testCheckVAExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		vas, err := client.VirtualAddresses()
 		if err != nil {
@@ -85,7 +88,7 @@ func testCheckVAExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckVAsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_virtual_address" {
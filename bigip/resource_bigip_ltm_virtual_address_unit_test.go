@@ -0,0 +1,197 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipLtmVirtualAddressServer mocks the virtual address
+// /Common/test-va, the BigIP reports the address with its route domain and,
+// like version 13 and later, enabled route advertisement as selective. The
+// route advertisements sent are recorded in routes.
+func testBigipLtmVirtualAddressServer(routes *[]string) {
+	va := map[string]interface{}{}
+	save := func(r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&va)
+		if va["address"] == nil || va["address"] == "" {
+			va["address"] = "10.0.0.1"
+		}
+		if route, ok := va["routeAdvertisement"]; ok {
+			*routes = append(*routes, route.(string))
+			if route == "enabled" {
+				va["routeAdvertisement"] = "selective"
+			}
+		} else if r.Method == "POST" {
+			va["routeAdvertisement"] = "disabled"
+		}
+		if address := va["address"].(string); !strings.Contains(address, "%") {
+			va["address"] = address + "%0"
+		}
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/cm/traffic-group/~Common~traffic-group-1", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":"traffic-group-1","partition":"Common","fullPath":"/Common/traffic-group-1"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/virtual-address", func(w http.ResponseWriter, r *http.Request) {
+		save(r)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/virtual-address/~Common~test-va", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			save(r)
+		case "DELETE":
+			va = map[string]interface{}{}
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if len(va) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested virtual address (/Common/test-va) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(va)
+	})
+}
+
+func testBigipLtmVirtualAddressConfig(args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_virtual_address" "test-va" {
+			name = "/Common/test-va"
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, server.URL)
+}
+
+func TestAccBigipLtmVirtualAddressRouteAdvertisement(t *testing.T) {
+	setup()
+	var routes []string
+	testBigipLtmVirtualAddressServer(&routes)
+	defer teardown()
+	config := testBigipLtmVirtualAddressConfig(`
+		address = "10.0.0.1"
+		route_advertisement = "selective"
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "address", "10.0.0.1"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "route_advertisement", "selective"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "advertize_route", "true"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_ltm_virtual_address.test-va",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testBigipLtmVirtualAddressConfig(`
+					address = "10.0.0.1"
+					route_advertisement = "any"
+				`),
+				Check: resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "route_advertisement", "any"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"selective", "any"}, routes)
+}
+
+func TestAccBigipLtmVirtualAddressAdvertizeRoute(t *testing.T) {
+	setup()
+	var routes []string
+	testBigipLtmVirtualAddressServer(&routes)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				// The address defaults to the name, here the mock's 10.0.0.1
+				Config: testBigipLtmVirtualAddressConfig(""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "address", "10.0.0.1"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "route_advertisement", "disabled"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "advertize_route", "false"),
+				),
+			},
+			{
+				// The deprecated advertize_route reads back the selective
+				// route advertisement without a diff
+				Config: testBigipLtmVirtualAddressConfig(`advertize_route = true`),
+				Check:  resource.TestCheckResourceAttr("bigip_ltm_virtual_address.test-va", "route_advertisement", "selective"),
+			},
+		},
+	})
+	assert.Equal(t, []string{"enabled"}, routes)
+
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmVirtualAddressConfig(`
+					advertize_route = true
+					route_advertisement = "all"
+				`),
+				ExpectError: regexp.MustCompile("conflicts with"),
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmVirtualAddressDeletedOutOfBand(t *testing.T) {
+	setup()
+	var routes []string
+	testBigipLtmVirtualAddressServer(&routes)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmVirtualAddressConfig(`address = "10.0.0.1"`),
+			},
+			{
+				PreConfig: func() {
+					client := bigip.NewSession(server.URL, "admin", "admin", nil)
+					assert.NoError(t, client.DeleteVirtualAddress("/Common/test-va"))
+				},
+				Config:             testBigipLtmVirtualAddressConfig(`address = "10.0.0.1"`),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestValidateVirtualAddress(t *testing.T) {
+	for _, address := range []string{"10.0.0.1", "10.0.0.1%2", "2001:db8::1", "any6"} {
+		_, errs := validateVirtualAddress(address, "address")
+		assert.Empty(t, errs, address)
+	}
+	for _, address := range []string{"10.0.0.1/24", "vip.example.com", ""} {
+		_, errs := validateVirtualAddress(address, "address")
+		assert.NotEmpty(t, errs, address)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"fmt"
 	"log"
 	"regexp"
+	"strconv"
+	"strings"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipLtmVirtualServer() *schema.Resource {
@@ -25,6 +27,7 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 				Type:         schema.TypeString,
 				Required:     true,
 				Description:  "Name of the virtual server",
+				ForceNew:     true,
 				ValidateFunc: validateF5Name,
 			},
 
@@ -100,9 +103,10 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 			},
 
 			"irules": {
-				Type:     schema.TypeList,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Optional: true,
+				Type:        schema.TypeList,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Optional:    true,
+				Description: "iRules of the virtual server, in the order they are evaluated",
 			},
 
 			"source_address_translation": {
@@ -157,16 +161,22 @@ func resourceBigipLtmVirtualServer() *schema.Resource {
 				Computed:    true,
 				Description: "Enables the virtual server on the VLANs specified by the VLANs option.",
 			},
+			"mirror": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Mirror the connections of the virtual server to the peer device, so they survive a failover",
+			},
 		},
 	}
 }
 
 func resourceBigipLtmVirtualServerCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	port := d.Get("port").(int)
-	TranslateAddress := d.Get("translate_port").(string)
+	TranslateAddress := d.Get("translate_address").(string)
 	TranslatePort := d.Get("translate_port").(string)
 
 	log.Println("[INFO] Creating virtual server " + name)
@@ -197,7 +207,7 @@ func resourceBigipLtmVirtualServerCreate(d *schema.ResourceData, meta interface{
 }
 
 func resourceBigipLtmVirtualServerRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -213,25 +223,25 @@ func resourceBigipLtmVirtualServerRead(d *schema.ResourceData, meta interface{})
 		d.SetId("")
 		return nil
 	}
-	// Extract destination address from "/partition_name/(virtual_server_address)[%route_domain]:port"
-	regex := regexp.MustCompile(`(\/.+\/)((?:[0-9]{1,3}\.){3}[0-9]{1,3})(?:\%\d+)?(\:\d+)`)
-	destination := regex.FindStringSubmatch(vs.Destination)
-	if len(destination) < 3 {
-		return fmt.Errorf("Unable to extract destination address from virtual server destination: " + vs.Destination)
+	destination, port, err := parseVirtualServerDestination(vs.Destination)
+	if err != nil {
+		return err
 	}
-	if err := d.Set("destination", destination[2]); err != nil {
+	if err := d.Set("destination", destination); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Destination to state for Virtual Server  (%s): %s", d.Id(), err)
 	}
+	d.Set("port", port)
 
 	// Extract source address from "(source_address)[%route_domain](/mask)" groups 1 + 2
-	regex = regexp.MustCompile(`((?:[0-9]{1,3}\.){3}[0-9]{1,3})(?:\%\d+)?(\/\d+)`)
-	source := regex.FindStringSubmatch(vs.Source)
-	parsedSource := source[1] + source[2]
+	regex := regexp.MustCompile(`((?:[0-9]{1,3}\.){3}[0-9]{1,3})(?:\%\d+)?(\/\d+)`)
+	parsedSource := vs.Source
+	if source := regex.FindStringSubmatch(vs.Source); source != nil {
+		parsedSource = source[1] + source[2]
+	}
 	if err := d.Set("source", parsedSource); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Source to state for Virtual Server  (%s): %s", d.Id(), err)
 	}
 
-	d.Set("protocol", vs.IPProtocol)
 	d.Set("name", name)
 	if err := d.Set("pool", vs.Pool); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Pool to state for Virtual Server  (%s): %s", d.Id(), err)
@@ -239,7 +249,6 @@ func resourceBigipLtmVirtualServerRead(d *schema.ResourceData, meta interface{})
 	if err := d.Set("mask", vs.Mask); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Mask to state for Virtual Server  (%s): %s", d.Id(), err)
 	}
-	d.Set("port", vs.SourcePort)
 	d.Set("irules", makeStringList(&vs.Rules))
 	d.Set("ip_protocol", vs.IPProtocol)
 	d.Set("source_address_translation", vs.SourceAddressTranslation.Type)
@@ -256,20 +265,30 @@ func resourceBigipLtmVirtualServerRead(d *schema.ResourceData, meta interface{})
 	if err := d.Set("translate_port", vs.TranslatePort); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving TranslatePort to state for Virtual Server  (%s): %s", d.Id(), err)
 	}
-	d.Set("persistence_profiles", vs.PersistenceProfiles)
+	persistenceProfiles := make([]string, len(vs.PersistenceProfiles))
+	for i, profile := range vs.PersistenceProfiles {
+		persistenceProfiles[i] = profile.Name
+		if profile.Partition != "" {
+			persistenceProfiles[i] = fmt.Sprintf("/%s/%s", profile.Partition, profile.Name)
+		}
+	}
+	if err := d.Set("persistence_profiles", makeStringSet(&persistenceProfiles)); err != nil {
+		return fmt.Errorf("[DEBUG] Error saving PersistenceProfiles to state for Virtual Server  (%s): %s", d.Id(), err)
+	}
 	if err := d.Set("fallback_persistence_profile", vs.FallbackPersistenceProfile); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving FallbackPersistenceProfile to state for Virtual Server  (%s): %s", d.Id(), err)
 	}
 	d.Set("vlans_enabled", vs.VlansEnabled)
+	d.Set("mirror", vs.Mirror == "enabled")
 	profiles, err := client.VirtualServerProfiles(name)
 	if err != nil {
 		return err
 	}
 
-	if profiles != nil && len(profiles.Profiles) > 0 {
-		profile_names := schema.NewSet(schema.HashString, make([]interface{}, 0, len(profiles.Profiles)))
-		client_profile_names := schema.NewSet(schema.HashString, make([]interface{}, 0, len(profiles.Profiles)))
-		server_profile_names := schema.NewSet(schema.HashString, make([]interface{}, 0, len(profiles.Profiles)))
+	profile_names := schema.NewSet(schema.HashString, nil)
+	client_profile_names := schema.NewSet(schema.HashString, nil)
+	server_profile_names := schema.NewSet(schema.HashString, nil)
+	if profiles != nil {
 		for _, profile := range profiles.Profiles {
 			switch profile.Context {
 			case bigip.CONTEXT_CLIENT:
@@ -282,22 +301,50 @@ func resourceBigipLtmVirtualServerRead(d *schema.ResourceData, meta interface{})
 				profile_names.Add(profile.FullPath)
 			}
 		}
-		if profile_names.Len() > 0 {
-			d.Set("profiles", profile_names)
-		}
-		if client_profile_names.Len() > 0 {
-			d.Set("client_profiles", client_profile_names)
-		}
-		if server_profile_names.Len() > 0 {
-			d.Set("server_profiles", server_profile_names)
-		}
 	}
+	d.Set("profiles", profile_names)
+	d.Set("client_profiles", client_profile_names)
+	d.Set("server_profiles", server_profile_names)
 
 	return nil
 }
 
+// formatVirtualServerDestination joins an address and port the way the BigIP
+// expects, separating IPv6 addresses from their port with a dot.
+func formatVirtualServerDestination(address string, port int) string {
+	if strings.Contains(address, ":") {
+		return fmt.Sprintf("%s.%d", address, port)
+	}
+	return fmt.Sprintf("%s:%d", address, port)
+}
+
+// parseVirtualServerDestination splits a virtual server destination such as
+// /Common/10.0.0.1%2:443 or /Common/2001:db8::1.443 into its address, without
+// partition and route domain, and its port.
+func parseVirtualServerDestination(destination string) (string, int, error) {
+	_, address := parseF5Identifier(destination)
+	separator := ":"
+	if strings.Count(address, ":") > 1 {
+		// IPv6 addresses use a dot before the port
+		separator = "."
+	}
+	i := strings.LastIndex(address, separator)
+	if i < 0 {
+		return "", 0, fmt.Errorf("Unable to extract destination address from virtual server destination: %s", destination)
+	}
+	port, err := strconv.Atoi(address[i+1:])
+	if err != nil {
+		return "", 0, fmt.Errorf("Unable to extract port from virtual server destination: %s", destination)
+	}
+	address = address[:i]
+	if j := strings.Index(address, "%"); j >= 0 {
+		address = address[:j]
+	}
+	return address, port, nil
+}
+
 func resourceBigipLtmVirtualServerExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Fetching virtual server " + name)
@@ -316,7 +363,7 @@ func resourceBigipLtmVirtualServerExists(d *schema.ResourceData, meta interface{
 }
 
 func resourceBigipLtmVirtualServerUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -360,7 +407,7 @@ func resourceBigipLtmVirtualServerUpdate(d *schema.ResourceData, meta interface{
 	}
 
 	vs := &bigip.VirtualServer{
-		Destination:                fmt.Sprintf("%s:%d", d.Get("destination").(string), d.Get("port").(int)),
+		Destination:                formatVirtualServerDestination(d.Get("destination").(string), d.Get("port").(int)),
 		FallbackPersistenceProfile: d.Get("fallback_persistence_profile").(string),
 		Source:              d.Get("source").(string),
 		Pool:                d.Get("pool").(string),
@@ -381,6 +428,10 @@ func resourceBigipLtmVirtualServerUpdate(d *schema.ResourceData, meta interface{
 		TranslatePort:    d.Get("translate_port").(string),
 		TranslateAddress: d.Get("translate_address").(string),
 		VlansEnabled:     d.Get("vlans_enabled").(bool),
+		Mirror:           "disabled",
+	}
+	if d.Get("mirror").(bool) {
+		vs.Mirror = "enabled"
 	}
 
 	err := client.ModifyVirtualServer(name, vs)
@@ -392,7 +443,7 @@ func resourceBigipLtmVirtualServerUpdate(d *schema.ResourceData, meta interface{
 }
 
 func resourceBigipLtmVirtualServerDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting virtual server " + name)
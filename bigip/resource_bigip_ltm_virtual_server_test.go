@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -86,8 +85,12 @@ func TestAccBigipLtmVS_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckVSExists(TEST_VS_NAME, true),
 				),
-				ResourceName:      TEST_VS_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_VS_RESOURCE,
+				ResourceName:      "bigip_ltm_virtual_server.test-vs",
+				ImportState:       true,
+				ImportStateId:     TEST_VS_NAME,
 				ImportStateVerify: true,
 			},
 		},
@@ -96,7 +99,7 @@ func TestAccBigipLtmVS_import(t *testing.T) {
 
 func testCheckVSExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		vs, err := client.GetVirtualServer(name)
 		if err != nil {
@@ -113,7 +116,7 @@ func testCheckVSExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckVSsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_ltm_virtual_address" {
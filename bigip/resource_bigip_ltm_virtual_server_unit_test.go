@@ -0,0 +1,162 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func testBigipLtmVirtualServerServer() {
+	vs := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/virtual", func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&vs)
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/virtual/~Common~test-vs", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			json.NewEncoder(w).Encode(vs)
+			return
+		case "PUT":
+			vs = map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&vs)
+			// The BigIP reports the destination with its partition and
+			// route domain and splits persistence profile names
+			vs["destination"] = "/Common/" + vs["destination"].(string)
+			vs["source"] = "0.0.0.0%2/0"
+			persist := []map[string]interface{}{}
+			for _, p := range vs["persist"].([]interface{}) {
+				_, name := parseF5Identifier(p.(map[string]interface{})["name"].(string))
+				persist = append(persist, map[string]interface{}{"name": name, "partition": "Common"})
+			}
+			vs["persist"] = persist
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/virtual/~Common~test-vs/profiles", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[{"name":"http","fullPath":"/Common/http","context":"all"},{"name":"tcp","fullPath":"/Common/tcp","context":"clientside"}]}`)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/virtual/~Common~test-vs/policies", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items":[]}`)
+	})
+}
+
+func testBigipLtmVirtualServerConfig(url, args string) string {
+	return fmt.Sprintf(`
+		resource "bigip_ltm_virtual_server" "test-vs" {
+			name = "/Common/test-vs"
+			destination = "10.255.255.254"
+			port = 9999
+			source = "0.0.0.0/0"
+			irules = ["/Common/rule-b", "/Common/rule-a"]
+			profiles = ["/Common/http"]
+			client_profiles = ["/Common/tcp"]
+			persistence_profiles = ["/Common/source_addr"]
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, args, url)
+}
+
+func TestAccBigipLtmVirtualServerImport(t *testing.T) {
+	setup()
+	testBigipLtmVirtualServerServer()
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmVirtualServerConfig(server.URL, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "destination", "10.255.255.254"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "port", "9999"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.0", "/Common/rule-b"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "irules.1", "/Common/rule-a"),
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "persistence_profiles.#", "1"),
+				),
+			},
+			{
+				Config:            testBigipLtmVirtualServerConfig(server.URL, ""),
+				ResourceName:      "bigip_ltm_virtual_server.test-vs",
+				ImportState:       true,
+				ImportStateId:     "/Common/test-vs",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipLtmVirtualServerMirror(t *testing.T) {
+	setup()
+	testBigipLtmVirtualServerServer()
+	defer teardown()
+	checkMirror := func(expected string) resource.TestCheckFunc {
+		return func(s *terraform.State) error {
+			vs, err := bigip.NewSession(server.URL, "admin", "admin", nil).GetVirtualServer("/Common/test-vs")
+			if err != nil {
+				return err
+			}
+			if vs.Mirror != expected {
+				return fmt.Errorf("Expected mirror %s, got %s", expected, vs.Mirror)
+			}
+			return nil
+		}
+	}
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipLtmVirtualServerConfig(server.URL, "mirror = true"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "mirror", "true"),
+					checkMirror("enabled"),
+				),
+			},
+			{
+				Config: testBigipLtmVirtualServerConfig(server.URL, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_ltm_virtual_server.test-vs", "mirror", "false"),
+					checkMirror("disabled"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseVirtualServerDestination(t *testing.T) {
+	for _, c := range []struct {
+		destination string
+		address     string
+		port        int
+	}{
+		{"/Common/10.0.0.1:80", "10.0.0.1", 80},
+		{"/Common/10.0.0.1%2:443", "10.0.0.1", 443},
+		{"/Common/2001:db8::1.443", "2001:db8::1", 443},
+		{"/Common/2001:db8::1%3.0", "2001:db8::1", 0},
+	} {
+		address, port, err := parseVirtualServerDestination(c.destination)
+		assert.Nil(t, err, c.destination)
+		assert.Equal(t, c.address, address, c.destination)
+		assert.Equal(t, c.port, port, c.destination)
+	}
+
+	_, _, err := parseVirtualServerDestination("/Common/any")
+	assert.NotNil(t, err)
+	assert.Equal(t, "2001:db8::1.443", formatVirtualServerDestination("2001:db8::1", 443))
+	assert.Equal(t, "10.0.0.1:80", formatVirtualServerDestination("10.0.0.1", 80))
+}
@@ -3,12 +3,17 @@ package bigip
 import (
 	"fmt"
 	"log"
+	"net"
 	"regexp"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
+// routeNetworkRegex splits a route network of the form
+// address[%route_domain]/prefix or default[%route_domain].
+var routeNetworkRegex = regexp.MustCompile(`^([^%/]+)(%\d+)?(/\d+)?$`)
+
 func resourceBigipNetRoute() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipNetRouteCreate,
@@ -21,72 +26,139 @@ func resourceBigipNetRoute() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the route, either a full path such as /Common/my-route or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+
+			"partition": partitionSchema("route"),
+
+			"full_path": {
 				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the route",
+				Computed:    true,
+				Description: "Full path of the route",
 			},
 
 			"network": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Destination network",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Destination network in CIDR notation, e.g. 10.10.10.0/24, 10.10.10.0%2/24 in route domain 2, or 0.0.0.0/0 (default) for the default route",
+				ValidateFunc: validateRouteNetwork,
+				StateFunc: func(v interface{}) string {
+					network, err := normalizeRouteNetwork(v.(string))
+					if err != nil {
+						return v.(string)
+					}
+					return network
+				},
 			},
 
 			"gw": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Gateway address",
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Gateway address, e.g. 10.1.1.1, or 10.1.1.1%2 in route domain 2",
+				ValidateFunc:  validateRouteGateway,
+				ConflictsWith: []string{"pool"},
+			},
+
+			"pool": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Full path of an LTM pool whose members are the gateways of the route",
+				ValidateFunc:  validateF5Name,
+				ConflictsWith: []string{"gw"},
 			},
 		},
 	}
 
 }
 
-func resourceBigipNetRouteCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+// normalizeRouteNetwork returns a route network in the form reported by the
+// BigIP, address[%route_domain]/prefix, with default as 0.0.0.0/0 and
+// default-inet6 as ::/0.
+func normalizeRouteNetwork(network string) (string, error) {
+	match := routeNetworkRegex.FindStringSubmatch(network)
+	if match == nil {
+		return "", fmt.Errorf("%q is not a network in CIDR notation", network)
+	}
+	address, routeDomain, prefix := match[1], match[2], match[3]
+	switch {
+	case address == "default" && prefix == "":
+		address, prefix = "0.0.0.0", "/0"
+	case address == "default-inet6" && prefix == "":
+		address, prefix = "::", "/0"
+	}
+	ip, ipNet, err := net.ParseCIDR(address + prefix)
+	if err != nil {
+		return "", fmt.Errorf("%q is not a network in CIDR notation", network)
+	}
+	if !ip.Equal(ipNet.IP) {
+		return "", fmt.Errorf("%q has host bits set, the network is %s", network, ipNet.IP)
+	}
+	return address + routeDomain + prefix, nil
+}
 
-	name := d.Get("name").(string)
-	network := d.Get("network").(string)
-	gw := d.Get("gw").(string)
+func validateRouteNetwork(value interface{}, field string) (ws []string, errors []error) {
+	if _, err := normalizeRouteNetwork(value.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be a network in CIDR notation, e.g. 10.1.1.0/24, 10.1.1.0%%2/24 or 0.0.0.0/0: %v", field, err))
+	}
+	return
+}
 
-	log.Println("[INFO] Creating Route")
+func validateRouteGateway(value interface{}, field string) (ws []string, errors []error) {
+	if _, _, err := parseNodeAddress(value.(string)); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an IP address, e.g. 10.1.1.1 or 10.1.1.1%%2: %v", field, err))
+	}
+	return
+}
 
-	err := client.CreateRoute(
-		name,
-		network,
-		gw,
-	)
+func dataToRoute(d *schema.ResourceData) *bigip.Route {
+	network, _ := normalizeRouteNetwork(d.Get("network").(string))
+	return &bigip.Route{
+		Network: network,
+		Gateway: d.Get("gw").(string),
+		Pool:    d.Get("pool").(string),
+	}
+}
 
+func resourceBigipNetRouteCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "route")
 	if err != nil {
-		log.Printf("[ERROR] Unable to Create Route  (%s) (%v)", name, err)
 		return err
 	}
+	log.Println("[INFO] Creating Route " + name)
+
+	config := dataToRoute(d)
+	config.Name = name
+	err = client.AddRoute(config)
+	if err != nil {
+		return fmt.Errorf("Error creating route (%s): %v", name, apiErrorDetails(err))
+	}
 	d.SetId(name)
 	return resourceBigipNetRouteRead(d, meta)
 }
 
 func resourceBigipNetRouteUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	log.Println("[INFO] Updating Route " + name)
 
-	r := &bigip.Route{
-		Name:    name,
-		Network: d.Get("network").(string),
-	}
-
-	err := client.ModifyRoute(name, r)
+	err := client.ModifyRoute(name, dataToRoute(d))
 	if err != nil {
-		log.Printf("[ERROR] Unable to Retrieve Route  (%s) (%v)", name, err)
-		return err
+		return fmt.Errorf("Error modifying route (%s): %v", name, apiErrorDetails(err))
 	}
 	return resourceBigipNetRouteRead(d, meta)
 }
 
 func resourceBigipNetRouteRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	obj, err := client.GetRoute(name)
 	if err != nil {
@@ -98,34 +170,35 @@ func resourceBigipNetRouteRead(d *schema.ResourceData, meta interface{}) error {
 		d.SetId("")
 		return nil
 	}
-	d.Set("name", name)
-
-	regex := regexp.MustCompile(`(default|(?:[0-9]{1,3}\.){3}[0-9]{1,3}\/[0-9]{1,2})(?:\%\d+)?`)
-	network := regex.FindStringSubmatch(obj.Network)
 
-	regex = regexp.MustCompile(`((?:[0-9]{1,3}\.){3}[0-9]{1,3})(?:\%\d+)?`)
-	gw := regex.FindStringSubmatch(obj.Gateway)
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
 
-	if err := d.Set("network", network[1]); err != nil {
+	// The BigIP reports the default routes as default and default-inet6
+	network, err := normalizeRouteNetwork(obj.Network)
+	if err != nil {
+		network = obj.Network
+	}
+	if err := d.Set("network", network); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Network to state for Route (%s): %s", d.Id(), err)
 	}
-
-	if err := d.Set("gw", gw[1]); err != nil {
+	if err := d.Set("gw", obj.Gateway); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Gateway to state for Route (%s): %s", d.Id(), err)
 	}
+	d.Set("pool", obj.Pool)
 	return nil
 }
 
 func resourceBigipNetRouteDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting Route " + name)
 
 	err := client.DeleteRoute(name)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Delete Route  (%s) (%v)", name, err)
-		return err
+		return fmt.Errorf("Error deleting route (%s): %v", name, apiErrorDetails(err))
 	}
 	d.SetId("")
 	return nil
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -81,7 +80,7 @@ func TestAccBigipNetroute_import(t *testing.T) {
 
 func testCheckrouteExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		p, err := client.GetRoute(name)
 		if err != nil {
@@ -98,7 +97,7 @@ func testCheckrouteExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckroutesDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_net_route" {
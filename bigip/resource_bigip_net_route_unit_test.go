@@ -0,0 +1,171 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipNetRouteServer serves the routes created, reporting the default
+// routes as default like the BigIP.
+func testBigipNetRouteServer(posts *[]map[string]interface{}) {
+	routes := map[string]map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/net/route", func(w http.ResponseWriter, r *http.Request) {
+		route := map[string]interface{}{}
+		json.NewDecoder(r.Body).Decode(&route)
+		*posts = append(*posts, route)
+		reported := map[string]interface{}{}
+		for k, v := range route {
+			reported[k] = v
+		}
+		if network, ok := reported["network"].(string); ok {
+			reported["network"] = regexp.MustCompile(`^0\.0\.0\.0(%\d+)?/0$`).ReplaceAllString(network, "default$1")
+		}
+		routes["/mgmt/tm/net/route/"+regexp.MustCompile(`/`).ReplaceAllString(route["name"].(string), "~")] = reported
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/net/route/", func(w http.ResponseWriter, r *http.Request) {
+		route, ok := routes[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested route was not found."}`)
+			return
+		}
+		if r.Method == "DELETE" {
+			delete(routes, r.URL.Path)
+		}
+		json.NewEncoder(w).Encode(route)
+	})
+}
+
+func testBigipNetRouteConfig(url, route string) string {
+	return fmt.Sprintf(`
+		%s
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, route, url)
+}
+
+func TestAccBigipNetRouteRouteDomain(t *testing.T) {
+	setup()
+	var posts []map[string]interface{}
+	testBigipNetRouteServer(&posts)
+	defer teardown()
+	config := testBigipNetRouteConfig(server.URL, `
+		resource "bigip_net_route" "transit" {
+			name = "transit"
+			partition = "Tenant1"
+			network = "10.20.0.0%2/16"
+			gw = "10.1.1.254%2"
+		}
+		resource "bigip_net_route" "default" {
+			name = "/Common/default-gw"
+			network = "default"
+			pool = "/Common/gateways"
+		}
+	`)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_net_route.transit", "id", "/Tenant1/transit"),
+					resource.TestCheckResourceAttr("bigip_net_route.transit", "name", "transit"),
+					resource.TestCheckResourceAttr("bigip_net_route.transit", "full_path", "/Tenant1/transit"),
+					resource.TestCheckResourceAttr("bigip_net_route.transit", "network", "10.20.0.0%2/16"),
+					resource.TestCheckResourceAttr("bigip_net_route.transit", "gw", "10.1.1.254%2"),
+					resource.TestCheckResourceAttr("bigip_net_route.default", "network", "0.0.0.0/0"),
+					resource.TestCheckResourceAttr("bigip_net_route.default", "pool", "/Common/gateways"),
+					resource.TestCheckResourceAttr("bigip_net_route.default", "gw", ""),
+				),
+			},
+			{
+				Config:                  config,
+				ResourceName:            "bigip_net_route.transit",
+				ImportState:             true,
+				ImportStateId:           "/Tenant1/transit",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"name"},
+			},
+		},
+	})
+	assert.Contains(t, posts, map[string]interface{}{"name": "/Common/default-gw", "network": "0.0.0.0/0", "pool": "/Common/gateways"})
+	assert.Contains(t, posts, map[string]interface{}{"name": "/Tenant1/transit", "network": "10.20.0.0%2/16", "gw": "10.1.1.254%2"})
+}
+
+func TestAccBigipNetRouteInvalidNetwork(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipNetRouteConfig("https://127.0.0.1", `
+					resource "bigip_net_route" "test-route" {
+						name = "test-route"
+						network = "10.10.10.1/24"
+						gw = "10.1.1.1"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`has host bits set, the network is 10.10.10.0`),
+			},
+		},
+	})
+}
+
+func TestAccBigipNetRouteGatewayAndPool(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipNetRouteConfig("https://127.0.0.1", `
+					resource "bigip_net_route" "test-route" {
+						name = "test-route"
+						network = "10.10.10.0/24"
+						gw = "10.1.1.1"
+						pool = "/Common/gateways"
+					}
+				`),
+				ExpectError: regexp.MustCompile(`conflicts with`),
+			},
+		},
+	})
+}
+
+func TestNormalizeRouteNetwork(t *testing.T) {
+	for network, expected := range map[string]string{
+		"10.1.0.0/16":      "10.1.0.0/16",
+		"10.1.0.0%2/16":    "10.1.0.0%2/16",
+		"default":          "0.0.0.0/0",
+		"default%2":        "0.0.0.0%2/0",
+		"0.0.0.0/0":        "0.0.0.0/0",
+		"default-inet6":    "::/0",
+		"2001:db8::/32":    "2001:db8::/32",
+		"2001:db8::%3/32":  "2001:db8::%3/32",
+		"10.1.0.0/16%2":    "",
+		"10.1.0.1/16":      "",
+		"10.1.0.0":         "",
+		"not-a-network/24": "",
+	} {
+		normalized, err := normalizeRouteNetwork(network)
+		if expected == "" {
+			assert.NotNil(t, err, network)
+			continue
+		}
+		assert.Nil(t, err, network)
+		assert.Equal(t, expected, normalized, network)
+	}
+}
@@ -3,10 +3,10 @@ package bigip
 import (
 	"fmt"
 	"log"
-	"regexp"
+	"strings"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipNetSelfIP() *schema.Resource {
@@ -22,37 +22,43 @@ func resourceBigipNetSelfIP() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the SelfIP",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the SelfIP",
+				ValidateFunc: validateF5Name,
 			},
 
 			"ip": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "SelfIP IP address",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "SelfIP IP address and netmask in CIDR notation",
+				ValidateFunc: validateSelfIPAddress,
 			},
 
 			"vlan": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the vlan",
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Full path of the vlan",
+				ValidateFunc: validateF5Name,
 			},
 
 			"traffic_group": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "Name of the traffic group, defaults to traffic-group-local-only if not specified",
+				Description: "Name of an existing traffic group, defaults to traffic-group-local-only if not specified. Floating self IPs need a floating traffic group such as traffic-group-1",
 				Default:     "traffic-group-local-only",
+				DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+					return strings.TrimPrefix(old, "/Common/") == strings.TrimPrefix(new, "/Common/")
+				},
 			},
 		},
 	}
 }
 
 func resourceBigipNetSelfIPCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	ip := d.Get("ip").(string)
@@ -60,6 +66,10 @@ func resourceBigipNetSelfIPCreate(d *schema.ResourceData, meta interface{}) erro
 
 	log.Printf("[DEBUG] Creating SelfIP %s", name)
 
+	if err := checkTrafficGroup(client, d.Get("traffic_group").(string)); err != nil {
+		return err
+	}
+
 	err := client.CreateSelfIP(name, ip, vlan)
 
 	if err != nil {
@@ -72,7 +82,7 @@ func resourceBigipNetSelfIPCreate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceBigipNetSelfIPRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 
 	log.Printf("[DEBUG] Reading SelfIP %s", name)
@@ -90,27 +100,32 @@ func resourceBigipNetSelfIPRead(d *schema.ResourceData, meta interface{}) error
 	d.Set("name", selfIP.FullPath)
 	d.Set("vlan", selfIP.Vlan)
 
-	// Extract Self IP address from "(selfip_address)[%route_domain](/mask)" groups 1 + 2
-	regex := regexp.MustCompile(`((?:[0-9]{1,3}\.){3}[0-9]{1,3})(?:\%\d+)?(\/\d+)`)
-	selfipAddress := regex.FindStringSubmatch(selfIP.Address)
-	parsedSelfipAddress := selfipAddress[1] + selfipAddress[2]
-	d.Set("ip", parsedSelfipAddress)
+	// The route domain of "address[%route_domain]/mask" is kept only when
+	// the configuration sets one
+	address := selfIP.Address
+	if match := selfIPAddressRegex.FindStringSubmatch(address); match != nil && !strings.Contains(d.Get("ip").(string), "%") {
+		address = match[1] + match[3]
+	}
+	d.Set("ip", address)
 
-	// Extract Traffic Group name from the full path (ignoring /Common/ prefix)
-	regex = regexp.MustCompile(`\/Common\/(.+)`)
-	trafficGroup := regex.FindStringSubmatch(selfIP.TrafficGroup)
-	d.Set("traffic_group", trafficGroup[1])
+	d.Set("traffic_group", strings.TrimPrefix(selfIP.TrafficGroup, "/Common/"))
 
 	return nil
 }
 
 func resourceBigipNetSelfIPUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	log.Printf("[DEBUG] Updating SelfIP %s", name)
 
+	if !d.IsNewResource() && d.HasChange("traffic_group") {
+		if err := checkTrafficGroup(client, d.Get("traffic_group").(string)); err != nil {
+			return err
+		}
+	}
+
 	r := &bigip.SelfIP{
 		Name:         name,
 		Address:      d.Get("ip").(string),
@@ -128,7 +143,7 @@ func resourceBigipNetSelfIPUpdate(d *schema.ResourceData, meta interface{}) erro
 }
 
 func resourceBigipNetSelfIPDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 
 	log.Printf("[DEBUG] Deleting SelfIP %s", name)
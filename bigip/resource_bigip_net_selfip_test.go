@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -106,7 +105,7 @@ func TestAccBigipNetselfip_import(t *testing.T) {
 
 func testCheckselfipExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.SelfIP(name)
 		if err != nil {
 			return err
@@ -122,7 +121,7 @@ func testCheckselfipExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckselfipsDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_net_selfip" {
 			continue
@@ -133,7 +132,7 @@ func testCheckselfipsDestroyed(s *terraform.State) error {
 		if err != nil {
 			return err
 		}
-		if selfip == nil {
+		if selfip != nil {
 			return fmt.Errorf("selfip %s not destroyed.", name)
 		}
 	}
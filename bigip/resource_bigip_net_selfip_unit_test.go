@@ -0,0 +1,138 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+)
+
+// testBigipNetSelfIPServer mocks a self IP, the BigIP reports the traffic
+// group by full path and the address with its route domain. The traffic
+// groups traffic-group-local-only and traffic-group-1 exist.
+func testBigipNetSelfIPServer() {
+	selfIP := map[string]interface{}{}
+	mux.HandleFunc("/mgmt/tm/cm/traffic-group/", func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(r.URL.Path, "/mgmt/tm/cm/traffic-group/~Common~")
+		if name != "traffic-group-local-only" && name != "traffic-group-1" {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested traffic group (/Common/%s) was not found."}`, name)
+			return
+		}
+		fmt.Fprintf(w, `{"name":"%s","partition":"Common","fullPath":"/Common/%s"}`, name, name)
+	})
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			json.NewDecoder(r.Body).Decode(&selfIP)
+			selfIP["fullPath"] = selfIP["name"]
+			selfIP["address"] = regexp.MustCompile(`/`).ReplaceAllString(selfIP["address"].(string), "%0/")
+			selfIP["trafficGroup"] = "/Common/traffic-group-local-only"
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/net/self/~Common~test-selfip", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			json.NewDecoder(r.Body).Decode(&selfIP)
+			selfIP["trafficGroup"] = "/Common/" + regexp.MustCompile(`^/Common/`).ReplaceAllString(selfIP["trafficGroup"].(string), "")
+		case "DELETE":
+			selfIP = map[string]interface{}{}
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if len(selfIP) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested Self IP (/Common/test-selfip) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(selfIP)
+	})
+}
+
+func testBigipNetSelfIPConfig(ip, trafficGroup string) string {
+	return fmt.Sprintf(`
+		resource "bigip_net_selfip" "test-selfip" {
+			name = "/Common/test-selfip"
+			ip = "%s"
+			vlan = "/Common/test-vlan"
+			traffic_group = "%s"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, ip, trafficGroup, server.URL)
+}
+
+func TestAccBigipNetSelfIPTrafficGroup(t *testing.T) {
+	setup()
+	testBigipNetSelfIPServer()
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipNetSelfIPConfig("2001:db8::1/64", "traffic-group-1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "ip", "2001:db8::1/64"),
+					resource.TestCheckResourceAttr("bigip_net_selfip.test-selfip", "traffic_group", "traffic-group-1"),
+				),
+			},
+			{
+				// The full path of the traffic group is no change
+				Config:   testBigipNetSelfIPConfig("2001:db8::1/64", "/Common/traffic-group-1"),
+				PlanOnly: true,
+			},
+			{
+				Config:            testBigipNetSelfIPConfig("2001:db8::1/64", "traffic-group-1"),
+				ResourceName:      "bigip_net_selfip.test-selfip",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccBigipNetSelfIPUnknownTrafficGroup(t *testing.T) {
+	setup()
+	testBigipNetSelfIPServer()
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipNetSelfIPConfig("10.1.1.1/24", "traffic-group-2"),
+				ExpectError: regexp.MustCompile("Traffic group /Common/traffic-group-2 does not exist on the BigIP"),
+			},
+			{
+				Config: testBigipNetSelfIPConfig("10.1.1.1/24", "traffic-group-1"),
+			},
+			{
+				Config:      testBigipNetSelfIPConfig("10.1.1.1/24", "/Common/traffic-group-2"),
+				ExpectError: regexp.MustCompile("Traffic group /Common/traffic-group-2 does not exist on the BigIP"),
+			},
+		},
+	})
+}
+
+func TestAccBigipNetSelfIPInvalidAddress(t *testing.T) {
+	for _, ip := range []string{"10.1.1.1", "10.1.1.300/24", "10.1.1.1/33", "vlan/24"} {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipNetSelfIPConfig(ip, "traffic-group-1"),
+					ExpectError: regexp.MustCompile("must be an address and netmask in CIDR notation"),
+				},
+			},
+		})
+	}
+}
@@ -4,8 +4,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipNetVlan() *schema.Resource {
@@ -21,16 +21,19 @@ func resourceBigipNetVlan() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "Name of the VLAN",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the VLAN",
+				ValidateFunc: validateF5Name,
 			},
 
 			"tag": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "VLAN ID (tag)",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Computed:     true,
+				Description:  "VLAN ID (tag), assigned by the BigIP when not set",
+				ValidateFunc: validateIntRange(1, 4094),
 			},
 
 			"interfaces": {
@@ -59,7 +62,7 @@ func resourceBigipNetVlan() *schema.Resource {
 }
 
 func resourceBigipNetVlanCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	tag := d.Get("tag").(int)
@@ -100,7 +103,7 @@ func resourceBigipNetVlanCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipNetVlanRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -152,7 +155,7 @@ func resourceBigipNetVlanRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceBigipNetVlanUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -162,6 +165,10 @@ func resourceBigipNetVlanUpdate(d *schema.ResourceData, meta interface{}) error
 		Name: name,
 		Tag:  d.Get("tag").(int),
 	}
+	if d.HasChange("interfaces") {
+		interfaces := dataToVlanInterfaces(d)
+		r.Interfaces = &interfaces
+	}
 
 	err := client.ModifyVlan(name, r)
 	if err != nil {
@@ -172,7 +179,7 @@ func resourceBigipNetVlanUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipNetVlanDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -186,3 +193,20 @@ func resourceBigipNetVlanDelete(d *schema.ResourceData, meta interface{}) error
 	d.SetId("")
 	return nil
 }
+
+// dataToVlanInterfaces returns the interfaces of the VLAN, each one either
+// tagged or untagged.
+func dataToVlanInterfaces(d *schema.ResourceData) []bigip.VlanInterface {
+	interfaces := []bigip.VlanInterface{}
+	for i := 0; i < d.Get("interfaces.#").(int); i++ {
+		prefix := fmt.Sprintf("interfaces.%d", i)
+		iface := bigip.VlanInterface{Name: d.Get(prefix + ".vlanport").(string)}
+		if d.Get(prefix + ".tagged").(bool) {
+			iface.Tagged = true
+		} else {
+			iface.Untagged = true
+		}
+		interfaces = append(interfaces, iface)
+	}
+	return interfaces
+}
@@ -5,7 +5,6 @@ import (
 	"testing"
 	"time"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -68,7 +67,7 @@ func TestAccBigipNetvlan_import(t *testing.T) {
 
 func testCheckvlanExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 		p, err := client.Vlan(name)
 		if err != nil {
 			return err
@@ -84,7 +83,7 @@ func testCheckvlanExists(name string, exists bool) resource.TestCheckFunc {
 }
 
 func testCheckvlansDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 	time.Sleep(2 * time.Second)
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_net_vlan" {
@@ -96,7 +95,7 @@ func testCheckvlansDestroyed(s *terraform.State) error {
 		if err != nil {
 			return err
 		}
-		if vlan == nil {
+		if vlan != nil {
 			return fmt.Errorf("vlan %s not destroyed.", name)
 		}
 	}
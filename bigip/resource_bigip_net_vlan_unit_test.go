@@ -0,0 +1,116 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+// testBigipNetVlanServer mocks a VLAN whose interfaces are replaced by a
+// PUT, the BigIP assigns a tag when none is set.
+func testBigipNetVlanServer() {
+	var vlan *bigip.Vlan
+	interfaces := []bigip.VlanInterface{}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/net/vlan", func(w http.ResponseWriter, r *http.Request) {
+		vlan = &bigip.Vlan{}
+		json.NewDecoder(r.Body).Decode(vlan)
+		vlan.FullPath = vlan.Name
+		if vlan.Tag == 0 {
+			vlan.Tag = 4093
+		}
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/net/vlan/~Common~test-vlan", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			update := &bigip.Vlan{}
+			json.NewDecoder(r.Body).Decode(update)
+			vlan.Tag = update.Tag
+			if update.Interfaces != nil {
+				interfaces = *update.Interfaces
+			}
+		case "DELETE":
+			vlan = nil
+			fmt.Fprintf(w, `{}`)
+			return
+		}
+		if vlan == nil {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested VLAN (/Common/test-vlan) was not found."}`)
+			return
+		}
+		json.NewEncoder(w).Encode(vlan)
+	})
+	mux.HandleFunc("/mgmt/tm/net/vlan/~Common~test-vlan/interfaces", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			iface := bigip.VlanInterface{}
+			json.NewDecoder(r.Body).Decode(&iface)
+			interfaces = append(interfaces, iface)
+		}
+		json.NewEncoder(w).Encode(&bigip.VlanInterfaces{VlanInterfaces: interfaces})
+	})
+}
+
+func testBigipNetVlanConfig(tag, interfaces string) string {
+	return fmt.Sprintf(`
+		resource "bigip_net_vlan" "test-vlan" {
+			name = "/Common/test-vlan"
+			%s
+			%s
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, tag, interfaces, server.URL)
+}
+
+func TestAccBigipNetVlanInterfaces(t *testing.T) {
+	setup()
+	testBigipNetVlanServer()
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipNetVlanConfig("", `interfaces { vlanport = "1.1" }`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "tag", "4093"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.#", "1"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.0.tagged", "false"),
+				),
+			},
+			{
+				Config: testBigipNetVlanConfig("tag = 101", `
+					interfaces {
+						vlanport = "1.1"
+						tagged = true
+					}
+					interfaces {
+						vlanport = "1.2"
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "tag", "101"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.#", "2"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.0.tagged", "true"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.1.vlanport", "1.2"),
+					resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.1.tagged", "false"),
+				),
+			},
+			{
+				Config: testBigipNetVlanConfig("tag = 101", ""),
+				Check:  resource.TestCheckResourceAttr("bigip_net_vlan.test-vlan", "interfaces.#", "0"),
+			},
+		},
+	})
+}
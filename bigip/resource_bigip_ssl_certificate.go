@@ -0,0 +1,166 @@
+package bigip
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceBigipSslCertificate() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipSslCertificateCreate,
+		Read:   resourceBigipSslCertificateRead,
+		Update: resourceBigipSslCertificateUpdate,
+		Delete: resourceBigipSslCertificateDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the certificate, e.g. www.example.com.crt, either a full path or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("certificate"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the certificate, for referencing it from client SSL profiles",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "PEM encoded certificate, only its hash is kept in the state",
+				StateFunc:   cryptoContentHash,
+			},
+			"checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Checksum of the installed certificate as reported by the BigIP",
+			},
+		},
+	}
+}
+
+// cryptoContentHash returns the hash of the content of a certificate or key
+// kept in the state, changing the content changes the hash.
+func cryptoContentHash(v interface{}) string {
+	return fmt.Sprintf("sha256:%x", sha256.Sum256([]byte(v.(string))))
+}
+
+// installCryptoFile uploads content and installs it with install, the
+// uploaded file is removed afterwards so that no copy of keys is left behind.
+func installCryptoFile(client *bigipClient, name, content string, install func(path string) error) error {
+	filename := strings.Replace(strings.TrimPrefix(name, "/"), "/", "_", -1)
+	path, err := client.UploadBytes([]byte(content), filename)
+	if err != nil {
+		return fmt.Errorf("Error uploading %s: %v", name, apiErrorDetails(err))
+	}
+	defer func() {
+		if err := client.RemoveUploadedFile(path); err != nil {
+			log.Printf("[WARN] Unable to remove the uploaded file %s: %v", path, err)
+		}
+	}()
+	if err := install(path); err != nil {
+		return fmt.Errorf("Error installing %s: %v", name, apiErrorDetails(err))
+	}
+	return nil
+}
+
+// readCryptoChecksum saves the checksum of an installed certificate or key.
+// A checksum other than the one saved means the file was replaced out of
+// band, the content is then cleared so that the next apply installs it again.
+func readCryptoChecksum(d *schema.ResourceData, checksum string) {
+	if old := d.Get("checksum").(string); old != "" && old != checksum {
+		log.Printf("[WARN] %s was changed out of band, its content will be installed again", d.Id())
+		d.Set("content", "")
+	}
+	d.Set("checksum", checksum)
+}
+
+func resourceBigipSslCertificateCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "SSL certificate")
+	if err != nil {
+		return err
+	}
+	log.Println("[INFO] Installing SSL certificate " + name)
+
+	existing, err := client.GetCertificate(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving SSL certificate %s: %v", name, err)
+	}
+	if existing != nil {
+		return fmt.Errorf("SSL certificate %s already exists, import it to manage it", name)
+	}
+
+	err = installCryptoFile(client, name, d.Get("content").(string), func(path string) error {
+		return client.InstallCertificate(name, path)
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+	return resourceBigipSslCertificateRead(d, meta)
+}
+
+func resourceBigipSslCertificateRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Fetching SSL certificate " + name)
+
+	cert, err := client.GetCertificate(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving SSL certificate %s: %v", name, err)
+	}
+	if cert == nil {
+		log.Printf("[WARN] SSL certificate (%s) not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	readCryptoChecksum(d, cert.Checksum)
+	return nil
+}
+
+func resourceBigipSslCertificateUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Replacing SSL certificate " + name)
+
+	err := installCryptoFile(client, name, d.Get("content").(string), func(path string) error {
+		return client.InstallCertificate(name, path)
+	})
+	if err != nil {
+		return err
+	}
+	// The checksum of the new content is expected to differ
+	d.Set("checksum", "")
+	return resourceBigipSslCertificateRead(d, meta)
+}
+
+func resourceBigipSslCertificateDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting SSL certificate " + name)
+
+	err := client.DeleteCertificate(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting SSL certificate %s: %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
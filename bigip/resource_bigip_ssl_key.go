@@ -0,0 +1,135 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceBigipSslKey() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceBigipSslKeyCreate,
+		Read:   resourceBigipSslKeyRead,
+		Update: resourceBigipSslKeyUpdate,
+		Delete: resourceBigipSslKeyDelete,
+		Importer: &schema.ResourceImporter{
+			State: schema.ImportStatePassthrough,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Name of the key, e.g. www.example.com.key, either a full path or a name relative to partition",
+				ValidateFunc: validateF5NameOrFullPath,
+			},
+			"partition": partitionSchema("key"),
+			"full_path": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full path of the key, for referencing it from client SSL profiles",
+			},
+			"content": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "PEM encoded private key, only its hash is kept in the state",
+				StateFunc:   cryptoContentHash,
+			},
+			"passphrase": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Passphrase of an encrypted key",
+			},
+			"checksum": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Checksum of the installed key as reported by the BigIP",
+			},
+		},
+	}
+}
+
+func resourceBigipSslKeyCreate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name, err := resourceFullPath(d, client, "SSL key")
+	if err != nil {
+		return err
+	}
+	log.Println("[INFO] Installing SSL key " + name)
+
+	existing, err := client.GetKey(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving SSL key %s: %v", name, err)
+	}
+	if existing != nil {
+		return fmt.Errorf("SSL key %s already exists, import it to manage it", name)
+	}
+
+	err = installCryptoFile(client, name, d.Get("content").(string), func(path string) error {
+		return client.InstallKey(name, path, d.Get("passphrase").(string))
+	})
+	if err != nil {
+		return err
+	}
+	d.SetId(name)
+	return resourceBigipSslKeyRead(d, meta)
+}
+
+func resourceBigipSslKeyRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Fetching SSL key " + name)
+
+	key, err := client.GetKey(name)
+	if err != nil {
+		return fmt.Errorf("Error retrieving SSL key %s: %v", name, err)
+	}
+	if key == nil {
+		log.Printf("[WARN] SSL key (%s) not found, removing from state", name)
+		d.SetId("")
+		return nil
+	}
+
+	if err := setNameAndPartition(d, client, name); err != nil {
+		return err
+	}
+	readCryptoChecksum(d, key.Checksum)
+	return nil
+}
+
+func resourceBigipSslKeyUpdate(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Replacing SSL key " + name)
+
+	err := installCryptoFile(client, name, d.Get("content").(string), func(path string) error {
+		return client.InstallKey(name, path, d.Get("passphrase").(string))
+	})
+	if err != nil {
+		return err
+	}
+	// The checksum of the new content is expected to differ
+	d.Set("checksum", "")
+	return resourceBigipSslKeyRead(d, meta)
+}
+
+func resourceBigipSslKeyDelete(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
+
+	name := d.Id()
+	log.Println("[INFO] Deleting SSL key " + name)
+
+	err := client.DeleteKey(name)
+	if err != nil {
+		return fmt.Errorf("Error deleting SSL key %s: %v", name, apiErrorDetails(err))
+	}
+	d.SetId("")
+	return nil
+}
@@ -4,8 +4,8 @@ import (
 	"log"
 	"time"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipSysBigiplicense() *schema.Resource {
@@ -36,7 +36,7 @@ func resourceBigipSysBigiplicense() *schema.Resource {
 }
 
 func resourceBigipSysBigiplicenseCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	command := d.Get("command").(string)
 	registration_key := d.Get("registration_key").(string)
@@ -56,7 +56,7 @@ func resourceBigipSysBigiplicenseCreate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceBigipSysBigiplicenseUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	registration_key := d.Id()
 
@@ -71,7 +71,7 @@ func resourceBigipSysBigiplicenseUpdate(d *schema.ResourceData, meta interface{}
 }
 
 func resourceBigipSysBigiplicenseRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -2,11 +2,14 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 	"log"
 )
 
+// sysDnsID is the ID of the DNS configuration, there is one per BigIP.
+const sysDnsID = "dns"
+
 func resourceBigipSysDns() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipSysDnsCreate,
@@ -14,7 +17,7 @@ func resourceBigipSysDns() *schema.Resource {
 		Read:   resourceBigipSysDnsRead,
 		Delete: resourceBigipSysDnsDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importSingleton(sysDnsID),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,6 +39,7 @@ func resourceBigipSysDns() *schema.Resource {
 			"number_of_dots": {
 				Type:        schema.TypeInt,
 				Optional:    true,
+				Computed:    true,
 				Description: "how many DNS Servers",
 			},
 
@@ -46,52 +50,43 @@ func resourceBigipSysDns() *schema.Resource {
 				Optional:    true,
 				Description: "Servers search domain",
 			},
+
+			"include": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Lines added to the resolver configuration of the BigIP",
+			},
 		},
 	}
 
 }
 
 func resourceBigipSysDnsCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	description := d.Get("description").(string)
-	nameservers := setToStringSlice(d.Get("name_servers").(*schema.Set))
-	numberofdots := d.Get("number_of_dots").(int)
-	search := setToStringSlice(d.Get("search").(*schema.Set))
 
 	log.Println("[INFO] Creating Dns ")
 
-	err := client.CreateDNS(
-		description,
-		nameservers,
-		numberofdots,
-		search,
-	)
-
+	// The DNS configuration always exists, creating it modifies it
+	err := client.ModifyDNS(dataToDNS(d))
 	if err != nil {
 		log.Printf("[ERROR] Unable to Create DNS (%s) (%v) ", description, err)
 		return err
 	}
-	d.SetId(description)
+	d.SetId(sysDnsID)
 
 	return resourceBigipSysDnsRead(d, meta)
 }
 
 func resourceBigipSysDnsUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
-	description := d.Id()
+	description := d.Get("description").(string)
 
 	log.Println("[INFO] Updating DNS " + description)
 
-	r := &bigip.DNS{
-		Description:  description,
-		NameServers:  setToStringSlice(d.Get("name_servers").(*schema.Set)),
-		NumberOfDots: d.Get("number_of_dots").(int),
-		Search:       setToStringSlice(d.Get("search").(*schema.Set)),
-	}
-
-	err := client.ModifyDNS(r)
+	err := client.ModifyDNS(dataToDNS(d))
 	if err != nil {
 		log.Printf("[ERROR] Unable to Modify DNS (%s) (%v) ", description, err)
 		return err
@@ -99,16 +94,24 @@ func resourceBigipSysDnsUpdate(d *schema.ResourceData, meta interface{}) error {
 	return resourceBigipSysDnsRead(d, meta)
 }
 
-func resourceBigipSysDnsRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+func dataToDNS(d *schema.ResourceData) *bigip.DNS {
+	return &bigip.DNS{
+		Description:  d.Get("description").(string),
+		NameServers:  setToStringSlice(d.Get("name_servers").(*schema.Set)),
+		NumberOfDots: d.Get("number_of_dots").(int),
+		Search:       setToStringSlice(d.Get("search").(*schema.Set)),
+		Include:      d.Get("include").(string),
+	}
+}
 
-	description := d.Id()
+func resourceBigipSysDnsRead(d *schema.ResourceData, meta interface{}) error {
+	client := meta.(*bigipClient)
 
-	log.Println("[INFO] Reading DNS " + description)
+	log.Println("[INFO] Reading DNS " + d.Id())
 
 	dns, err := client.DNSs()
 	if err != nil {
-		log.Printf("[ERROR] Unable to Retrieve DNS (%s) (%v) ", description, err)
+		log.Printf("[ERROR] Unable to Retrieve DNS (%s) (%v) ", d.Id(), err)
 		return err
 	}
 	if dns == nil {
@@ -127,12 +130,26 @@ func resourceBigipSysDnsRead(d *schema.ResourceData, meta interface{}) error {
 	if err := d.Set("search", dns.Search); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving Search  to state for DNS (%s): %s", d.Id(), err)
 	}
+	d.Set("include", dns.Include)
 
 	return nil
 }
 
 func resourceBigipSysDnsDelete(d *schema.ResourceData, meta interface{}) error {
-	// There is no Delete API for this operation
+	client := meta.(*bigipClient)
+
+	// The DNS configuration can not be deleted, remove the name servers,
+	// search domains and include instead
+	log.Println("[INFO] Resetting DNS " + d.Id())
 
+	err := client.ModifyDNS(&bigip.DNS{
+		NameServers: []string{},
+		Search:      []string{},
+	})
+	if err != nil {
+		log.Printf("[ERROR] Unable to Reset DNS (%s) (%v) ", d.Id(), err)
+		return err
+	}
+	d.SetId("")
 	return nil
 }
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -59,8 +58,12 @@ func TestAccBigipSysdns_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckdnsExists(TEST_DNS_NAME, true),
 				),
-				ResourceName:      TEST_DNS_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_DNS_RESOURCE,
+				ResourceName:      "bigip_sys_dns.test-dns",
+				ImportState:       true,
+				ImportStateId:     "dns",
 				ImportStateVerify: true,
 			},
 		},
@@ -69,7 +72,7 @@ func TestAccBigipSysdns_import(t *testing.T) {
 
 func testCheckdnsExists(description string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		dns, err := client.DNSs()
 		if err != nil {
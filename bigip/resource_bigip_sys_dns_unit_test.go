@@ -0,0 +1,88 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipSysSingletonServer mocks a system wide configuration at path that
+// always exists, the bodies of the requests changing it are recorded.
+func testBigipSysSingletonServer(path string, config map[string]interface{}, bodies *[]string) {
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "GET" {
+			body, _ := ioutil.ReadAll(r.Body)
+			*bodies = append(*bodies, string(body))
+			json.Unmarshal(body, &config)
+		}
+		json.NewEncoder(w).Encode(config)
+	})
+}
+
+func testBigipSysDnsConfig(url, servers string) string {
+	return fmt.Sprintf(`
+		resource "bigip_sys_dns" "test-dns" {
+			description = "/Common/test-dns"
+			name_servers = [%s]
+			search = ["example.com"]
+			include = "options rotate"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, servers, url)
+}
+
+func TestAccBigipSysDnsSingleton(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipSysSingletonServer("/mgmt/tm/sys/dns", map[string]interface{}{"numberOfDots": 1}, &bodies)
+	defer teardown()
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testBigipSysDnsConfig(server.URL, `"10.0.0.53"`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_dns.test-dns", "id", "dns"),
+					resource.TestCheckResourceAttr("bigip_sys_dns.test-dns", "name_servers.#", "1"),
+					resource.TestCheckResourceAttr("bigip_sys_dns.test-dns", "number_of_dots", "1"),
+					resource.TestCheckResourceAttr("bigip_sys_dns.test-dns", "include", "options rotate"),
+				),
+			},
+			{
+				Config: testBigipSysDnsConfig(server.URL, ""),
+				Check:  resource.TestCheckResourceAttr("bigip_sys_dns.test-dns", "name_servers.#", "0"),
+			},
+			{
+				Config:            testBigipSysDnsConfig(server.URL, ""),
+				ResourceName:      "bigip_sys_dns.test-dns",
+				ImportState:       true,
+				ImportStateId:     "dns",
+				ImportStateVerify: true,
+			},
+			{
+				Config:        testBigipSysDnsConfig(server.URL, ""),
+				ResourceName:  "bigip_sys_dns.test-dns",
+				ImportState:   true,
+				ImportStateId: "/Common/test-dns",
+				ExpectError:   regexp.MustCompile(`import it with the ID "dns"`),
+			},
+		},
+	})
+	assert.Contains(t, bodies[1], `"nameServers":[]`)
+	// Destroying the resource resets the configuration
+	assert.JSONEq(t, `{"nameServers":[],"search":[],"include":""}`, bodies[len(bodies)-1])
+}
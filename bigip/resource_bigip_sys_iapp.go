@@ -5,8 +5,8 @@ import (
 	"fmt"
 	"log"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
 func resourceBigipSysIapp() *schema.Resource {
@@ -213,7 +213,7 @@ func resourceBigipSysIapp() *schema.Resource {
 	}
 }
 func resourceBigipSysIappCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Get("name").(string)
 	description := d.Get("description").(string)
 
@@ -232,7 +232,7 @@ func resourceBigipSysIappCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipSysIappUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	log.Println("[INFO] Updating Iapp " + name)
 	p := dataToIapp(name, d)
@@ -246,7 +246,7 @@ func resourceBigipSysIappUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipSysIappRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -295,7 +295,7 @@ func resourceBigipSysIappRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceBigipSysIappDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 	name := d.Id()
 	err := client.DeleteIapp(name)
 	if err != nil {
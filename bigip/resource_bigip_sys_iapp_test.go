@@ -5,7 +5,6 @@ import (
 	"log"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 )
@@ -183,7 +182,7 @@ func TestAccBigipSysIapp_import(t *testing.T) {
 
 func testCheckIappExists(name string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		jsonfile, err := client.Iapp(name)
 		log.Println(" I am here in Exists !!!!!!!!!!!!", name)
@@ -205,7 +204,7 @@ func testCheckIappExists(name string) resource.TestCheckFunc {
 }
 
 func testCheckIappDestroyed(s *terraform.State) error {
-	client := testAccProvider.Meta().(*bigip.BigIP)
+	client := testAccProvider.Meta().(*bigipClient)
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "bigip_sys_iapp" {
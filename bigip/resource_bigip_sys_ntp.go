@@ -2,11 +2,17 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 	"log"
 )
 
+// sysNtpID is the ID of the NTP configuration, there is one per BigIP.
+const sysNtpID = "ntp"
+
+// defaultTimezone is the timezone of a BigIP as shipped.
+const defaultTimezone = "America/Los_Angeles"
+
 func resourceBigipSysNtp() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipSysNtpCreate,
@@ -14,7 +20,7 @@ func resourceBigipSysNtp() *schema.Resource {
 		Read:   resourceBigipSysNtpRead,
 		Delete: resourceBigipSysNtpDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			State: importSingleton(sysNtpID),
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -36,6 +42,7 @@ func resourceBigipSysNtp() *schema.Resource {
 			"timezone": {
 				Type:        schema.TypeString,
 				Optional:    true,
+				Computed:    true,
 				Description: "Servers timezone",
 			},
 		},
@@ -44,7 +51,7 @@ func resourceBigipSysNtp() *schema.Resource {
 }
 
 func resourceBigipSysNtpCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	description := d.Get("description").(string)
 	servers := setToStringSlice(d.Get("servers").(*schema.Set))
@@ -62,14 +69,14 @@ func resourceBigipSysNtpCreate(d *schema.ResourceData, meta interface{}) error {
 		log.Printf("[ERROR] Unable to Configure  NTP   (%s) ", err)
 		return err
 	}
-	d.SetId(description)
+	d.SetId(sysNtpID)
 	return resourceBigipSysNtpRead(d, meta)
 }
 
 func resourceBigipSysNtpUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
-	description := d.Id()
+	description := d.Get("description").(string)
 
 	log.Println("[INFO] Updating NTP " + description)
 
@@ -88,11 +95,9 @@ func resourceBigipSysNtpUpdate(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceBigipSysNtpRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
-	description := d.Id()
-
-	log.Println("[INFO] Reading NTP " + description)
+	log.Println("[INFO] Reading NTP " + d.Id())
 
 	ntp, err := client.NTPs()
 	if err != nil {
@@ -119,6 +124,20 @@ func resourceBigipSysNtpRead(d *schema.ResourceData, meta interface{}) error {
 }
 
 func resourceBigipSysNtpDelete(d *schema.ResourceData, meta interface{}) error {
-	/* This function is not supported on BIG-IP, you cannot DELETE NTP API is not supported */
+	client := meta.(*bigipClient)
+
+	// The NTP configuration can not be deleted, remove the servers and
+	// restore the default timezone instead
+	log.Println("[INFO] Resetting NTP " + d.Id())
+
+	err := client.ModifyNTP(&bigip.NTP{
+		Servers:  []string{},
+		Timezone: defaultTimezone,
+	})
+	if err != nil {
+		log.Printf("[ERROR] Unable to Reset NTP (%v) ", err)
+		return err
+	}
+	d.SetId("")
 	return nil
 }
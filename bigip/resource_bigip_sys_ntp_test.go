@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"testing"
 
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
 	"github.com/hashicorp/terraform/terraform"
@@ -55,8 +54,12 @@ func TestAccBigipSysNtp_import(t *testing.T) {
 				Check: resource.ComposeTestCheckFunc(
 					testCheckntpExists(TEST_NTP_NAME, true),
 				),
-				ResourceName:      TEST_NTP_NAME,
-				ImportState:       false,
+			},
+			{
+				Config:            TEST_NTP_RESOURCE,
+				ResourceName:      "bigip_sys_ntp.test-ntp",
+				ImportState:       true,
+				ImportStateId:     "ntp",
 				ImportStateVerify: true,
 			},
 		},
@@ -65,7 +68,7 @@ func TestAccBigipSysNtp_import(t *testing.T) {
 
 func testCheckntpExists(description string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		ntp, err := client.NTPs()
 		if err != nil {
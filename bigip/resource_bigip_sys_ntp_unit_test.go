@@ -2,9 +2,11 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/hashicorp/terraform/helper/resource"
 	"regexp"
 	"testing"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
 )
 
 func testBigipSysNtpInvalid(resourceName string) string {
@@ -36,3 +38,44 @@ func TestAccBigipSysNtpInvalid(t *testing.T) {
 		},
 	})
 }
+
+func TestAccBigipSysNtpSingleton(t *testing.T) {
+	setup()
+	var bodies []string
+	testBigipSysSingletonServer("/mgmt/tm/sys/ntp", map[string]interface{}{"timezone": "America/Los_Angeles"}, &bodies)
+	defer teardown()
+	config := fmt.Sprintf(`
+		resource "bigip_sys_ntp" "test-ntp" {
+			description = "/Common/test-ntp"
+			servers = ["10.10.10.10"]
+			timezone = "Europe/Paris"
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+		}
+	`, server.URL)
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: config,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("bigip_sys_ntp.test-ntp", "id", "ntp"),
+					resource.TestCheckResourceAttr("bigip_sys_ntp.test-ntp", "timezone", "Europe/Paris"),
+				),
+			},
+			{
+				Config:            config,
+				ResourceName:      "bigip_sys_ntp.test-ntp",
+				ImportState:       true,
+				ImportStateId:     "ntp",
+				ImportStateVerify: true,
+			},
+		},
+	})
+	// Destroying the resource resets the configuration
+	assert.JSONEq(t, `{"servers":[],"timezone":"America/Los_Angeles"}`, bodies[len(bodies)-1])
+}
@@ -2,11 +2,23 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
-	"github.com/hashicorp/terraform/helper/schema"
 	"log"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 )
 
+// provisionModules are the modules of the BIG-IP that can be provisioned.
+var provisionModules = []string{
+	"afm", "am", "apm", "asm", "avr", "cgnat", "dos", "fps", "gtm", "ilx",
+	"lc", "ltm", "pem", "sslo", "swg", "urldb",
+}
+
+// provisionSettleTime is waited for after changing a level before checking
+// whether the BIG-IP is ready, it reports ready until provisioning starts.
+const provisionSettleTime = 10 * time.Second
+
 func resourceBigipSysProvision() *schema.Resource {
 	return &schema.Resource{
 		Create: resourceBigipSysProvisionCreate,
@@ -19,15 +31,22 @@ func resourceBigipSysProvision() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of the module to be provisioned",
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				Description:  "Module to provision, e.g. ltm, gtm, asm or apm",
+				ValidateFunc: validateProvisionModule,
+				StateFunc: func(v interface{}) string {
+					return provisionModule(v.(string))
+				},
 			},
 
 			"full_path": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "path",
+				Computed:    true,
+				Description: "Full path of the module as reported by the BigIP",
+				Deprecated:  "The module is set by name, full_path is ignored",
 			},
 
 			"cpu_ratio": {
@@ -43,10 +62,11 @@ func resourceBigipSysProvision() *schema.Resource {
 			},
 
 			"level": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "what level nominal or dedicated",
-				Default:     "nominal",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Provisioning level of the module: none, minimum, nominal or dedicated",
+				Default:      "nominal",
+				ValidateFunc: validateStringValue([]string{"none", "minimum", "nominal", "dedicated"}),
 			},
 
 			"memory_ratio": {
@@ -54,42 +74,47 @@ func resourceBigipSysProvision() *schema.Resource {
 				Optional:    true,
 				Description: "memory Ratio",
 			},
+
+			"wait_for_ready": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait after changing the level until the BigIP is ready again, including after a reboot.",
+			},
+
+			"wait_timeout": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1800,
+				Description: "Seconds to wait for the BigIP to be ready when wait_for_ready is set. Provisioning fails once it elapses.",
+			},
 		},
 	}
 
 }
 
+// provisionModule returns the module of a name, which older configurations
+// give as a full path such as /Common/asm.
+func provisionModule(name string) string {
+	_, module := parseF5Identifier(name)
+	return module
+}
+
+func validateProvisionModule(value interface{}, field string) ([]string, []error) {
+	return validateStringValue(provisionModules)(provisionModule(value.(string)), field)
+}
+
 func resourceBigipSysProvisionCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
-
-	name := d.Get("name").(string)
-	FullPath := d.Get("full_path").(string)
-	cpuRatio := d.Get("cpu_ratio").(int)
-	diskRatio := d.Get("disk_ratio").(int)
-	level := d.Get("level").(string)
-	memoryRatio := d.Get("memory_ratio").(int)
-
-	log.Println("[INFO] Provisioning  ")
-
-	err := client.CreateProvision(
-		name,
-		FullPath,
-		cpuRatio,
-		diskRatio,
-		level,
-		memoryRatio,
-	)
+	name := provisionModule(d.Get("name").(string))
+	log.Println("[INFO] Provisioning " + name)
 
-	if err != nil {
-		log.Printf("[ERROR] Unable to Create Provision  (%s) ", err)
-		return err
-	}
+	// Modules always exist, provisioning one sets its level
 	d.SetId(name)
-	return resourceBigipSysProvisionRead(d, meta)
+	return resourceBigipSysProvisionUpdate(d, meta)
 }
 
 func resourceBigipSysProvisionUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -97,7 +122,6 @@ func resourceBigipSysProvisionUpdate(d *schema.ResourceData, meta interface{}) e
 
 	r := &bigip.Provision{
 		Name:        name,
-		FullPath:    d.Get("full_path").(string),
 		CpuRatio:    d.Get("cpu_ratio").(int),
 		DiskRatio:   d.Get("disk_ratio").(int),
 		Level:       d.Get("level").(string),
@@ -106,20 +130,28 @@ func resourceBigipSysProvisionUpdate(d *schema.ResourceData, meta interface{}) e
 
 	err := client.ModifyProvision(r)
 	if err != nil {
-		log.Printf("[ERROR] Unable to Retrieve Provision (%v) ", err)
-		return err
+		if d.IsNewResource() {
+			d.SetId("")
+		}
+		return fmt.Errorf("Error provisioning %s: %v", name, apiErrorDetails(err))
+	}
+
+	if d.Get("wait_for_ready").(bool) && (d.IsNewResource() || d.HasChange("level")) {
+		if err := waitForSysReady(client, time.Duration(d.Get("wait_timeout").(int))*time.Second); err != nil {
+			return fmt.Errorf("Error provisioning %s: %v", name, err)
+		}
 	}
 	return resourceBigipSysProvisionRead(d, meta)
 }
 
 func resourceBigipSysProvisionRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
 	log.Println("[INFO] Reading Provisions " + name)
 
-	p, err := client.Provisions(name)
+	p, err := client.GetProvision(name)
 	if err != nil {
 		log.Printf("[ERROR] Unable to Retrieve Provision (%s) (%v) ", name, err)
 		return err
@@ -129,6 +161,7 @@ func resourceBigipSysProvisionRead(d *schema.ResourceData, meta interface{}) err
 		d.SetId("")
 		return nil
 	}
+	d.Set("name", name)
 	if err := d.Set("full_path", p.FullPath); err != nil {
 		return fmt.Errorf("[DEBUG] Error saving FullPath to state for Provision  (%s): %s", d.Id(), err)
 	}
@@ -141,6 +174,41 @@ func resourceBigipSysProvisionRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceBigipSysProvisionDelete(d *schema.ResourceData, meta interface{}) error {
-	//API is not supported for Deleting
+	// Modules cannot be deleted, destroying the resource leaves the module
+	// provisioned rather than deprovisioning it behind the back of the user
+	log.Println("[INFO] Removing provisioning of " + d.Id() + " from state, the module stays provisioned")
+	d.SetId("")
 	return nil
 }
+
+// waitForSysReady waits until the BigIP reports its configuration, license
+// and provisioning ready, or returns an error once the timeout elapses. The
+// BigIP does not answer or fails requests while it restarts services or
+// reboots, these errors are waited out.
+func waitForSysReady(client *bigipClient, timeout time.Duration) error {
+	log.Println("[INFO] Waiting for the BigIP to be ready")
+	start := waitClock.Now()
+	waitClock.Sleep(provisionSettleTime)
+
+	var lastErr error
+	err := waitForCondition(5*time.Second, timeout-provisionSettleTime, func() (bool, error) {
+		ready, err := client.GetSysReady()
+		if err != nil {
+			log.Printf("[DEBUG] BigIP not ready yet: %v", err)
+			lastErr = err
+			return false, nil
+		}
+		lastErr = nil
+		return ready.Ready(), nil
+	})
+	if err == errWaitTimeout {
+		if lastErr != nil {
+			return fmt.Errorf("BigIP was not ready within %s, last error: %v", timeout, lastErr)
+		}
+		return fmt.Errorf("BigIP was not ready within %s", timeout)
+	}
+	if err == nil {
+		log.Printf("[INFO] BigIP ready after %s", waitClock.Now().Sub(start))
+	}
+	return err
+}
@@ -2,7 +2,6 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/terraform"
 	"testing"
@@ -66,7 +65,7 @@ func TestAccBigipSysProvision_import(t *testing.T) {
 
 func testCheckprovisionExists(name string, exists bool) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
-		client := testAccProvider.Meta().(*bigip.BigIP)
+		client := testAccProvider.Meta().(*bigipClient)
 
 		provision, err := client.Provisions(name)
 		if err != nil {
@@ -0,0 +1,141 @@
+package bigip
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/stretchr/testify/assert"
+)
+
+// testBigipSysProvisionServer serves the provisioning of ltm and asm, the
+// readiness endpoint answers the given responses in turn, the last one from
+// then on.
+func testBigipSysProvisionServer(puts *[]map[string]interface{}, readiness ...string) {
+	provisions := map[string]map[string]interface{}{
+		"ltm": {"name": "ltm", "fullPath": "ltm", "level": "nominal"},
+		"asm": {"name": "asm", "fullPath": "asm", "level": "none"},
+	}
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/sys/provision/", func(w http.ResponseWriter, r *http.Request) {
+		module := r.URL.Path[len("/mgmt/tm/sys/provision/"):]
+		provision, ok := provisions[module]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprintf(w, `{"code":404,"message":"01020036:3: The requested provision (%s) was not found."}`, module)
+			return
+		}
+		if r.Method == "PUT" {
+			body := map[string]interface{}{}
+			json.NewDecoder(r.Body).Decode(&body)
+			*puts = append(*puts, body)
+			for k, v := range body {
+				provision[k] = v
+			}
+		}
+		json.NewEncoder(w).Encode(provision)
+	})
+	checks := 0
+	mux.HandleFunc("/mgmt/tm/sys/ready", func(w http.ResponseWriter, r *http.Request) {
+		response := readiness[len(readiness)-1]
+		if checks < len(readiness) {
+			response = readiness[checks]
+		}
+		checks++
+		if response == "down" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			fmt.Fprintf(w, `{"code":503,"message":"Service Unavailable"}`)
+			return
+		}
+		fmt.Fprintf(w, `{"entries":{"https://localhost/mgmt/tm/sys/ready/0":{"nestedStats":{"entries":{
+			"configReady":{"description":"yes"},
+			"licenseReady":{"description":"yes"},
+			"provisionReady":{"description":"%s"}}}}}}`, response)
+	})
+}
+
+func testBigipSysProvisionConfig(url, name, level string) string {
+	return fmt.Sprintf(`
+		resource "bigip_sys_provision" "test-provision" {
+			name = "%s"
+			level = "%s"
+			wait_for_ready = true
+			wait_timeout = 60
+		}
+		provider "bigip" {
+			address = "%s"
+			username = "admin"
+			password = "admin"
+			max_retries = 0
+		}
+	`, name, level, url)
+}
+
+func TestAccBigipSysProvisionWaitsForReady(t *testing.T) {
+	setup()
+	var puts []map[string]interface{}
+	testBigipSysProvisionServer(&puts, "down", "no", "yes")
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config: testBigipSysProvisionConfig(server.URL, "asm", "nominal"),
+					Check: resource.ComposeTestCheckFunc(
+						resource.TestCheckResourceAttr("bigip_sys_provision.test-provision", "id", "asm"),
+						resource.TestCheckResourceAttr("bigip_sys_provision.test-provision", "level", "nominal"),
+						resource.TestCheckResourceAttr("bigip_sys_provision.test-provision", "full_path", "asm"),
+					),
+				},
+				{
+					// A full path as in older configurations is the same module
+					Config:   testBigipSysProvisionConfig(server.URL, "/Common/asm", "nominal"),
+					PlanOnly: true,
+				},
+			},
+		})
+		assert.Equal(t, []map[string]interface{}{{"name": "asm", "level": "nominal"}}, puts)
+		// Settle time, then the service unavailable and not ready checks
+		assert.Equal(t, []time.Duration{provisionSettleTime, 5 * time.Second, 10 * time.Second}, c.sleeps)
+	})
+}
+
+func TestAccBigipSysProvisionNotReady(t *testing.T) {
+	setup()
+	var puts []map[string]interface{}
+	testBigipSysProvisionServer(&puts, "down")
+	defer teardown()
+	withFakeClock(t, func(c *fakeClock) {
+		resource.Test(t, resource.TestCase{
+			IsUnitTest: true,
+			Providers:  testProviders,
+			Steps: []resource.TestStep{
+				{
+					Config:      testBigipSysProvisionConfig(server.URL, "ltm", "dedicated"),
+					ExpectError: regexp.MustCompile(`BigIP was not ready within 1m0s, last error: .*Service Unavailable`),
+				},
+			},
+		})
+	})
+}
+
+func TestAccBigipSysProvisionUnknownModule(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		IsUnitTest: true,
+		Providers:  testProviders,
+		Steps: []resource.TestStep{
+			{
+				Config:      testBigipSysProvisionConfig("https://127.0.0.1", "foo", "nominal"),
+				ExpectError: regexp.MustCompile(`"name" must be one of \[afm am apm asm`),
+			},
+		},
+	})
+}
@@ -2,8 +2,8 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 	"log"
 )
 
@@ -42,7 +42,7 @@ func resourceBigipSysSnmp() *schema.Resource {
 }
 
 func resourceBigipSysSnmpCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	sysContact := d.Get("sys_contact").(string)
 	sysLocation := d.Get("sys_location").(string)
@@ -65,7 +65,7 @@ func resourceBigipSysSnmpCreate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipSysSnmpUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	sysContact := d.Id()
 
@@ -86,7 +86,7 @@ func resourceBigipSysSnmpUpdate(d *schema.ResourceData, meta interface{}) error
 }
 
 func resourceBigipSysSnmpRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	sysContact := d.Id()
 
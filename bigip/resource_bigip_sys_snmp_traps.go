@@ -2,8 +2,8 @@ package bigip
 
 import (
 	"fmt"
-	"github.com/f5devcentral/go-bigip"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
 	"log"
 )
 
@@ -103,7 +103,7 @@ func resourceBigipSysSnmpTraps() *schema.Resource {
 }
 
 func resourceBigipSysSnmpTrapsCreate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Get("name").(string)
 	authPasswordEncrypted := d.Get("auth_passwordencrypted").(string)
@@ -148,7 +148,7 @@ func resourceBigipSysSnmpTrapsCreate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceBigipSysSnmpTrapsUpdate(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 
@@ -179,7 +179,7 @@ func resourceBigipSysSnmpTrapsUpdate(d *schema.ResourceData, meta interface{}) e
 }
 
 func resourceBigipSysSnmpTrapsRead(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	host := d.Id()
 
@@ -225,7 +225,7 @@ func resourceBigipSysSnmpTrapsRead(d *schema.ResourceData, meta interface{}) err
 }
 
 func resourceBigipSysSnmpTrapsDelete(d *schema.ResourceData, meta interface{}) error {
-	client := meta.(*bigip.BigIP)
+	client := meta.(*bigipClient)
 
 	name := d.Id()
 	log.Println("[INFO] Deleting snmp host " + name)
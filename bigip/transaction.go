@@ -0,0 +1,34 @@
+package bigip
+
+import (
+	"fmt"
+	"log"
+)
+
+// withTransaction calls f with a client whose requests are queued in an
+// iControl REST transaction when the provider is configured with
+// transactional, and commits them once f succeeds. Otherwise f is called with
+// the client itself, as in a dry run where no request is sent. f must only
+// create, modify or delete entities, reads are not possible inside a
+// transaction.
+func withTransaction(client *bigipClient, f func(*bigipClient) error) error {
+	if !client.config.Transactional || client.ConfigOptions.DryRun {
+		return f(client)
+	}
+
+	t, err := client.BeginTransaction()
+	if err != nil {
+		return fmt.Errorf("Error starting transaction: %v", err)
+	}
+	log.Printf("[INFO] Started transaction %d", t.TransID)
+
+	if err := f(&bigipClient{client.WithTransaction(t.TransID), client.config}); err != nil {
+		if err := client.DeleteTransaction(t.TransID); err != nil {
+			log.Printf("[WARN] Unable to delete transaction %d: %v", t.TransID, err)
+		}
+		return err
+	}
+
+	log.Printf("[INFO] Committing transaction %d", t.TransID)
+	return client.CommitTransaction(t.TransID)
+}
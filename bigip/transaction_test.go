@@ -0,0 +1,85 @@
+package bigip
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/terraform-providers/terraform-provider-bigip/internal/go-bigip"
+)
+
+func testTransactionClient(t *testing.T, commitState string) (*bigipClient, *[]string) {
+	var calls []string
+	mux.HandleFunc("/mgmt/tm/net/self", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{}`)
+	})
+	mux.HandleFunc("/mgmt/tm/transaction", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" transaction")
+		fmt.Fprintf(w, `{"transId":42,"state":"STARTED"}`)
+	})
+	mux.HandleFunc("/mgmt/tm/transaction/42", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" transaction/42")
+		fmt.Fprintf(w, `{"transId":42,"state":"%s","errorMessage":"01020036:3: The requested monitor was not found."}`, commitState)
+	})
+	mux.HandleFunc("/mgmt/tm/ltm/monitor/http", func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" monitor "+r.Header.Get("X-F5-REST-Coordination-Id"))
+		fmt.Fprintf(w, `{}`)
+	})
+
+	config := testRetryConfig(server.URL)
+	config.Transactional = true
+	client, err := config.Client()
+	assert.Nil(t, err)
+	return client, &calls
+}
+
+func TestWithTransactionCommits(t *testing.T) {
+	setup()
+	defer teardown()
+	client, calls := testTransactionClient(t, "COMPLETED")
+
+	err := withTransaction(client, func(client *bigipClient) error {
+		return client.AddMonitor(&bigip.Monitor{Name: "test-monitor", ParentMonitor: "http"})
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"POST transaction", "POST monitor 42", "PATCH transaction/42"}, *calls)
+	assert.Equal(t, int64(0), client.TransactionID)
+}
+
+func TestWithTransactionDeletesOnError(t *testing.T) {
+	setup()
+	defer teardown()
+	client, calls := testTransactionClient(t, "COMPLETED")
+
+	err := withTransaction(client, func(client *bigipClient) error {
+		return errors.New("failed")
+	})
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, []string{"POST transaction", "DELETE transaction/42"}, *calls)
+}
+
+func TestWithTransactionFailedCommit(t *testing.T) {
+	setup()
+	defer teardown()
+	client, _ := testTransactionClient(t, "FAILED")
+
+	err := withTransaction(client, func(client *bigipClient) error {
+		return client.AddMonitor(&bigip.Monitor{Name: "test-monitor", ParentMonitor: "http"})
+	})
+	assert.EqualError(t, err, "Transaction 42 failed: 01020036:3: The requested monitor was not found.")
+}
+
+func TestWithoutTransaction(t *testing.T) {
+	setup()
+	defer teardown()
+	client, calls := testTransactionClient(t, "COMPLETED")
+	client.config.Transactional = false
+
+	err := withTransaction(client, func(client *bigipClient) error {
+		return client.AddMonitor(&bigip.Monitor{Name: "test-monitor", ParentMonitor: "http"})
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"POST monitor "}, *calls)
+}
@@ -2,8 +2,12 @@ package bigip
 
 import (
 	"fmt"
+	"net"
 	"reflect"
 	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
 
 	"github.com/hashicorp/terraform/helper/schema"
 )
@@ -30,6 +34,15 @@ func validateStringValue(values []string) schema.SchemaValidateFunc {
 	}
 }
 
+func validateIntRange(min, max int) schema.SchemaValidateFunc {
+	return func(value interface{}, field string) (ws []string, errors []error) {
+		if v := value.(int); v < min || v > max {
+			errors = append(errors, fmt.Errorf("%q must be between %d and %d, got %d", field, min, max, v))
+		}
+		return
+	}
+}
+
 func validateF5Name(value interface{}, field string) (ws []string, errors []error) {
 	var values []string
 	switch value.(type) {
@@ -50,10 +63,147 @@ func validateF5Name(value interface{}, field string) (ws []string, errors []erro
 	}
 
 	for _, v := range values {
-		match, _ := regexp.MatchString("^/[\\w_\\-.]+/[\\w_\\-.]+$", v)
-		if !match {
-			errors = append(errors, fmt.Errorf("%q must match /Partition/Name and contain letters, numbers or [._-]. e.g. /Common/my-pool", field))
+		if !strings.HasPrefix(v, "/") {
+			errors = append(errors, fmt.Errorf("%q must be a full path /Partition/Name, e.g. /Common/my-pool, got %q", field, v))
+			continue
+		}
+		if err := checkF5Name(v); err != nil {
+			errors = append(errors, fmt.Errorf("%q %s", field, err))
+		}
+	}
+	return
+}
+
+// validateIPMask checks that the value is a contiguous IPv4 or IPv6 network
+// mask such as 255.255.255.0, or any for no mask.
+func validateIPMask(value interface{}, field string) (ws []string, errors []error) {
+	v := value.(string)
+	if v == "any" {
+		return
+	}
+	ip := net.ParseIP(v)
+	if ip == nil {
+		errors = append(errors, fmt.Errorf("%q must be an IP mask such as 255.255.255.0 or any, got %q", field, v))
+		return
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	if _, bits := net.IPMask(ip).Size(); bits == 0 {
+		errors = append(errors, fmt.Errorf("%q must be a contiguous IP mask such as 255.255.255.0, got %q", field, v))
+	}
+	return
+}
+
+func validateF5NameOrFullPath(value interface{}, field string) (ws []string, errors []error) {
+	name, ok := value.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("Unknown type %v in validateF5NameOrFullPath", reflect.TypeOf(value)))
+		return
+	}
+
+	if err := checkF5Name(name); err != nil {
+		errors = append(errors, fmt.Errorf("%q %s", field, err))
+	}
+	return
+}
+
+// f5NameChars are the characters besides ASCII letters and numbers the
+// BigIP allows in object names. Names of nodes contain : for IPv6 addresses
+// and % for route domains.
+const f5NameChars = "._-:%"
+
+const (
+	// maxF5PartitionLength is the maximum length of a partition name.
+	maxF5PartitionLength = 64
+	// maxF5PathLength is the maximum length of the full path of an object.
+	maxF5PathLength = 255
+)
+
+// checkF5Name checks a name, or a full path /Partition/Name or
+// /Partition/Folder/Name when it starts with /, against the naming rules of
+// the BigIP. The error names the offending part.
+func checkF5Name(name string) error {
+	if len(name) > maxF5PathLength {
+		return fmt.Errorf("must be at most %d characters, %q has %d", maxF5PathLength, name, len(name))
+	}
+	segments := []string{name}
+	if strings.HasPrefix(name, "/") {
+		segments = strings.Split(name[1:], "/")
+		if len(segments) < 2 || len(segments) > 3 {
+			return fmt.Errorf("must be /Partition/Name or /Partition/Folder/Name, got %q", name)
 		}
+		if len(segments[0]) > maxF5PartitionLength {
+			return fmt.Errorf("partition of %q must be at most %d characters", name, maxF5PartitionLength)
+		}
+	}
+	for _, segment := range segments {
+		if segment == "" {
+			return fmt.Errorf("has an empty partition, folder or name in %q", name)
+		}
+		for i, r := range segment {
+			valid := r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)) || strings.ContainsRune(f5NameChars, r)
+			if !valid {
+				return fmt.Errorf("contains the invalid character %q in %q, names may only contain letters, numbers and %s", r, name, f5NameChars)
+			}
+			if i == 0 && strings.ContainsRune(".-%", r) {
+				return fmt.Errorf("must not start a partition, folder or name with %q in %q", r, name)
+			}
+		}
+	}
+	return nil
+}
+
+func validateRateLimit(value interface{}, field string) (ws []string, errors []error) {
+	v, ok := value.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("Unknown type %v in validateRateLimit", reflect.TypeOf(value)))
+		return
+	}
+
+	if v == "disabled" {
+		return
+	}
+	if i, err := strconv.Atoi(v); err != nil || i < 0 {
+		errors = append(errors, fmt.Errorf("%q must be \"disabled\" or a non-negative number of connections per second, got %q", field, v))
+	}
+	return
+}
+
+// selfIPAddressRegex splits a self IP address of the form
+// address[%route_domain]/prefix.
+var selfIPAddressRegex = regexp.MustCompile(`^([^%/]+)(%\d+)?(/\d+)$`)
+
+func validateSelfIPAddress(value interface{}, field string) (ws []string, errors []error) {
+	v, ok := value.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("Unknown type %v in validateSelfIPAddress", reflect.TypeOf(value)))
+		return
+	}
+
+	match := selfIPAddressRegex.FindStringSubmatch(v)
+	if match == nil {
+		errors = append(errors, fmt.Errorf("%q must be an address and netmask in CIDR notation, e.g. 10.1.1.1/24 or 10.1.1.1%%2/24, got %q", field, v))
+		return
+	}
+	if _, _, err := net.ParseCIDR(match[1] + match[3]); err != nil {
+		errors = append(errors, fmt.Errorf("%q must be an address and netmask in CIDR notation, e.g. 10.1.1.1/24 or 10.1.1.1%%2/24, got %q", field, v))
+	}
+	return
+}
+
+func validateFQDNInterval(value interface{}, field string) (ws []string, errors []error) {
+	v, ok := value.(string)
+	if !ok {
+		errors = append(errors, fmt.Errorf("Unknown type %v in validateFQDNInterval", reflect.TypeOf(value)))
+		return
+	}
+
+	if v == "ttl" {
+		return
+	}
+	if i, err := strconv.Atoi(v); err != nil || i <= 0 {
+		errors = append(errors, fmt.Errorf("%q must be \"ttl\" or a positive number of seconds, got %q", field, v))
 	}
 	return
 }
@@ -1,6 +1,7 @@
 package bigip
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform/helper/schema"
@@ -18,6 +19,24 @@ func TestInvalidStringValue(t *testing.T) {
 	assert.Equal(t, "\"field\" must be one of [a b c]", errors[0].Error())
 }
 
+func TestIntRange(t *testing.T) {
+	//test value => expected error count
+	data := map[int]int{
+		1:     0,
+		100:   0,
+		65535: 0,
+		0:     1,
+		-1:    1,
+		65536: 1,
+	}
+	for d, ec := range data {
+		_, errs := validateIntRange(1, 65535)(d, "testField")
+		assert.Equal(t, ec, len(errs), "%d did not throw %d errors", d, ec)
+	}
+	_, errs := validateIntRange(1, 65535)(0, "ratio")
+	assert.EqualError(t, errs[0], `"ratio" must be between 1 and 65535, got 0`)
+}
+
 func TestF5NameString(t *testing.T) {
 	//test string => expected error count
 	data := map[string]int{
@@ -35,6 +54,104 @@ func TestF5NameString(t *testing.T) {
 	}
 }
 
+func TestCheckF5Name(t *testing.T) {
+	valid := []string{
+		"/Common/my-pool",
+		"/Common/www.example.com",
+		"/Common/10.0.0.5",
+		"/Common/10.0.0.5%2",
+		"/Common/2001:db8::5",
+		"/Common/_internal",
+		"/Common/app.app/app_pool",
+		"/Tenant_1/pool.v2-blue",
+		"my-pool",
+		"10.0.0.5",
+		"/" + strings.Repeat("p", 64) + "/pool",
+		"/Common/" + strings.Repeat("n", 247),
+	}
+	for _, name := range valid {
+		assert.NoError(t, checkF5Name(name), name)
+	}
+
+	//test name => expected error
+	invalid := map[string]string{
+		"/Common/my pool":                       `contains the invalid character ' ' in "/Common/my pool", names may only contain letters, numbers and ._-:%`,
+		"/Common/pool@dc1":                      `contains the invalid character '@' in "/Common/pool@dc1", names may only contain letters, numbers and ._-:%`,
+		"/Common/poöl":                          `contains the invalid character 'ö' in "/Common/poöl", names may only contain letters, numbers and ._-:%`,
+		"my*pool":                               `contains the invalid character '*' in "my*pool", names may only contain letters, numbers and ._-:%`,
+		"/Common/-pool":                         `must not start a partition, folder or name with '-' in "/Common/-pool"`,
+		"/.hidden/pool":                         `must not start a partition, folder or name with '.' in "/.hidden/pool"`,
+		"/Common/":                              `has an empty partition, folder or name in "/Common/"`,
+		"//pool":                                `has an empty partition, folder or name in "//pool"`,
+		"/Common":                               `must be /Partition/Name or /Partition/Folder/Name, got "/Common"`,
+		"/a/b/c/d":                              `must be /Partition/Name or /Partition/Folder/Name, got "/a/b/c/d"`,
+		"/" + strings.Repeat("p", 65) + "/pool": `partition of "/` + strings.Repeat("p", 65) + `/pool" must be at most 64 characters`,
+		"/Common/" + strings.Repeat("n", 248):   `must be at most 255 characters, "/Common/` + strings.Repeat("n", 248) + `" has 256`,
+	}
+	for name, expected := range invalid {
+		assert.EqualError(t, checkF5Name(name), expected, name)
+	}
+
+	_, errs := validateF5Name("my-pool", "pool")
+	assert.EqualError(t, errs[0], `"pool" must be a full path /Partition/Name, e.g. /Common/my-pool, got "my-pool"`)
+	_, errs = validateF5NameOrFullPath("/Common/my pool", "name")
+	assert.EqualError(t, errs[0], `"name" contains the invalid character ' ' in "/Common/my pool", names may only contain letters, numbers and ._-:%`)
+}
+
+func TestF5NameOrFullPath(t *testing.T) {
+	//test string => expected error count
+	data := map[string]int{
+		"/Common/foo":       0,
+		"/Tenant1/10.0.0.5": 0,
+		"foo":               0,
+		"10.0.0.5":          0,
+		"Common/foo":        1,
+		"/Common/foo/":      1,
+		"//":                1,
+		"/":                 1,
+	}
+	for d, ec := range data {
+		_, errs := validateF5NameOrFullPath(d, "testField")
+		assert.Equal(t, ec, len(errs), "%s did not throw %d errors", d, ec)
+	}
+}
+
+func TestRateLimit(t *testing.T) {
+	//test string => expected error count
+	data := map[string]int{
+		"disabled": 0,
+		"0":        0,
+		"1000":     0,
+		"diabled":  1,
+		"Disabled": 1,
+		"-1":       1,
+		"1.5":      1,
+		"":         1,
+	}
+	for d, ec := range data {
+		_, errs := validateRateLimit(d, "testField")
+		assert.Equal(t, ec, len(errs), "%s did not throw %d errors", d, ec)
+	}
+}
+
+func TestFQDNInterval(t *testing.T) {
+	//test string => expected error count
+	data := map[string]int{
+		"ttl":  0,
+		"1":    0,
+		"3600": 0,
+		"TTL":  1,
+		"0":    1,
+		"-5":   1,
+		"1h":   1,
+		"":     1,
+	}
+	for d, ec := range data {
+		_, errs := validateFQDNInterval(d, "testField")
+		assert.Equal(t, ec, len(errs), "%s did not throw %d errors", d, ec)
+	}
+}
+
 func TestF5NameSet(t *testing.T) {
 	//test string => expected error count
 	data := map[*schema.Set]int{
@@ -96,3 +213,22 @@ func TestValidateReqPrefDisabledString(t *testing.T) {
 		assert.Equal(t, ec, len(errs), "%s did not throw %d errors", d, ec)
 	}
 }
+
+func TestValidateIPMask(t *testing.T) {
+	//test string => expected error count
+	data := map[string]int{
+		"255.255.255.255": 0,
+		"255.255.255.0":   0,
+		"0.0.0.0":         0,
+		"any":             0,
+		"ffff:ffff::":     0,
+		"255.0.255.0":     1,
+		"10.0.0.1":        1,
+		"/24":             1,
+		"":                1,
+	}
+	for d, ec := range data {
+		_, errs := validateIPMask(d, "testField")
+		assert.Equal(t, ec, len(errs), "%s did not throw %d errors", d, ec)
+	}
+}
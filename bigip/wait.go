@@ -0,0 +1,77 @@
+package bigip
+
+import (
+	"errors"
+	"time"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+// maxWaitInterval caps the interval between two checks of waitForCondition.
+const maxWaitInterval = 30 * time.Second
+
+// errWaitTimeout is returned by waitForCondition when the condition is not met
+// before the timeout elapses.
+var errWaitTimeout = errors.New("timeout while waiting for condition")
+
+// clock tells the time and sleeps, waitClock is replaced by a fake clock in
+// tests.
+type clock interface {
+	Now() time.Time
+	Sleep(time.Duration)
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time        { return time.Now() }
+func (realClock) Sleep(d time.Duration) { time.Sleep(d) }
+
+var waitClock clock = realClock{}
+
+// waitForCondition calls check until it returns true, returns an error or the
+// timeout elapses, in which case errWaitTimeout is returned. The interval
+// between two checks starts at interval and doubles after each check, up to
+// maxWaitInterval. check is called a last time when the timeout elapses.
+func waitForCondition(interval, timeout time.Duration, check func() (bool, error)) error {
+	deadline := waitClock.Now().Add(timeout)
+	for {
+		done, err := check()
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+
+		remaining := deadline.Sub(waitClock.Now())
+		if remaining <= 0 {
+			return errWaitTimeout
+		}
+		if interval > remaining {
+			interval = remaining
+		}
+		waitClock.Sleep(interval)
+
+		interval *= 2
+		if interval > maxWaitInterval {
+			interval = maxWaitInterval
+		}
+	}
+}
+
+// clientForOperation returns a copy of client whose requests, and their
+// retries, end by the deadline of an operation of the resource d, set by its
+// timeouts block for key, e.g. schema.TimeoutCreate. The deadline is returned
+// too, to bound the waits of the operation with timeoutBefore.
+func clientForOperation(client *bigipClient, d *schema.ResourceData, key string) (*bigipClient, time.Time) {
+	deadline := time.Now().Add(d.Timeout(key))
+	return &bigipClient{client.WithDeadline(deadline), client.config}, deadline
+}
+
+// timeoutBefore shortens timeout so that a wait ends by the deadline.
+func timeoutBefore(timeout time.Duration, deadline time.Time) time.Duration {
+	if remaining := time.Until(deadline); remaining < timeout {
+		return remaining
+	}
+	return timeout
+}
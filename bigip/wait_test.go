@@ -0,0 +1,89 @@
+package bigip
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock advances only when slept on and records the sleeps.
+type fakeClock struct {
+	now    time.Time
+	sleeps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Sleep(d time.Duration) {
+	c.sleeps = append(c.sleeps, d)
+	c.now = c.now.Add(d)
+}
+
+func withFakeClock(t *testing.T, f func(c *fakeClock)) {
+	c := &fakeClock{now: time.Unix(0, 0)}
+	waitClock = c
+	defer func() { waitClock = realClock{} }()
+	f(c)
+}
+
+func TestWaitForConditionBackoff(t *testing.T) {
+	withFakeClock(t, func(c *fakeClock) {
+		checks := 0
+		err := waitForCondition(time.Second, time.Hour, func() (bool, error) {
+			checks++
+			return checks == 8, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 8, checks)
+		assert.Equal(t, []time.Duration{
+			1 * time.Second,
+			2 * time.Second,
+			4 * time.Second,
+			8 * time.Second,
+			16 * time.Second,
+			30 * time.Second,
+			30 * time.Second,
+		}, c.sleeps)
+	})
+}
+
+func TestWaitForConditionImmediate(t *testing.T) {
+	withFakeClock(t, func(c *fakeClock) {
+		err := waitForCondition(time.Second, time.Minute, func() (bool, error) {
+			return true, nil
+		})
+		assert.Nil(t, err)
+		assert.Empty(t, c.sleeps)
+	})
+}
+
+func TestWaitForConditionTimeout(t *testing.T) {
+	withFakeClock(t, func(c *fakeClock) {
+		checks := 0
+		err := waitForCondition(time.Second, 10*time.Second, func() (bool, error) {
+			checks++
+			return false, nil
+		})
+		assert.Equal(t, errWaitTimeout, err)
+		// The last sleep is cut short to check once more at the timeout
+		assert.Equal(t, []time.Duration{1 * time.Second, 2 * time.Second, 4 * time.Second, 3 * time.Second}, c.sleeps)
+		assert.Equal(t, 5, checks)
+	})
+}
+
+func TestWaitForConditionError(t *testing.T) {
+	withFakeClock(t, func(c *fakeClock) {
+		checks := 0
+		err := waitForCondition(time.Second, time.Minute, func() (bool, error) {
+			checks++
+			if checks == 2 {
+				return false, errors.New("check failed")
+			}
+			return false, nil
+		})
+		assert.EqualError(t, err, "check failed")
+		assert.Equal(t, []time.Duration{time.Second}, c.sleeps)
+	})
+}
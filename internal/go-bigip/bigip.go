@@ -0,0 +1,781 @@
+// Package bigip interacts with F5 BIG-IP systems using the REST API.
+package bigip
+
+import (
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var defaultConfigOptions = &ConfigOptions{
+	APICallTimeout: 60 * time.Second,
+}
+
+type ConfigOptions struct {
+	APICallTimeout time.Duration
+	// MaxRetries is the number of times a request failing with a transient
+	// error (HTTP 5xx or a configuration operation in progress) is retried.
+	MaxRetries int
+	// RetryBackoff is the delay before the first retry, doubled on every
+	// subsequent retry.
+	RetryBackoff time.Duration
+	// VerifyTLS enables verification of the BIG-IP certificate, which is
+	// skipped by default.
+	VerifyTLS bool
+	// MaxIdleConnsPerHost is the number of idle connections kept open for
+	// reuse, zero uses the net/http default of 2.
+	MaxIdleConnsPerHost int
+	// DisableKeepAlives opens a new connection for every request.
+	DisableKeepAlives bool
+	// Port is the port of the management interface, used when the host
+	// does not include one. Zero uses the default port of the scheme.
+	Port int
+	// DryRun logs create, modify and delete requests instead of sending
+	// them, they succeed with an empty response. Reads are sent, except for
+	// objects created in the dry run, which are read as they were planned.
+	// Requests whose response is used, such as running a command, are
+	// refused.
+	DryRun bool
+}
+
+// transientErrors are messages returned by the BIG-IP while it is busy, the
+// request can be retried once the running operation completes.
+var transientErrors = []string{
+	"configuration operation in progress",
+	"Configuration Utility restarting",
+	"mcpd is not running",
+}
+
+// BigIP is a container for our session state.
+type BigIP struct {
+	Host          string
+	User          string
+	Password      string
+	Token         string // if set, will be used instead of User/Password
+	Transport     *http.Transport
+	ConfigOptions *ConfigOptions
+	// LoginProviderName is set for sessions created with NewTokenSession,
+	// it is used to renew an expired token.
+	LoginProviderName string
+	// TransactionID is set on clients returned by WithTransaction, their
+	// requests are queued in the transaction instead of being applied.
+	TransactionID int64
+	// Deadline is set on clients returned by WithDeadline, their requests
+	// are not sent or retried past it.
+	Deadline time.Time
+	// dryRunCreated holds the objects created by a dry run, it is shared by
+	// the copies of the client.
+	dryRunCreated *dryRunObjects
+	// sessionToken holds the token acquired by NewTokenSession and renewed
+	// when it expires, it is shared by the copies of the client.
+	sessionToken *authToken
+}
+
+// authToken is an authentication token used and renewed by concurrent
+// requests.
+type authToken struct {
+	sync.Mutex
+	token string
+}
+
+// dryRunObjects holds the objects created by a dry run by their URL, with
+// the attributes they were created and modified with.
+type dryRunObjects struct {
+	sync.Mutex
+	objects map[string]map[string]interface{}
+}
+
+// Transaction is an iControl REST transaction, the requests added to it are
+// applied together when it is committed.
+type Transaction struct {
+	TransID      int64  `json:"transId"`
+	State        string `json:"state,omitempty"`
+	ErrorMessage string `json:"errorMessage,omitempty"`
+}
+
+// APIRequest builds our request before sending it to the server.
+type APIRequest struct {
+	Method      string
+	URL         string
+	Body        string
+	ContentType string
+	// ContentRange is the range of a file sent by an upload request.
+	ContentRange string
+	// ReadsResponse is set on requests other than GET whose response is
+	// used, e.g. to run a command. A dry run can not fake it and refuses them.
+	ReadsResponse bool
+	// NoRetry is set on requests that must not be sent twice, e.g. to run a
+	// command, as a server error can be reported after the command ran.
+	NoRetry bool
+}
+
+// RequestError contains information about any error we get from a request.
+type RequestError struct {
+	Code       int      `json:"code,omitempty"`
+	Message    string   `json:"message,omitempty"`
+	ErrorStack []string `json:"errorStack,omitempty"`
+}
+
+// Error returns the error message.
+func (r *RequestError) Error() error {
+	if r.Message != "" {
+		return errors.New(r.Message)
+	}
+
+	return nil
+}
+
+// APIError is returned for requests rejected by the BIG-IP. Besides the
+// message it keeps the status code and the raw response body, which often
+// explain why a request failed.
+type APIError struct {
+	StatusCode int
+	RequestError
+	Body string
+}
+
+// Error returns the message of the BIG-IP, or the response body when there
+// is no message.
+func (e *APIError) Error() string {
+	if e.Message != "" {
+		return e.Message
+	}
+	return fmt.Sprintf("HTTP %d :: %s", e.StatusCode, e.Body)
+}
+
+// IsPermissionDenied reports whether err is the BIG-IP refusing a request
+// because the user may not access the object, e.g. one in a partition the
+// user has no role for. Some versions send a 404 code in the body of such
+// answers, so only the HTTP status tells them apart from missing objects.
+func IsPermissionDenied(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && (apiErr.StatusCode == http.StatusUnauthorized || apiErr.StatusCode == http.StatusForbidden)
+}
+
+func newAPIError(status int, body []byte) *APIError {
+	e := &APIError{StatusCode: status, Body: string(body)}
+	json.Unmarshal(body, &e.RequestError)
+	return e
+}
+
+// NewSession sets up our connection to the BIG-IP system.
+func NewSession(host, user, passwd string, configOptions *ConfigOptions) *BigIP {
+	var baseURL string
+	if !strings.HasPrefix(host, "http") {
+		baseURL = fmt.Sprintf("https://%s", host)
+	} else {
+		baseURL = host
+	}
+	if configOptions == nil {
+		configOptions = defaultConfigOptions
+	}
+	return &BigIP{
+		Host:     withPort(baseURL, configOptions.Port),
+		User:     user,
+		Password: passwd,
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				InsecureSkipVerify: !configOptions.VerifyTLS,
+			},
+			MaxIdleConnsPerHost: configOptions.MaxIdleConnsPerHost,
+			DisableKeepAlives:   configOptions.DisableKeepAlives,
+		},
+		ConfigOptions: configOptions,
+		dryRunCreated: &dryRunObjects{objects: map[string]map[string]interface{}{}},
+		sessionToken:  &authToken{},
+	}
+}
+
+// withPort adds port to baseURL unless it is zero or baseURL already
+// includes a port.
+func withPort(baseURL string, port int) string {
+	if port == 0 {
+		return baseURL
+	}
+	u, err := url.Parse(baseURL)
+	if err != nil || u.Port() != "" {
+		return baseURL
+	}
+	u.Host = net.JoinHostPort(u.Hostname(), strconv.Itoa(port))
+	return u.String()
+}
+
+// NewTokenSession sets up our connection to the BIG-IP system, and
+// instructs the session to use token authentication instead of Basic
+// Auth. This is required when using an external authentication
+// provider, such as Radius or Active Directory. loginProviderName is
+// probably "tmos" but your environment may vary.
+func NewTokenSession(host, user, passwd, loginProviderName string, configOptions *ConfigOptions) (b *BigIP, err error) {
+	b = NewSession(host, user, passwd, configOptions)
+	b.LoginProviderName = loginProviderName
+	err = b.refreshToken("")
+	return
+}
+
+// AuthToken returns the token requests are authenticated with, the one
+// acquired by NewTokenSession or else Token.
+func (b *BigIP) AuthToken() string {
+	if b.sessionToken != nil {
+		b.sessionToken.Lock()
+		defer b.sessionToken.Unlock()
+		if b.sessionToken.token != "" {
+			return b.sessionToken.token
+		}
+	}
+	return b.Token
+}
+
+// refreshToken requests a new authentication token for a session created
+// with NewTokenSession and stores it in the session, replacing the expired
+// token. Requests that find their token already replaced by a concurrent
+// request use the new one instead of requesting another.
+func (b *BigIP) refreshToken(expired string) error {
+	type authReq struct {
+		Username          string `json:"username"`
+		Password          string `json:"password"`
+		LoginProviderName string `json:"loginProviderName"`
+	}
+	type authResp struct {
+		Token struct {
+			Token string
+		}
+	}
+
+	marshalJSON, err := json.Marshal(authReq{
+		b.User,
+		b.Password,
+		b.LoginProviderName,
+	})
+	if err != nil {
+		return err
+	}
+
+	req := &APIRequest{
+		Method:      "post",
+		URL:         "mgmt/shared/authn/login",
+		Body:        string(marshalJSON),
+		ContentType: "application/json",
+	}
+
+	if b.sessionToken == nil {
+		b.sessionToken = &authToken{}
+	}
+	b.sessionToken.Lock()
+	defer b.sessionToken.Unlock()
+	if b.sessionToken.token != "" && b.sessionToken.token != expired {
+		return nil
+	}
+
+	// The login request is authenticated with the user and password
+	login := *b
+	login.Token = ""
+	login.sessionToken = nil
+	resp, err := login.apiCallWithRetries(req)
+	if err != nil {
+		return err
+	}
+
+	if resp == nil {
+		return fmt.Errorf("unable to acquire authentication token")
+	}
+
+	var aresp authResp
+	err = json.Unmarshal(resp, &aresp)
+	if err != nil {
+		return err
+	}
+
+	if aresp.Token.Token == "" {
+		return fmt.Errorf("unable to acquire authentication token")
+	}
+
+	b.sessionToken.token = aresp.Token.Token
+	return nil
+}
+
+// APICall is used to query the BIG-IP web API. Requests failing with a
+// transient error are retried with an exponential backoff, up to
+// ConfigOptions.MaxRetries times.
+func (b *BigIP) APICall(options *APIRequest) ([]byte, error) {
+	if b.ConfigOptions.DryRun && options.ReadsResponse {
+		return nil, fmt.Errorf("Dry run, not sent: %s %s returns a response a dry run can not fake", strings.ToUpper(options.Method), b.requestURL(options))
+	}
+	if b.ConfigOptions.DryRun && options.Method != "get" {
+		b.logDryRun(options)
+		return []byte("{}"), nil
+	}
+	if b.ConfigOptions.DryRun {
+		if data, ok := b.createdInDryRun(options); ok {
+			return data, nil
+		}
+	}
+	token := b.AuthToken()
+	data, err := b.apiCallWithRetries(options)
+	if err != nil && token != "" && b.LoginProviderName != "" && isTokenExpired(data) {
+		log.Printf("[WARN] Authentication token expired, requesting a new one")
+		if err := b.refreshToken(token); err != nil {
+			return nil, err
+		}
+		return b.apiCallWithRetries(options)
+	}
+	return data, err
+}
+
+// logDryRun logs a request not sent in a dry run, uploaded files are logged
+// by their size. Objects created, modified or deleted by the request are
+// recorded, so that they are read as planned.
+func (b *BigIP) logDryRun(options *APIRequest) {
+	body := options.Body
+	if options.ContentType == "application/octet-stream" {
+		body = fmt.Sprintf("<%d bytes of %s>", len(options.Body), options.ContentRange)
+	}
+	log.Printf("[INFO] Dry run, not sent: %s %s %s", strings.ToUpper(options.Method), b.requestURL(options), body)
+	if b.dryRunCreated == nil || options.ContentType == "application/octet-stream" {
+		return
+	}
+	path := strings.SplitN(b.requestURL(options), "?", 2)[0]
+	var attributes map[string]interface{}
+	json.Unmarshal([]byte(options.Body), &attributes)
+
+	b.dryRunCreated.Lock()
+	defer b.dryRunCreated.Unlock()
+	switch options.Method {
+	case "post":
+		for _, url := range dryRunObjectURLs(path, attributes) {
+			b.dryRunCreated.objects[url] = attributes
+		}
+	case "put", "patch":
+		if object, ok := b.dryRunCreated.objects[path]; ok {
+			for k, v := range attributes {
+				object[k] = v
+			}
+		}
+	case "delete":
+		delete(b.dryRunCreated.objects, path)
+	}
+}
+
+// dryRunObjectURLs returns the URLs of the object created by posting
+// attributes to the collection URL collection. Objects are named by their
+// full path, objects posted without a partition are also found in /Common.
+// The full path is added to the attributes when they lack it.
+func dryRunObjectURLs(collection string, attributes map[string]interface{}) []string {
+	name, _ := attributes["name"].(string)
+	if name == "" {
+		return nil
+	}
+	fullPath, _ := attributes["fullPath"].(string)
+	if fullPath == "" {
+		fullPath = name
+		if partition, _ := attributes["partition"].(string); partition != "" && !strings.HasPrefix(name, "/") {
+			fullPath = fmt.Sprintf("/%s/%s", partition, name)
+		}
+		attributes["fullPath"] = fullPath
+	}
+	urls := []string{collection + "/" + strings.Replace(fullPath, "/", "~", -1)}
+	if !strings.HasPrefix(fullPath, "/") {
+		urls = append(urls, collection+"/~Common~"+fullPath)
+	}
+	return urls
+}
+
+// createdInDryRun returns the attributes of an object read by a request,
+// when the object was created by the dry run and does not exist on the
+// BIG-IP.
+func (b *BigIP) createdInDryRun(options *APIRequest) ([]byte, bool) {
+	if b.dryRunCreated == nil {
+		return nil, false
+	}
+	path := strings.SplitN(b.requestURL(options), "?", 2)[0]
+	b.dryRunCreated.Lock()
+	defer b.dryRunCreated.Unlock()
+	object, ok := b.dryRunCreated.objects[path]
+	if !ok {
+		return nil, false
+	}
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, false
+	}
+	log.Printf("[INFO] Dry run, not sent: GET %s, the object was created in the dry run and is read as planned", path)
+	return data, true
+}
+
+// isTokenExpired reports whether a request was rejected because of an
+// expired or invalidated authentication token.
+func isTokenExpired(body []byte) bool {
+	var reqError RequestError
+	if json.Unmarshal(body, &reqError) != nil {
+		return false
+	}
+	return reqError.Code == 401
+}
+
+func (b *BigIP) apiCallWithRetries(options *APIRequest) ([]byte, error) {
+	backoff := b.ConfigOptions.RetryBackoff
+	for attempt := 0; ; attempt++ {
+		data, status, err := b.apiCall(options)
+		if err == nil || options.NoRetry || attempt >= b.ConfigOptions.MaxRetries || !isTransientError(status, data) {
+			return data, err
+		}
+		if !b.Deadline.IsZero() && time.Now().Add(backoff).After(b.Deadline) {
+			log.Printf("[WARN] Transient error on %s %s, not retrying past the deadline: %v", strings.ToUpper(options.Method), options.URL, err)
+			return data, err
+		}
+		log.Printf("[WARN] Transient error on %s %s (attempt %d of %d), retrying in %s: %v", strings.ToUpper(options.Method), options.URL, attempt+1, b.ConfigOptions.MaxRetries, backoff, err)
+		time.Sleep(backoff)
+		backoff *= 2
+	}
+}
+
+// isTransientError reports whether a failed request can be retried.
+// Server side errors are transient, client errors (4xx) are not unless the
+// BIG-IP reports it is busy.
+func isTransientError(status int, body []byte) bool {
+	if status >= 500 {
+		return true
+	}
+	for _, msg := range transientErrors {
+		if bytes.Contains(body, []byte(msg)) {
+			return true
+		}
+	}
+	return false
+}
+
+func (b *BigIP) apiCall(options *APIRequest) ([]byte, int, error) {
+	var req *http.Request
+	client := &http.Client{
+		Transport: b.Transport,
+		Timeout:   b.ConfigOptions.APICallTimeout,
+	}
+	if !b.Deadline.IsZero() {
+		remaining := time.Until(b.Deadline)
+		if remaining <= 0 {
+			return nil, 0, fmt.Errorf("deadline exceeded before %s %s", strings.ToUpper(options.Method), options.URL)
+		}
+		if client.Timeout == 0 || remaining < client.Timeout {
+			client.Timeout = remaining
+		}
+	}
+	url := b.requestURL(options)
+	body := bytes.NewReader([]byte(options.Body))
+	req, _ = http.NewRequest(strings.ToUpper(options.Method), url, body)
+	if token := b.AuthToken(); token != "" {
+		req.Header.Set("X-F5-Auth-Token", token)
+	} else {
+		req.SetBasicAuth(b.User, b.Password)
+	}
+
+	//fmt.Println("REQ -- ", options.Method, " ", url," -- ",options.Body)
+
+	if b.TransactionID != 0 {
+		req.Header.Set("X-F5-REST-Coordination-Id", strconv.FormatInt(b.TransactionID, 10))
+	}
+
+	if len(options.ContentType) > 0 {
+		req.Header.Set("Content-Type", options.ContentType)
+	}
+
+	if len(options.ContentRange) > 0 {
+		req.Header.Set("Content-Range", options.ContentRange)
+	}
+
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	defer res.Body.Close()
+
+	data, _ := ioutil.ReadAll(res.Body)
+
+	if res.StatusCode >= 400 {
+		return data, res.StatusCode, newAPIError(res.StatusCode, data)
+	}
+
+	return data, res.StatusCode, nil
+}
+
+// requestURL returns the URL of a request, relative to /mgmt/tm unless it
+// starts with mgmt/.
+func (b *BigIP) requestURL(options *APIRequest) string {
+	if strings.Contains(options.URL, "mgmt/") {
+		return fmt.Sprintf("%s/%s", b.Host, options.URL)
+	}
+	return fmt.Sprintf("%s/mgmt/tm/%s", b.Host, options.URL)
+}
+
+func (b *BigIP) iControlPath(parts []string) string {
+	var buffer bytes.Buffer
+	for i, p := range parts {
+		buffer.WriteString(strings.Replace(p, "/", "~", -1))
+		if i < len(parts)-1 {
+			buffer.WriteString("/")
+		}
+	}
+	return buffer.String()
+}
+
+// BeginTransaction starts a new transaction, use WithTransaction to add
+// requests to it.
+func (b *BigIP) BeginTransaction() (*Transaction, error) {
+	req := &APIRequest{
+		Method:        "post",
+		URL:           "mgmt/tm/transaction",
+		Body:          "{}",
+		ContentType:   "application/json",
+		ReadsResponse: true,
+	}
+	resp, err := b.APICall(req)
+	if err != nil {
+		return nil, err
+	}
+	var t Transaction
+	if err := json.Unmarshal(resp, &t); err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// WithTransaction returns a copy of the client whose requests are added to
+// the transaction <id>. Only create, modify and delete requests can be part
+// of a transaction, entities created in it can not be read before it is
+// committed.
+func (b *BigIP) WithTransaction(id int64) *BigIP {
+	tx := *b
+	tx.TransactionID = id
+	return &tx
+}
+
+// WithDeadline returns a copy of the client whose requests fail once the
+// deadline passes, transient errors are only retried before it. A zero
+// deadline returns the client itself.
+func (b *BigIP) WithDeadline(deadline time.Time) *BigIP {
+	if deadline.IsZero() {
+		return b
+	}
+	c := *b
+	c.Deadline = deadline
+	return &c
+}
+
+// CommitTransaction applies the requests of transaction <id>. Either all of
+// them succeed or none of them is applied.
+func (b *BigIP) CommitTransaction(id int64) error {
+	req := &APIRequest{
+		Method:        "patch",
+		URL:           fmt.Sprintf("mgmt/tm/transaction/%d", id),
+		Body:          `{"state":"VALIDATING"}`,
+		ContentType:   "application/json",
+		ReadsResponse: true,
+	}
+	resp, err := b.APICall(req)
+	if err != nil {
+		return err
+	}
+	var t Transaction
+	if err := json.Unmarshal(resp, &t); err != nil {
+		return err
+	}
+	if t.State == "FAILED" {
+		return fmt.Errorf("Transaction %d failed: %s", id, t.ErrorMessage)
+	}
+	return nil
+}
+
+// DeleteTransaction discards transaction <id> without applying its requests.
+func (b *BigIP) DeleteTransaction(id int64) error {
+	req := &APIRequest{
+		Method: "delete",
+		URL:    fmt.Sprintf("mgmt/tm/transaction/%d", id),
+	}
+	_, err := b.APICall(req)
+	return err
+}
+
+// Generic delete
+func (b *BigIP) delete(path ...string) error {
+	req := &APIRequest{
+		Method: "delete",
+		URL:    b.iControlPath(path),
+	}
+
+	_, callErr := b.APICall(req)
+	return callErr
+}
+
+func (b *BigIP) post(body interface{}, path ...string) error {
+	marshalJSON, err := jsonMarshal(body)
+	if err != nil {
+		return err
+	}
+
+	req := &APIRequest{
+		Method:      "post",
+		URL:         b.iControlPath(path),
+		Body:        strings.TrimRight(string(marshalJSON), "\n"),
+		ContentType: "application/json",
+	}
+
+	_, callErr := b.APICall(req)
+	return callErr
+}
+
+func (b *BigIP) put(body interface{}, path ...string) error {
+	marshalJSON, err := jsonMarshal(body)
+	if err != nil {
+		return err
+	}
+
+	req := &APIRequest{
+		Method:      "put",
+		URL:         b.iControlPath(path),
+		Body:        strings.TrimRight(string(marshalJSON), "\n"),
+		ContentType: "application/json",
+	}
+
+	_, callErr := b.APICall(req)
+	return callErr
+}
+
+func (b *BigIP) patch(body interface{}, path ...string) error {
+	marshalJSON, err := jsonMarshal(body)
+	if err != nil {
+		return err
+	}
+
+	req := &APIRequest{
+		Method:      "patch",
+		URL:         b.iControlPath(path),
+		Body:        string(marshalJSON),
+		ContentType: "application/json",
+	}
+
+	_, callErr := b.APICall(req)
+	return callErr
+}
+
+// Get a url and populate an entity. If the entity does not exist (404) then the
+// passed entity will be untouched and false will be returned as the second parameter.
+// You can use this to distinguish between a missing entity or an actual error.
+func (b *BigIP) getForEntity(e interface{}, path ...string) (error, bool) {
+	req := &APIRequest{
+		Method:      "get",
+		URL:         b.iControlPath(path),
+		ContentType: "application/json",
+	}
+
+	resp, err := b.APICall(req)
+	if err != nil {
+		var reqError RequestError
+		json.Unmarshal(resp, &reqError)
+		if reqError.Code == 404 && !IsPermissionDenied(err) {
+			return nil, false
+		}
+		return err, false
+	}
+
+	err = json.Unmarshal(resp, e)
+	if err != nil {
+		return err, false
+	}
+
+	return nil, true
+}
+
+// checkError handles any errors we get from our API requests. It returns either the
+// message of the error, if any, or nil.
+func (b *BigIP) checkError(resp []byte) error {
+	if len(resp) == 0 {
+		return nil
+	}
+
+	var reqError RequestError
+
+	err := json.Unmarshal(resp, &reqError)
+	if err != nil {
+		return errors.New(fmt.Sprintf("%s\n%s", err.Error(), string(resp[:])))
+	}
+
+	err = reqError.Error()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// jsonMarshal specifies an encoder with 'SetEscapeHTML' set to 'false' so that <, >, and & are not escaped. https://golang.org/pkg/encoding/json/#Marshal
+// https://stackoverflow.com/questions/28595664/how-to-stop-json-marshal-from-escaping-and
+func jsonMarshal(t interface{}) ([]byte, error) {
+	buffer := &bytes.Buffer{}
+	encoder := json.NewEncoder(buffer)
+	encoder.SetEscapeHTML(false)
+	err := encoder.Encode(t)
+	return buffer.Bytes(), err
+}
+
+// Helper to copy between transfer objects and model objects to hide the myriad of boolean representations
+// in the iControlREST api. DTO fields can be tagged with bool:"yes|enabled|true" to set what true and false
+// marshal to.
+func marshal(to, from interface{}) error {
+	toVal := reflect.ValueOf(to).Elem()
+	fromVal := reflect.ValueOf(from).Elem()
+	toType := toVal.Type()
+	for i := 0; i < toVal.NumField(); i++ {
+		toField := toVal.Field(i)
+		toFieldType := toType.Field(i)
+		fromField := fromVal.FieldByName(toFieldType.Name)
+		if fromField.Interface() != nil && fromField.Kind() == toField.Kind() {
+			// e.g. string and json.Number
+			toField.Set(fromField.Convert(toField.Type()))
+		} else if toField.Kind() == reflect.Bool && fromField.Kind() == reflect.String {
+			switch fromField.Interface() {
+			case "yes", "enabled", "true":
+				toField.SetBool(true)
+				break
+			case "no", "disabled", "false", "":
+				toField.SetBool(false)
+				break
+			default:
+				return fmt.Errorf("Unknown boolean conversion for %s: %s", toFieldType.Name, fromField.Interface())
+			}
+		} else if fromField.Kind() == reflect.Bool && toField.Kind() == reflect.String {
+			tag := toFieldType.Tag.Get("bool")
+			switch tag {
+			case "yes":
+				toField.SetString(toBoolString(fromField.Interface().(bool), "yes", "no"))
+				break
+			case "enabled":
+				toField.SetString(toBoolString(fromField.Interface().(bool), "enabled", "disabled"))
+				break
+			case "true":
+				toField.SetString(toBoolString(fromField.Interface().(bool), "true", "false"))
+				break
+			}
+		} else {
+			return fmt.Errorf("Unknown type conversion %s -> %s", fromField.Kind(), toField.Kind())
+		}
+	}
+	return nil
+}
+
+func toBoolString(b bool, trueStr, falseStr string) string {
+	if b {
+		return trueStr
+	}
+	return falseStr
+}
@@ -2,9 +2,11 @@ package bigip
 
 import (
 	"encoding/json"
+	"sort"
+	"strings"
 )
 
-//  LIC contains device license for BIG-IP system.
+// LIC contains device license for BIG-IP system.
 type LICs struct {
 	LIC []LIC `json:"items"`
 }
@@ -47,10 +49,29 @@ type Devices struct {
 }
 
 type Device struct {
-	ConfigsyncIp      string `json:"configsyncIp,omitempty"`
-	Name              string `json:"name,omitempty"`
-	MirrorIp          string `json:"mirrorIp,omitempty"`
-	MirrorSecondaryIp string `json:"mirrorSecondaryIp,omitempty"`
+	ConfigsyncIp      string                 `json:"configsyncIp,omitempty"`
+	Name              string                 `json:"name,omitempty"`
+	MirrorIp          string                 `json:"mirrorIp,omitempty"`
+	MirrorSecondaryIp string                 `json:"mirrorSecondaryIp,omitempty"`
+	UnicastAddress    []DeviceUnicastAddress `json:"unicastAddress,omitempty"`
+	SelfDevice        string                 `json:"selfDevice,omitempty"`
+}
+
+// DeviceUnicastAddress is an address the device sends and receives the
+// network failover heartbeats of its device groups on.
+type DeviceUnicastAddress struct {
+	Ip            string `json:"ip,omitempty"`
+	Port          int    `json:"port,omitempty"`
+	EffectiveIp   string `json:"effectiveIp,omitempty"`
+	EffectivePort int    `json:"effectivePort,omitempty"`
+}
+
+// TrafficGroup is a group of floating objects, e.g. self IPs and virtual
+// addresses, that fail over together between the devices of a cluster.
+type TrafficGroup struct {
+	Name      string `json:"name,omitempty"`
+	Partition string `json:"partition,omitempty"`
+	FullPath  string `json:"fullPath,omitempty"`
 }
 
 type Devicegroups struct {
@@ -70,18 +91,16 @@ type Devicegroup struct {
 	Deviceb                      []Devicerecord
 }
 type devicegroupDTO struct {
-	AutoSync                     string `json:"autoSync,omitempty"`
-	Name                         string `json:"name,omitempty"`
-	Partition                    string `json:"partition,omitempty"`
-	Description                  string `json:"description,omitempty"`
-	Type                         string `json:"type,omitempty"`
-	FullLoadOnSync               string `json:"fullLoadOnSync,omitempty"`
-	SaveOnAutoSync               string `json:"saveOnAutoSync,omitempty"`
-	NetworkFailover              string `json:"networkFailover,omitempty"`
-	IncrementalConfigSyncSizeMax int    `json:"incrementalConfigSyncSizeMax,omitempty"`
-	Deviceb                      struct {
-		Items []Devicerecord `json:"items,omitempty"`
-	} `json:"devicesReference,omitempty"`
+	AutoSync                     string         `json:"autoSync,omitempty"`
+	Name                         string         `json:"name,omitempty"`
+	Partition                    string         `json:"partition,omitempty"`
+	Description                  string         `json:"description,omitempty"`
+	Type                         string         `json:"type,omitempty"`
+	FullLoadOnSync               string         `json:"fullLoadOnSync,omitempty"`
+	SaveOnAutoSync               string         `json:"saveOnAutoSync,omitempty"`
+	NetworkFailover              string         `json:"networkFailover,omitempty"`
+	IncrementalConfigSyncSizeMax int            `json:"incrementalConfigSyncSizeMax,omitempty"`
+	Deviceb                      *Devicerecords `json:"devicesReference,omitempty"`
 }
 
 type Devicerecords struct {
@@ -91,9 +110,16 @@ type Devicerecords struct {
 type Devicerecord struct {
 	SetSyncLeader bool   `json:"setSyncLeader"`
 	Name          string `json:"name"`
+	FullPath      string `json:"fullPath,omitempty"`
 }
 
+// MarshalJSON leaves out the devices when there are none, so that modifying
+// a device group keeps its members.
 func (p *Devicegroup) MarshalJSON() ([]byte, error) {
+	var devices *Devicerecords
+	if len(p.Deviceb) > 0 {
+		devices = &Devicerecords{Items: p.Deviceb}
+	}
 	return json.Marshal(devicegroupDTO{
 		Name:                         p.Name,
 		Partition:                    p.Partition,
@@ -104,9 +130,7 @@ func (p *Devicegroup) MarshalJSON() ([]byte, error) {
 		SaveOnAutoSync:               p.SaveOnAutoSync,
 		NetworkFailover:              p.NetworkFailover,
 		IncrementalConfigSyncSizeMax: p.IncrementalConfigSyncSizeMax,
-		Deviceb: struct {
-			Items []Devicerecord `json:"items,omitempty"`
-		}{Items: p.Deviceb},
+		Deviceb:                      devices,
 	})
 }
 
@@ -126,7 +150,9 @@ func (p *Devicegroup) UnmarshalJSON(b []byte) error {
 	p.SaveOnAutoSync = dto.SaveOnAutoSync
 	p.NetworkFailover = dto.NetworkFailover
 	p.IncrementalConfigSyncSizeMax = dto.IncrementalConfigSyncSizeMax
-	p.Deviceb = dto.Deviceb.Items
+	if dto.Deviceb != nil {
+		p.Deviceb = dto.Deviceb.Items
+	}
 
 	return nil
 }
@@ -139,6 +165,8 @@ const (
 	uriDiv           = "device"
 	uriDevices       = "devices"
 	uriDG            = "device-group"
+	uriTrafficGroup  = "traffic-group"
+	uriSyncStatus    = "sync-status"
 	uriLins          = "licensing"
 	uriPoo           = "pool"
 	uriPur           = "purchased-pool"
@@ -240,22 +268,27 @@ func (b *BigIP) CreateDevice(name, configsyncIp, mirrorIp, mirrorSecondaryIp str
 	return b.post(config, uriCm, uriDiv)
 }
 
-// API does not work, you cannot modify API issue
+// ModifyDevice changes the settings of a device, e.g. its config sync and
+// failover addresses, leaving out the settings not set.
 func (b *BigIP) ModifyDevice(config *Device) error {
-	return b.put(config, uriCm, uriDiv)
+	return b.patch(config, uriCm, uriDiv, config.Name)
 }
 
 func (b *BigIP) DeleteDevice(name string) error {
 	return b.delete(uriCm, uriDiv, name)
 }
 
+// Devices returns the named device, or nil if it does not exist.
 func (b *BigIP) Devices(name string) (*Device, error) {
 	var device Device
-	err, _ := b.getForEntity(&device, uriCm, uriDiv, name)
+	err, ok := b.getForEntity(&device, uriCm, uriDiv, name)
 
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		return nil, nil
+	}
 
 	return &device, nil
 }
@@ -264,19 +297,25 @@ func (b *BigIP) CreateDevicegroup(p *Devicegroup) error {
 	return b.post(p, uriCm, uriDG)
 }
 
+// UpdateDevicegroup changes the settings of a device group, its devices
+// are changed with AddDevicegroupDevice and DeleteDevicegroupDevices.
 func (b *BigIP) UpdateDevicegroup(name string, p *Devicegroup) error {
-	return b.put(p, uriCm, uriDG, name)
+	return b.patch(p, uriCm, uriDG, name)
 }
 func (b *BigIP) ModifyDevicegroup(config *Devicegroup) error {
 	return b.put(config, uriCm, uriDG)
 }
 
+// Devicegroups returns the named device group, or nil if it does not exist.
 func (b *BigIP) Devicegroups(name string) (*Devicegroup, error) {
 	var devicegroup Devicegroup
-	err, _ := b.getForEntity(&devicegroup, uriCm, uriDG, name)
+	err, ok := b.getForEntity(&devicegroup, uriCm, uriDG, name)
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		return nil, nil
+	}
 
 	return &devicegroup, nil
 }
@@ -285,6 +324,22 @@ func (b *BigIP) DeleteDevicegroup(name string) error {
 	return b.delete(uriCm, uriDG, name)
 }
 
+// DevicegroupDevices returns the devices of the named device group.
+func (b *BigIP) DevicegroupDevices(name string) ([]Devicerecord, error) {
+	var devices Devicerecords
+	err, _ := b.getForEntity(&devices, uriCm, uriDG, name, uriDevices)
+	if err != nil {
+		return nil, err
+	}
+
+	return devices.Items, nil
+}
+
+// AddDevicegroupDevice adds a device to the named device group.
+func (b *BigIP) AddDevicegroupDevice(name string, device Devicerecord) error {
+	return b.post(device, uriCm, uriDG, name, uriDevices)
+}
+
 func (b *BigIP) DeleteDevicegroupDevices(name, rname string) error {
 	return b.delete(uriCm, uriDG, name, uriDevices, rname)
 }
@@ -298,3 +353,93 @@ func (b *BigIP) DevicegroupsDevices(name, rname string) (*Devicegroup, error) {
 
 	return &devicegroup, nil
 }
+
+// GetTrafficGroup returns the named traffic group, or nil if it does not
+// exist.
+func (b *BigIP) GetTrafficGroup(name string) (*TrafficGroup, error) {
+	var trafficGroup TrafficGroup
+	err, ok := b.getForEntity(&trafficGroup, uriCm, uriTrafficGroup, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &trafficGroup, nil
+}
+
+type configSyncCommand struct {
+	Command     string `json:"command"`
+	UtilCmdArgs string `json:"utilCmdArgs"`
+}
+
+// ConfigSync syncs the configuration of this device to the other devices of
+// the device group, like tmsh run cm config-sync to-group. The sync runs in
+// the background, GetSyncStatus tells when it is done.
+func (b *BigIP) ConfigSync(deviceGroup string) error {
+	config := &configSyncCommand{
+		Command:     "run",
+		UtilCmdArgs: "config-sync to-group " + deviceGroup,
+	}
+	return b.post(config, uriCm)
+}
+
+// SyncStatus is the config sync status of the device, e.g. In Sync or
+// Changes Pending. Details has a line per device group and per peer device,
+// e.g. "failover (In Sync): All devices in the device group are in sync".
+type SyncStatus struct {
+	Status  string
+	Color   string
+	Summary string
+	Details []string
+}
+
+// DeviceGroupStatus returns the status of the device group from the details,
+// e.g. In Sync, or "" when the details have no line for the device group.
+func (s *SyncStatus) DeviceGroupStatus(deviceGroup string) string {
+	prefix := deviceGroup + " ("
+	for _, detail := range s.Details {
+		if strings.HasPrefix(detail, prefix) {
+			if end := strings.Index(detail[len(prefix):], ")"); end >= 0 {
+				return detail[len(prefix) : len(prefix)+end]
+			}
+		}
+	}
+	return ""
+}
+
+type syncStatusEntries struct {
+	Entries map[string]struct {
+		Description string            `json:"description"`
+		NestedStats syncStatusEntries `json:"nestedStats"`
+	} `json:"entries"`
+}
+
+// GetSyncStatus returns the config sync status of the device.
+func (b *BigIP) GetSyncStatus() (*SyncStatus, error) {
+	var stats syncStatusEntries
+	err, _ := b.getForEntity(&stats, uriCm, uriSyncStatus)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &SyncStatus{}
+	for _, entry := range stats.Entries {
+		e := entry.NestedStats.Entries
+		status.Status = e["status"].Description
+		status.Color = e["color"].Description
+		status.Summary = e["summary"].Description
+		for key, details := range e {
+			if !strings.HasSuffix(key, "/details") {
+				continue
+			}
+			for _, detail := range details.NestedStats.Entries {
+				status.Details = append(status.Details, detail.NestedStats.Entries["details"].Description)
+			}
+		}
+	}
+	sort.Strings(status.Details)
+
+	return status, nil
+}
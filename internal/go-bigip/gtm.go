@@ -0,0 +1,457 @@
+package bigip
+
+import "encoding/json"
+import "log"
+
+//ooo
+
+type Datacenters struct {
+	Datacenters []Datacenter `json:"items"`
+}
+
+type Datacenter struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Contact     string `json:"contact,omitempty"`
+	App_service string `json:"appService,omitempty"`
+	Disabled    bool   `json:"disabled,omitempty"`
+	Enabled     bool   `json:"enabled,omitempty"`
+	Prober_pool string `json:"proberPool,omitempty"`
+}
+
+type Gtmmonitors struct {
+	Gtmmonitors []Gtmmonitor `json:"items"`
+}
+
+type Gtmmonitor struct {
+	Name          string `json:"name,omitempty"`
+	Defaults_from string `json:"defaultsFrom,omitempty"`
+	Interval      int    `json:"interval,omitempty"`
+	Probe_timeout int    `json:"probeTimeout,omitempty"`
+	Recv          string `json:"recv,omitempty"`
+	Send          string `json:"send,omitempty"`
+}
+
+type Servers struct {
+	Servers []Server `json:"items"`
+}
+
+// Server is a GTM server, a BIG-IP system or another host with virtual
+// servers that GTM pools send clients to.
+type Server struct {
+	Name                     string
+	Partition                string
+	FullPath                 string
+	Datacenter               string
+	Monitor                  string
+	Virtual_server_discovery string
+	Product                  string
+	Addresses                []ServerAddresses
+	GTMVirtual_Server        []VSrecord
+}
+
+type serverDTO struct {
+	Name                     string            `json:"name,omitempty"`
+	Partition                string            `json:"partition,omitempty"`
+	FullPath                 string            `json:"fullPath,omitempty"`
+	Datacenter               string            `json:"datacenter,omitempty"`
+	Monitor                  string            `json:"monitor,omitempty"`
+	Virtual_server_discovery string            `json:"virtualServerDiscovery,omitempty"`
+	Product                  string            `json:"product,omitempty"`
+	Addresses                []ServerAddresses `json:"addresses,omitempty"`
+	GTMVirtual_Server        []VSrecord        `json:"virtualServers"`
+	// The BIG-IP reports the virtual servers as a subcollection
+	GTMVirtual_ServerReference struct {
+		Items []VSrecord `json:"items,omitempty"`
+	} `json:"virtualServersReference,omitempty"`
+}
+
+func (p *Server) MarshalJSON() ([]byte, error) {
+	virtualServers := p.GTMVirtual_Server
+	if virtualServers == nil {
+		virtualServers = []VSrecord{}
+	}
+	return json.Marshal(struct {
+		Name                     string            `json:"name,omitempty"`
+		Partition                string            `json:"partition,omitempty"`
+		Datacenter               string            `json:"datacenter,omitempty"`
+		Monitor                  string            `json:"monitor,omitempty"`
+		Virtual_server_discovery string            `json:"virtualServerDiscovery,omitempty"`
+		Product                  string            `json:"product,omitempty"`
+		Addresses                []ServerAddresses `json:"addresses,omitempty"`
+		GTMVirtual_Server        []VSrecord        `json:"virtualServers"`
+	}{
+		Name:                     p.Name,
+		Partition:                p.Partition,
+		Datacenter:               p.Datacenter,
+		Monitor:                  p.Monitor,
+		Virtual_server_discovery: p.Virtual_server_discovery,
+		Product:                  p.Product,
+		Addresses:                p.Addresses,
+		GTMVirtual_Server:        virtualServers,
+	})
+}
+
+func (p *Server) UnmarshalJSON(b []byte) error {
+	var dto serverDTO
+	err := json.Unmarshal(b, &dto)
+	if err != nil {
+		return err
+	}
+
+	p.Name = dto.Name
+	p.Partition = dto.Partition
+	p.FullPath = dto.FullPath
+	p.Datacenter = dto.Datacenter
+	p.Monitor = dto.Monitor
+	p.Virtual_server_discovery = dto.Virtual_server_discovery
+	p.Product = dto.Product
+	p.Addresses = dto.Addresses
+	p.GTMVirtual_Server = dto.GTMVirtual_Server
+	if len(p.GTMVirtual_Server) == 0 {
+		p.GTMVirtual_Server = dto.GTMVirtual_ServerReference.Items
+	}
+	return nil
+}
+
+type ServerAddressess struct {
+	Items []ServerAddresses `json:"items,omitempty"`
+}
+
+type ServerAddresses struct {
+	Name        string `json:"name"`
+	Device_name string `json:"deviceName,omitempty"`
+	Translation string `json:"translation,omitempty"`
+}
+
+type VSrecords struct {
+	Items []VSrecord `json:"items,omitempty"`
+}
+
+type VSrecord struct {
+	Name        string `json:"name"`
+	Destination string `json:"destination,omitempty"`
+}
+
+type Pool_as struct {
+	Pool_as []Pool_a `json:"items"`
+}
+
+type Pool_a struct {
+	Name                 string   `json:"name,omitempty"`
+	Monitor              string   `json:"monitor,omitempty"`
+	Load_balancing_mode  string   `json:"load_balancing_mode,omitempty"`
+	Max_answers_returned int      `json:"max_answers_returned,omitempty"`
+	Alternate_mode       string   `json:"alternate_mode,omitempty"`
+	Fallback_ip          string   `json:"fallback_ip,omitempty"`
+	Fallback_mode        string   `json:"fallback_mode,omitempty"`
+	Members              []string `json:"members,omitempty"`
+}
+
+const (
+	uriGtm        = "gtm"
+	uriServer     = "server"
+	uriDatacenter = "datacenter"
+	uriGtmmonitor = "monitor"
+	uriHttp       = "http"
+	uriPool_a     = "pool/a"
+)
+
+func (b *BigIP) Datacenters() (*Datacenter, error) {
+	var datacenter Datacenter
+	err, _ := b.getForEntity(&datacenter, uriGtm, uriDatacenter)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &datacenter, nil
+}
+
+func (b *BigIP) CreateDatacenter(name, description, contact, app_service string, enabled, disabled bool, prober_pool string) error {
+	config := &Datacenter{
+		Name:        name,
+		Description: description,
+		Contact:     contact,
+		App_service: app_service,
+		Enabled:     enabled,
+		Disabled:    disabled,
+		Prober_pool: prober_pool,
+	}
+	return b.post(config, uriGtm, uriDatacenter)
+}
+
+func (b *BigIP) ModifyDatacenter(*Datacenter) error {
+	return b.patch(uriGtm, uriDatacenter)
+}
+
+func (b *BigIP) DeleteDatacenter(name string) error {
+	return b.delete(uriGtm, uriDatacenter, name)
+}
+
+func (b *BigIP) Gtmmonitors() (*Gtmmonitor, error) {
+	var gtmmonitor Gtmmonitor
+	err, _ := b.getForEntity(&gtmmonitor, uriGtm, uriGtmmonitor, uriHttp)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &gtmmonitor, nil
+}
+func (b *BigIP) CreateGtmmonitor(name, defaults_from string, interval, probeTimeout int, recv, send string) error {
+	config := &Gtmmonitor{
+		Name:          name,
+		Defaults_from: defaults_from,
+		Interval:      interval,
+		Probe_timeout: probeTimeout,
+		Recv:          recv,
+		Send:          send,
+	}
+	return b.post(config, uriGtm, uriGtmmonitor, uriHttp)
+}
+
+func (b *BigIP) ModifyGtmmonitor(*Gtmmonitor) error {
+	return b.patch(uriGtm, uriGtmmonitor, uriHttp)
+}
+
+func (b *BigIP) DeleteGtmmonitor(name string) error {
+	return b.delete(uriGtm, uriGtmmonitor, uriHttp, name)
+}
+
+func (b *BigIP) CreateGtmserver(p *Server) error {
+	return b.post(p, uriGtm, uriServer)
+}
+
+// Update an existing policy.
+func (b *BigIP) UpdateGtmserver(name string, p *Server) error {
+	return b.put(p, uriGtm, uriServer, name)
+}
+
+// Delete a policy by name.
+func (b *BigIP) DeleteGtmserver(name string) error {
+	return b.delete(uriGtm, uriServer, name)
+}
+
+// GetGtmserver returns the named server with its virtual servers, or nil if
+// it does not exist.
+func (b *BigIP) GetGtmserver(name string) (*Server, error) {
+	var p Server
+	err, ok := b.getForEntity(&p, uriGtm, uriServer, name+"?expandSubcollections=true")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &p, nil
+}
+
+func (b *BigIP) CreatePool_a(name, monitor, load_balancing_mode string, max_answers_returned int, alternate_mode, fallback_ip, fallback_mode string, members []string) error {
+	config := &Pool_a{
+		Name:                 name,
+		Monitor:              monitor,
+		Load_balancing_mode:  load_balancing_mode,
+		Max_answers_returned: max_answers_returned,
+		Alternate_mode:       alternate_mode,
+		Fallback_ip:          fallback_ip,
+		Fallback_mode:        fallback_mode,
+		Members:              members,
+	}
+	log.Println("in poola now", config)
+	return b.patch(config, uriGtm, uriPool_a)
+}
+
+func (b *BigIP) ModifyPool_a(config *Pool_a) error {
+	return b.put(config, uriGtm, uriPool_a)
+}
+
+func (b *BigIP) Pool_as() (*Pool_a, error) {
+	var pool_a Pool_a
+	err, _ := b.getForEntity(&pool_a, uriGtm, uriPool_a)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &pool_a, nil
+}
+
+// GTMWideIPPool is a pool of a wide IP, Order is its position in the list of
+// pools of the wide IP, starting at 0.
+type GTMWideIPPool struct {
+	Name      string `json:"name"`
+	Partition string `json:"partition,omitempty"`
+	Order     int    `json:"order"`
+	Ratio     int    `json:"ratio,omitempty"`
+}
+
+// GTMWideIP is a wide IP, a DNS name resolved by GTM to the members of its
+// pools. LastResortPool is the type and full path of a pool, e.g.
+// "a /Common/pool".
+type GTMWideIP struct {
+	Name           string          `json:"name,omitempty"`
+	Partition      string          `json:"partition,omitempty"`
+	FullPath       string          `json:"fullPath,omitempty"`
+	Description    string          `json:"description,omitempty"`
+	PoolLbMode     string          `json:"poolLbMode,omitempty"`
+	LastResortPool string          `json:"lastResortPool"`
+	Pools          []GTMWideIPPool `json:"pools"`
+}
+
+const uriWideIP = "wideip"
+
+// GetGTMWideIP returns the wide IP of the given record type, e.g. a or aaaa,
+// or nil if it does not exist.
+func (b *BigIP) GetGTMWideIP(recordType, name string) (*GTMWideIP, error) {
+	var wideIP GTMWideIP
+	err, ok := b.getForEntity(&wideIP, uriGtm, uriWideIP, recordType, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &wideIP, nil
+}
+
+// AddGTMWideIP adds a wide IP of the given record type.
+func (b *BigIP) AddGTMWideIP(recordType string, config *GTMWideIP) error {
+	return b.post(config, uriGtm, uriWideIP, recordType)
+}
+
+// ModifyGTMWideIP updates the wide IP of the given record type.
+func (b *BigIP) ModifyGTMWideIP(recordType, name string, config *GTMWideIP) error {
+	return b.put(config, uriGtm, uriWideIP, recordType, name)
+}
+
+// DeleteGTMWideIP removes the wide IP of the given record type.
+func (b *BigIP) DeleteGTMWideIP(recordType, name string) error {
+	return b.delete(uriGtm, uriWideIP, recordType, name)
+}
+
+// GTMPoolMember is a member of a GTM pool, a virtual server of a GTM server.
+// Name is "server:virtual server" and MemberOrder the position of the member in
+// the pool, starting at 0.
+type GTMPoolMember struct {
+	Name        string `json:"name"`
+	Partition   string `json:"partition,omitempty"`
+	MemberOrder int    `json:"memberOrder"`
+	Ratio       int    `json:"ratio,omitempty"`
+}
+
+// GTMPool is a GTM pool of the given record type, answering DNS queries for
+// wide IPs with its members.
+type GTMPool struct {
+	Name              string
+	Partition         string
+	FullPath          string
+	Description       string
+	LoadBalancingMode string
+	AlternateMode     string
+	FallbackMode      string
+	FallbackIP        string
+	Monitor           string
+	Members           []GTMPoolMember
+}
+
+type gtmPoolDTO struct {
+	Name              string          `json:"name,omitempty"`
+	Partition         string          `json:"partition,omitempty"`
+	FullPath          string          `json:"fullPath,omitempty"`
+	Description       string          `json:"description,omitempty"`
+	LoadBalancingMode string          `json:"loadBalancingMode,omitempty"`
+	AlternateMode     string          `json:"alternateMode,omitempty"`
+	FallbackMode      string          `json:"fallbackMode,omitempty"`
+	FallbackIP        string          `json:"fallbackIp,omitempty"`
+	Monitor           string          `json:"monitor,omitempty"`
+	Members           []GTMPoolMember `json:"members"`
+	// The BIG-IP reports the members as a subcollection
+	MembersReference struct {
+		Items []GTMPoolMember `json:"items,omitempty"`
+	} `json:"membersReference,omitempty"`
+}
+
+func (p *GTMPool) MarshalJSON() ([]byte, error) {
+	members := p.Members
+	if members == nil {
+		members = []GTMPoolMember{}
+	}
+	return json.Marshal(struct {
+		Name              string          `json:"name,omitempty"`
+		Partition         string          `json:"partition,omitempty"`
+		Description       string          `json:"description"`
+		LoadBalancingMode string          `json:"loadBalancingMode,omitempty"`
+		AlternateMode     string          `json:"alternateMode,omitempty"`
+		FallbackMode      string          `json:"fallbackMode,omitempty"`
+		FallbackIP        string          `json:"fallbackIp,omitempty"`
+		Monitor           string          `json:"monitor,omitempty"`
+		Members           []GTMPoolMember `json:"members"`
+	}{
+		Name:              p.Name,
+		Partition:         p.Partition,
+		Description:       p.Description,
+		LoadBalancingMode: p.LoadBalancingMode,
+		AlternateMode:     p.AlternateMode,
+		FallbackMode:      p.FallbackMode,
+		FallbackIP:        p.FallbackIP,
+		Monitor:           p.Monitor,
+		Members:           members,
+	})
+}
+
+func (p *GTMPool) UnmarshalJSON(b []byte) error {
+	var dto gtmPoolDTO
+	err := json.Unmarshal(b, &dto)
+	if err != nil {
+		return err
+	}
+
+	p.Name = dto.Name
+	p.Partition = dto.Partition
+	p.FullPath = dto.FullPath
+	p.Description = dto.Description
+	p.LoadBalancingMode = dto.LoadBalancingMode
+	p.AlternateMode = dto.AlternateMode
+	p.FallbackMode = dto.FallbackMode
+	p.FallbackIP = dto.FallbackIP
+	p.Monitor = dto.Monitor
+	p.Members = dto.Members
+	if len(p.Members) == 0 {
+		p.Members = dto.MembersReference.Items
+	}
+	return nil
+}
+
+const uriGTMPool = "pool"
+
+// GetGTMPool returns the pool of the given record type, e.g. a or aaaa, with
+// its members, or nil if it does not exist.
+func (b *BigIP) GetGTMPool(recordType, name string) (*GTMPool, error) {
+	var pool GTMPool
+	err, ok := b.getForEntity(&pool, uriGtm, uriGTMPool, recordType, name+"?expandSubcollections=true")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &pool, nil
+}
+
+// AddGTMPool adds a pool of the given record type.
+func (b *BigIP) AddGTMPool(recordType string, config *GTMPool) error {
+	return b.post(config, uriGtm, uriGTMPool, recordType)
+}
+
+// ModifyGTMPool updates the pool of the given record type, replacing its
+// members.
+func (b *BigIP) ModifyGTMPool(recordType, name string, config *GTMPool) error {
+	return b.put(config, uriGtm, uriGTMPool, recordType, name)
+}
+
+// DeleteGTMPool removes the pool of the given record type.
+func (b *BigIP) DeleteGTMPool(recordType, name string) error {
+	return b.delete(uriGtm, uriGTMPool, recordType, name)
+}
@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
+	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -68,82 +71,93 @@ type ClientSSLProfiles struct {
 	ClientSSLProfiles []ClientSSLProfile `json:"items"`
 }
 
+// CertKeyChain is a certificate and key of a client-ssl profile, the profile
+// has one of each key type, e.g. RSA and ECDSA.
+type CertKeyChain struct {
+	Name       string `json:"name,omitempty"`
+	Cert       string `json:"cert,omitempty"`
+	Chain      string `json:"chain,omitempty"`
+	Key        string `json:"key,omitempty"`
+	Passphrase string `json:"passphrase,omitempty"`
+}
+
 // ClientSSLProfile contains information about each client-ssl profile. You can use all
 // of these fields when modifying a client-ssl profile.
 type ClientSSLProfile struct {
-	Name              string `json:"name,omitempty"`
-	Partition         string `json:"partition,omitempty"`
-	FullPath          string `json:"fullPath,omitempty"`
-	Generation        int    `json:"generation,omitempty"`
-	AlertTimeout      string `json:"alertTimeout,omitempty"`
-	AllowNonSsl       string `json:"allowNonSsl,omitempty"`
-	Authenticate      string `json:"authenticate,omitempty"`
-	AuthenticateDepth int    `json:"authenticateDepth,omitempty"`
-	CaFile            string `json:"caFile,omitempty"`
-	CacheSize         int    `json:"cacheSize,omitempty"`
-	CacheTimeout      int    `json:"cacheTimeout,omitempty"`
-	Cert              string `json:"cert,omitempty"`
-	CertKeyChain      []struct {
-		Name       string `json:"name,omitempty"`
-		Cert       string `json:"cert,omitempty"`
-		Chain      string `json:"chain,omitempty"`
-		Key        string `json:"key,omitempty"`
-		Passphrase string `json:"passphrase,omitempty"`
-	} `json:"certKeyChain,omitempty"`
-	CertExtensionIncludes           []string `json:"certExtensionIncludes,omitempty"`
-	CertLifespan                    int      `json:"certLifespan,omitempty"`
-	CertLookupByIpaddrPort          string   `json:"certLookupByIpaddrPort,omitempty"`
-	Chain                           string   `json:"chain,omitempty"`
-	Ciphers                         string   `json:"Ciphers,omitempty"`
-	ClientCertCa                    string   `json:"clientCertCa,omitempty"`
-	CrlFile                         string   `json:"crlFile,omitempty"`
-	DefaultsFrom                    string   `json:"defaultsFrom,omitempty"`
-	ForwardProxyBypassDefaultAction string   `json:"forwardProxyBypassDefaultAction,omitempty"`
-	GenericAlert                    string   `json:"genericAlert,omitempty"`
-	HandshakeTimeout                string   `json:"handshakeTimeout,omitempty"`
-	InheritCertkeychain             string   `json:"inheritCertkeychain,omitempty"`
-	Key                             string   `json:"key,omitempty"`
-	ModSslMethods                   string   `json:"modSslMethods,omitempty"`
-	Mode                            string   `json:"mode,omitempty"`
-	TmOptions                       []string `json:"tmOptions,omitempty"`
-	Passphrase                      string   `json:"passphrase,omitempty"`
-	PeerCertMode                    string   `json:"peerCertMode,omitempty"`
-	ProxyCaCert                     string   `json:"proxyCaCert,omitempty"`
-	ProxyCaKey                      string   `json:"proxyCaKey,omitempty"`
-	ProxyCaPassphrase               string   `json:"proxyCaPassphrase,omitempty"`
-	ProxySsl                        string   `json:"proxySsl,omitempty"`
-	ProxySslPassthrough             string   `json:"proxySslPassthrough,omitempty"`
-	RenegotiatePeriod               string   `json:"renegotiatePeriod,omitempty"`
-	RenegotiateSize                 string   `json:"renegotiateSize,omitempty"`
-	Renegotiation                   string   `json:"renegotiation,omitempty"`
-	RetainCertificate               string   `json:"retainCertificate,omitempty"`
-	SecureRenegotiation             string   `json:"secureRenegotiation,omitempty"`
-	ServerName                      string   `json:"serverName,omitempty"`
-	SessionMirroring                string   `json:"sessionMirroring,omitempty"`
-	SessionTicket                   string   `json:"sessionTicket,omitempty"`
-	SniDefault                      string   `json:"sniDefault,omitempty"`
-	SniRequire                      string   `json:"sniRequire,omitempty"`
-	SslForwardProxy                 string   `json:"sslForwardProxy,omitempty"`
-	SslForwardProxyBypass           string   `json:"sslForwardProxyBypass,omitempty"`
-	SslSignHash                     string   `json:"sslSignHash,omitempty"`
-	StrictResume                    string   `json:"strictResume,omitempty"`
-	UncleanShutdown                 string   `json:"uncleanShutdown,omitempty"`
+	Name                            string         `json:"name,omitempty"`
+	Partition                       string         `json:"partition,omitempty"`
+	FullPath                        string         `json:"fullPath,omitempty"`
+	Generation                      int            `json:"generation,omitempty"`
+	AlertTimeout                    string         `json:"alertTimeout,omitempty"`
+	AllowNonSsl                     string         `json:"allowNonSsl,omitempty"`
+	Authenticate                    string         `json:"authenticate,omitempty"`
+	AuthenticateDepth               int            `json:"authenticateDepth,omitempty"`
+	CaFile                          string         `json:"caFile,omitempty"`
+	CacheSize                       int            `json:"cacheSize,omitempty"`
+	CacheTimeout                    int            `json:"cacheTimeout,omitempty"`
+	Cert                            string         `json:"cert,omitempty"`
+	CertKeyChain                    []CertKeyChain `json:"certKeyChain,omitempty"`
+	CertExtensionIncludes           []string       `json:"certExtensionIncludes,omitempty"`
+	CertLifespan                    int            `json:"certLifespan,omitempty"`
+	CertLookupByIpaddrPort          string         `json:"certLookupByIpaddrPort,omitempty"`
+	Chain                           string         `json:"chain,omitempty"`
+	Ciphers                         string         `json:"Ciphers,omitempty"`
+	ClientCertCa                    string         `json:"clientCertCa,omitempty"`
+	CrlFile                         string         `json:"crlFile,omitempty"`
+	DefaultsFrom                    string         `json:"defaultsFrom,omitempty"`
+	ForwardProxyBypassDefaultAction string         `json:"forwardProxyBypassDefaultAction,omitempty"`
+	GenericAlert                    string         `json:"genericAlert,omitempty"`
+	HandshakeTimeout                string         `json:"handshakeTimeout,omitempty"`
+	InheritCertkeychain             string         `json:"inheritCertkeychain,omitempty"`
+	Key                             string         `json:"key,omitempty"`
+	ModSslMethods                   string         `json:"modSslMethods,omitempty"`
+	Mode                            string         `json:"mode,omitempty"`
+	TmOptions                       []string       `json:"tmOptions,omitempty"`
+	Passphrase                      string         `json:"passphrase,omitempty"`
+	PeerCertMode                    string         `json:"peerCertMode,omitempty"`
+	ProxyCaCert                     string         `json:"proxyCaCert,omitempty"`
+	ProxyCaKey                      string         `json:"proxyCaKey,omitempty"`
+	ProxyCaPassphrase               string         `json:"proxyCaPassphrase,omitempty"`
+	ProxySsl                        string         `json:"proxySsl,omitempty"`
+	ProxySslPassthrough             string         `json:"proxySslPassthrough,omitempty"`
+	RenegotiatePeriod               string         `json:"renegotiatePeriod,omitempty"`
+	RenegotiateSize                 string         `json:"renegotiateSize,omitempty"`
+	Renegotiation                   string         `json:"renegotiation,omitempty"`
+	RetainCertificate               string         `json:"retainCertificate,omitempty"`
+	SecureRenegotiation             string         `json:"secureRenegotiation,omitempty"`
+	ServerName                      string         `json:"serverName,omitempty"`
+	SessionMirroring                string         `json:"sessionMirroring,omitempty"`
+	SessionTicket                   string         `json:"sessionTicket,omitempty"`
+	SniDefault                      string         `json:"sniDefault,omitempty"`
+	SniRequire                      string         `json:"sniRequire,omitempty"`
+	SslForwardProxy                 string         `json:"sslForwardProxy,omitempty"`
+	SslForwardProxyBypass           string         `json:"sslForwardProxyBypass,omitempty"`
+	SslSignHash                     string         `json:"sslSignHash,omitempty"`
+	StrictResume                    string         `json:"strictResume,omitempty"`
+	UncleanShutdown                 string         `json:"uncleanShutdown,omitempty"`
 }
 
 // Nodes contains a list of every node on the BIG-IP system.
 type Nodes struct {
 	Nodes []Node `json:"items"`
+	// NextLink is set when the list is a page and more nodes follow.
+	NextLink string `json:"nextLink,omitempty"`
 }
 
 // Node contains information about each individual node. You can use all
 // of these fields when modifying a node.
 type Node struct {
-	Name            string `json:"name,omitempty"`
-	Partition       string `json:"partition,omitempty"`
-	FullPath        string `json:"fullPath,omitempty"`
-	Generation      int    `json:"generation,omitempty"`
-	Address         string `json:"address,omitempty"`
-	ConnectionLimit int    `json:"connectionLimit,omitempty"`
+	Name       string `json:"name,omitempty"`
+	Partition  string `json:"partition,omitempty"`
+	FullPath   string `json:"fullPath,omitempty"`
+	Generation int    `json:"generation,omitempty"`
+	Address    string `json:"address,omitempty"`
+	// Description is left unchanged when nil, a pointer to an empty string
+	// removes it.
+	Description *string `json:"description,omitempty"`
+	// ConnectionLimit is left unchanged when nil, a pointer to 0 removes
+	// the limit. Some versions omit the field when no limit is set.
+	ConnectionLimit *int   `json:"connectionLimit,omitempty"`
 	DynamicRatio    int    `json:"dynamicRatio,omitempty"`
 	Logging         string `json:"logging,omitempty"`
 	Monitor         string `json:"monitor,omitempty"`
@@ -158,6 +172,47 @@ type Node struct {
 		Interval      string `json:"interval,omitempty"`
 		Name          string `json:"tmName,omitempty"`
 	} `json:"fqdn,omitempty"`
+	// Metadata is left unchanged when nil, a pointer to an empty slice
+	// removes all entries.
+	Metadata *[]NodeMetadata `json:"metadata,omitempty"`
+	// Ephemeral is "true" for the nodes the BIG-IP creates for the addresses
+	// an FQDN node with autopopulate resolves to, named _auto_<address>.
+	// It is read only.
+	Ephemeral string `json:"ephemeral,omitempty"`
+}
+
+// NodeMetadata is a user defined name/value entry attached to a node.
+type NodeMetadata struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Persist string `json:"persist,omitempty"`
+}
+
+// NodeStats contains the statistics of a node.
+type NodeStats struct {
+	CurConns          int64
+	MaxConns          int64
+	TotConns          int64
+	TotRequests       int64
+	BitsIn            int64
+	BitsOut           int64
+	PktsIn            int64
+	PktsOut           int64
+	CurSessions       int64
+	AvailabilityState string
+	EnabledState      string
+}
+
+// statsEntries is the layout of the stats endpoint of an object.
+type statsEntries struct {
+	Entries map[string]struct {
+		NestedStats struct {
+			Entries map[string]struct {
+				Value       int64  `json:"value"`
+				Description string `json:"description"`
+			} `json:"entries"`
+		} `json:"nestedStats"`
+	} `json:"entries"`
 }
 
 // DataGroups contains a list of data groups on the BIG-IP system.
@@ -216,6 +271,17 @@ func (p *DataGroup) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// ExternalDataGroup contains information about a data group whose records
+// are read from a data group file.
+type ExternalDataGroup struct {
+	Name             string `json:"name,omitempty"`
+	Partition        string `json:"partition,omitempty"`
+	FullPath         string `json:"fullPath,omitempty"`
+	Generation       int    `json:"generation,omitempty"`
+	Type             string `json:"type,omitempty"`
+	ExternalFileName string `json:"externalFileName,omitempty"`
+}
+
 // SnatPools contains a list of every snatpool on the BIG-IP system.
 type SnatPools struct {
 	SnatPools []SnatPool `json:"items"`
@@ -280,19 +346,20 @@ type poolMembers struct {
 }
 
 // Pool Member contains information about each individual member in a pool. You can use all
-// of these fields when modifying a pool member.
+// of these fields when modifying a pool member. ConnectionLimit, DynamicRatio
+// and PriorityGroup are left unchanged when nil, so they can be set back to 0.
 type PoolMember struct {
 	Name            string `json:"name,omitempty"`
 	Partition       string `json:"partition,omitempty"`
 	FullPath        string `json:"fullPath,omitempty"`
 	Generation      int    `json:"generation,omitempty"`
 	Address         string `json:"address,omitempty"`
-	ConnectionLimit int    `json:"connectionLimit,omitempty"`
-	DynamicRatio    int    `json:"dynamicRatio,omitempty"`
+	ConnectionLimit *int   `json:"connectionLimit,omitempty"`
+	DynamicRatio    *int   `json:"dynamicRatio,omitempty"`
 	InheritProfile  string `json:"inheritProfile,omitempty"`
 	Logging         string `json:"logging,omitempty"`
 	Monitor         string `json:"monitor,omitempty"`
-	PriorityGroup   int    `json:"priorityGroup,omitempty"`
+	PriorityGroup   *int   `json:"priorityGroup,omitempty"`
 	RateLimit       string `json:"rateLimit,omitempty"`
 	Ratio           int    `json:"ratio,omitempty"`
 	Session         string `json:"session,omitempty"`
@@ -542,15 +609,18 @@ type VirtualAddress struct {
 	ARP                   bool
 	AutoDelete            bool
 	ConnectionLimit       int
+	Description           string
 	Enabled               bool
 	Floating              bool
 	ICMPEcho              bool
 	InheritedTrafficGroup bool
 	Mask                  string
-	RouteAdvertisement    bool
-	ServerScope           string
-	TrafficGroup          string
-	Unit                  int
+	// RouteAdvertisement is enabled or disabled, or on version 13 and later
+	// selective, always, any or all.
+	RouteAdvertisement string
+	ServerScope        string
+	TrafficGroup       string
+	Unit               int
 }
 
 type virtualAddressDTO struct {
@@ -562,12 +632,13 @@ type virtualAddressDTO struct {
 	ARP                   string `json:"arp,omitempty" bool:"enabled"`
 	AutoDelete            string `json:"autoDelete,omitempty" bool:"true"`
 	ConnectionLimit       int    `json:"connectionLimit,omitempty"`
+	Description           string `json:"description,omitempty"`
 	Enabled               string `json:"enabled,omitempty" bool:"yes"`
 	Floating              string `json:"floating,omitempty" bool:"enabled"`
 	ICMPEcho              string `json:"icmpEcho,omitempty" bool:"enabled"`
 	InheritedTrafficGroup string `json:"inheritedTrafficGroup,omitempty" bool:"yes"`
 	Mask                  string `json:"mask,omitempty"`
-	RouteAdvertisement    string `json:"routeAdvertisement,omitempty" bool:"enabled"`
+	RouteAdvertisement    string `json:"routeAdvertisement,omitempty"`
 	ServerScope           string `json:"serverScope,omitempty"`
 	TrafficGroup          string `json:"trafficGroup,omitempty"`
 	Unit                  int    `json:"unit,omitempty"`
@@ -598,7 +669,7 @@ type Policy struct {
 
 type policyDTO struct {
 	Name        string   `json:"name"`
-	PublishCopy string   `json:"publishedCopy"`
+	PublishCopy string   `json:"publishedCopy,omitempty"`
 	Partition   string   `json:"partition,omitempty"`
 	Controls    []string `json:"controls,omitempty"`
 	Requires    []string `json:"requires,omitempty"`
@@ -947,33 +1018,63 @@ type Monitor struct {
 	TimeUntilUp    int
 	Timeout        int
 	Transparent    string
+	Logging        string
 	UpInterval     int
 	Username       string
+	SSLProfile     string
+	Base           string
+	Filter         string
+	Domain         string
+	Run            string
+	Args           string
+	UserDefined    map[string]string
 }
 
 type monitorDTO struct {
-	Name           string `json:"name,omitempty"`
-	Partition      string `json:"partition,omitempty"`
-	DefaultsFrom   string `json:"defaultsFrom,omitempty"`
-	FullPath       string `json:"fullPath,omitempty"`
-	Generation     int    `json:"generation,omitempty"`
-	ParentMonitor  string `json:"defaultsFrom,omitempty"`
-	Description    string `json:"description,omitempty"`
-	Destination    string `json:"destination,omitempty"`
-	Interval       int    `json:"interval,omitempty"`
-	IPDSCP         int    `json:"ipDscp,omitempty"`
-	ManualResume   string `json:"manualResume,omitempty"`
-	Password       string `json:"password,omitempty"`
-	ReceiveString  string `json:"recv,omitempty"`
-	ReceiveDisable string `json:"recvDisable,omitempty"`
-	Reverse        string `json:"reverse,omitempty"`
-	SendString     string `json:"send,omitempty"`
-	TimeUntilUp    int    `json:"timeUntilUp,omitempty"`
-	Timeout        int    `json:"timeout,omitempty"`
-	Transparent    string `json:"transparent,omitempty"`
-	UpInterval     int    `json:"upInterval,omitempty"`
-	Username       string `json:"username,omitempty"`
-}
+	Name           string            `json:"name,omitempty"`
+	Partition      string            `json:"partition,omitempty"`
+	DefaultsFrom   string            `json:"defaultsFrom,omitempty"`
+	FullPath       string            `json:"fullPath,omitempty"`
+	Generation     int               `json:"generation,omitempty"`
+	ParentMonitor  string            `json:"-"`
+	Description    string            `json:"description,omitempty"`
+	Destination    string            `json:"destination,omitempty"`
+	Interval       int               `json:"interval,omitempty"`
+	IPDSCP         int               `json:"ipDscp,omitempty"`
+	ManualResume   string            `json:"manualResume,omitempty"`
+	Password       string            `json:"password,omitempty"`
+	ReceiveString  string            `json:"recv,omitempty"`
+	ReceiveDisable string            `json:"recvDisable,omitempty"`
+	Reverse        string            `json:"reverse,omitempty"`
+	SendString     string            `json:"send,omitempty"`
+	TimeUntilUp    int               `json:"timeUntilUp,omitempty"`
+	Timeout        int               `json:"timeout,omitempty"`
+	Transparent    string            `json:"transparent,omitempty"`
+	Logging        string            `json:"logging,omitempty"`
+	UpInterval     int               `json:"upInterval,omitempty"`
+	Username       string            `json:"username,omitempty"`
+	SSLProfile     string            `json:"sslProfile,omitempty"`
+	Base           string            `json:"base,omitempty"`
+	Filter         string            `json:"filter,omitempty"`
+	Domain         string            `json:"domain,omitempty"`
+	Run            string            `json:"run,omitempty"`
+	Args           string            `json:"args,omitempty"`
+	UserDefined    map[string]string `json:"-"`
+}
+
+// External monitor variables are written as a single "NAME value NAME value"
+// string but read back as apiRawValues keyed by "userDefined NAME".
+type monitorWriteDTO struct {
+	monitorDTO
+	UserDefined string `json:"userDefined,omitempty"`
+}
+
+type monitorReadDTO struct {
+	monitorDTO
+	APIRawValues map[string]string `json:"apiRawValues,omitempty"`
+}
+
+const monitorUserDefinedPrefix = "userDefined "
 
 type Profiles struct {
 	Profiles []Profile `json:"items"`
@@ -1025,33 +1126,41 @@ type Oneconnect struct {
 }
 
 type tcpDTO struct {
-	Name              string `json:"name,omitempty"`
-	Partition         string `json:"partition,omitempty"`
-	DefaultsFrom      string `json:"defaultsFrom,omitempty"`
-	IdleTimeout       int    `json:"idleTimeout,omitempty"`
-	CloseWaitTimeout  int    `json:"closeWaitTimeout,omitempty"`
-	FinWait_2Timeout  int    `json:"finWait_2Timeout,omitempty"`
-	FinWaitTimeout    int    `json:"finWaitTimeout,omitempty"`
-	KeepAliveInterval int    `json:"keepAliveInterval,omitempty"`
-	DeferredAccept    string `json:"deferredAccept,omitempty"`
-	FastOpen          string `json:"fastOpen,omitempty"`
+	Name              string      `json:"name,omitempty"`
+	Partition         string      `json:"partition,omitempty"`
+	DefaultsFrom      string      `json:"defaultsFrom,omitempty"`
+	IdleTimeout       json.Number `json:"idleTimeout,omitempty"`
+	CloseWaitTimeout  json.Number `json:"closeWaitTimeout,omitempty"`
+	FinWait_2Timeout  json.Number `json:"finWait_2Timeout,omitempty"`
+	FinWaitTimeout    json.Number `json:"finWaitTimeout,omitempty"`
+	KeepAliveInterval int         `json:"keepAliveInterval,omitempty"`
+	DeferredAccept    string      `json:"deferredAccept,omitempty"`
+	FastOpen          string      `json:"fastOpen,omitempty"`
+	Nagle             string      `json:"nagle,omitempty"`
+	ProxyBufferHigh   int         `json:"proxyBufferHigh,omitempty"`
+	ProxyBufferLow    int         `json:"proxyBufferLow,omitempty"`
 }
 
 type Tcps struct {
 	Tcps []Tcp `json:"items"`
 }
 
+// Tcp is a TCP profile. Timeouts are numbers of seconds, 4294967295 stands
+// for indefinite, empty timeouts are inherited from the parent profile.
 type Tcp struct {
 	Name              string
 	Partition         string
 	DefaultsFrom      string
-	IdleTimeout       int
-	CloseWaitTimeout  int
-	FinWait_2Timeout  int
-	FinWaitTimeout    int
+	IdleTimeout       string
+	CloseWaitTimeout  string
+	FinWait_2Timeout  string
+	FinWaitTimeout    string
 	KeepAliveInterval int
 	DeferredAccept    string
 	FastOpen          string
+	Nagle             string
+	ProxyBufferHigh   int
+	ProxyBufferLow    int
 }
 
 type fasthttpDTO struct {
@@ -1091,12 +1200,16 @@ type fastl4DTO struct {
 	DefaultsFrom          string `json:"defaultsFrom,omitempty"`
 	Partition             string `json:"partition,omitempty"`
 	ExplicitFlowMigration string `json:"explicitFlowMigration,omitempty"`
-	HardwareSynCookie     string `json:"hardwareSynCookie,omitem"`
+	HardwareSynCookie     string `json:"hardwareSynCookie,omitempty"`
 	IdleTimeout           string `json:"idleTimeout,omitempty"`
 	ClientTimeout         int    `json:"clientTimeout,omitempty"`
 	IpTosToClient         string `json:"ipTosToClient,omitempty"`
 	IpTosToServer         string `json:"ipTosToServer,omitempty"`
 	KeepAliveInterval     string `json:"keepAliveInterval,omitempty"`
+	LooseClose            string `json:"looseClose,omitempty"`
+	LooseInitiation       string `json:"looseInitialization,omitempty"`
+	ResetOnTimeout        string `json:"resetOnTimeout,omitempty"`
+	TcpHandshakeTimeout   string `json:"tcpHandshakeTimeout,omitempty"`
 }
 
 type Fastl4s struct {
@@ -1114,13 +1227,21 @@ type Fastl4 struct {
 	IpTosToClient         string
 	IpTosToServer         string
 	KeepAliveInterval     string
+	LooseClose            string
+	LooseInitiation       string
+	ResetOnTimeout        string
+	TcpHandshakeTimeout   string
 }
 
 type httpcompressDTO struct {
-	Name         string   `json:"name,omitempty"`
-	DefaultsFrom string   `json:"defaultsFrom,omitempty"`
-	UriExclude   []string `json:"uriExclude,omitempty"`
-	UriInclude   []string `json:"uriInclude,omitempty"`
+	Name               string   `json:"name,omitempty"`
+	Partition          string   `json:"partition,omitempty"`
+	DefaultsFrom       string   `json:"defaultsFrom,omitempty"`
+	UriExclude         []string `json:"uriExclude,omitempty"`
+	UriInclude         []string `json:"uriInclude,omitempty"`
+	ContentTypeExclude []string `json:"contentTypeExclude,omitempty"`
+	ContentTypeInclude []string `json:"contentTypeInclude,omitempty"`
+	GzipLevel          int      `json:"gzipLevel,omitempty"`
 }
 
 type Httpcompresss struct {
@@ -1128,10 +1249,14 @@ type Httpcompresss struct {
 }
 
 type Httpcompress struct {
-	Name         string
-	DefaultsFrom string
-	UriExclude   []string
-	UriInclude   []string
+	Name               string
+	Partition          string
+	DefaultsFrom       string
+	UriExclude         []string
+	UriInclude         []string
+	ContentTypeExclude []string
+	ContentTypeInclude []string
+	GzipLevel          int
 }
 
 type http2DTO struct {
@@ -1218,21 +1343,44 @@ func (p *Records) UnmarshalJSON(b []byte) error {
 }
 
 func (p *Monitor) MarshalJSON() ([]byte, error) {
-	var dto monitorDTO
-	marshal(&dto, p)
+	var dto monitorWriteDTO
+	marshal(&dto.monitorDTO, p)
 	if strings.Contains(dto.SendString, "\r\n") {
 		dto.SendString = strings.Replace(dto.SendString, "\r\n", "\\r\\n", -1)
 	}
+	// Variables are sorted by name so the same map always serializes the same way
+	names := make([]string, 0, len(p.UserDefined))
+	for name := range p.UserDefined {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, 2*len(names))
+	for _, name := range names {
+		pairs = append(pairs, name, p.UserDefined[name])
+	}
+	dto.UserDefined = strings.Join(pairs, " ")
 	return jsonMarshal(dto)
 }
 
 func (p *Monitor) UnmarshalJSON(b []byte) error {
-	var dto monitorDTO
+	var dto monitorReadDTO
 	err := json.Unmarshal(b, &dto)
 	if err != nil {
 		return err
 	}
-	return marshal(p, &dto)
+	err = marshal(p, &dto.monitorDTO)
+	if err != nil {
+		return err
+	}
+	for key, value := range dto.APIRawValues {
+		if strings.HasPrefix(key, monitorUserDefinedPrefix) {
+			if p.UserDefined == nil {
+				p.UserDefined = make(map[string]string)
+			}
+			p.UserDefined[strings.TrimPrefix(key, monitorUserDefinedPrefix)] = value
+		}
+	}
+	return nil
 }
 
 func (p *Oneconnect) MarshalJSON() ([]byte, error) {
@@ -1329,7 +1477,9 @@ type Snats struct {
 	Snats []Snat `json:"items"`
 }
 
-// VirtualAddress contains information about each individual virtual address.
+// Snat translates the source address of connections from its origins, to
+// the address of Translation, the addresses of Snatpool or the self IPs of
+// the egress VLAN with Automap. Only one of them is set.
 type Snat struct {
 	Name          string
 	Partition     string
@@ -1339,23 +1489,27 @@ type Snat struct {
 	SourcePort    string
 	Translation   string
 	Snatpool      string
+	Automap       bool
+	Vlans         []string
+	VlansEnabled  bool
 	VlansDisabled bool
 	Origins       []Originsrecord
 }
 
 type snatDTO struct {
-	Name          string `json:"name"`
-	Partition     string `json:"partition,omitempty"`
-	FullPath      string `json:"fullPath,omitempty"`
-	AutoLasthop   string `json:"autoLastHop,omitempty"`
-	Mirror        string `json:"mirror,omitempty"`
-	SourcePort    string `json:"sourePort,omitempty"`
-	Translation   string `json:"translation,omitempty"`
-	Snatpool      string `json:"snatpool,omitempty"`
-	VlansDisabled bool   `json:"vlansDisabled,omitempty" bool:"disabled"`
-	Origins       struct {
-		Items []Originsrecord `json:"items,omitempty"`
-	} `json:"originsReference,omitempty"`
+	Name          string          `json:"name"`
+	Partition     string          `json:"partition,omitempty"`
+	FullPath      string          `json:"fullPath,omitempty"`
+	AutoLasthop   string          `json:"autoLasthop,omitempty"`
+	Mirror        string          `json:"mirror,omitempty"`
+	SourcePort    string          `json:"sourcePort,omitempty"`
+	Translation   string          `json:"translation,omitempty"`
+	Snatpool      string          `json:"snatpool,omitempty"`
+	Automap       bool            `json:"automap,omitempty"`
+	Vlans         []string        `json:"vlans,omitempty"`
+	VlansEnabled  bool            `json:"vlansEnabled,omitempty"`
+	VlansDisabled bool            `json:"vlansDisabled,omitempty"`
+	Origins       []Originsrecord `json:"origins,omitempty"`
 }
 
 type Originsrecords struct {
@@ -1363,8 +1517,8 @@ type Originsrecords struct {
 }
 
 type Originsrecord struct {
-	Name        string `json:"name"`
-	app_service string `json:"appService,omitempty"`
+	Name       string `json:"name"`
+	AppService string `json:"appService,omitempty"`
 }
 
 func (p *Snat) MarshalJSON() ([]byte, error) {
@@ -1372,14 +1526,16 @@ func (p *Snat) MarshalJSON() ([]byte, error) {
 		Name:          p.Name,
 		Partition:     p.Partition,
 		FullPath:      p.FullPath,
+		AutoLasthop:   p.AutoLasthop,
 		Mirror:        p.Mirror,
 		SourcePort:    p.SourcePort,
 		Translation:   p.Translation,
 		Snatpool:      p.Snatpool,
+		Automap:       p.Automap,
+		Vlans:         p.Vlans,
+		VlansEnabled:  p.VlansEnabled,
 		VlansDisabled: p.VlansDisabled,
-		Origins: struct {
-			Items []Originsrecord `json:"items,omitempty"`
-		}{Items: p.Origins},
+		Origins:       p.Origins,
 	})
 }
 
@@ -1398,8 +1554,11 @@ func (p *Snat) UnmarshalJSON(b []byte) error {
 	p.SourcePort = dto.SourcePort
 	p.Translation = dto.Translation
 	p.Snatpool = dto.Snatpool
+	p.Automap = dto.Automap
+	p.Vlans = dto.Vlans
+	p.VlansEnabled = dto.VlansEnabled
 	p.VlansDisabled = dto.VlansDisabled
-	p.Origins = dto.Origins.Items
+	p.Origins = dto.Origins
 
 	return nil
 }
@@ -1528,7 +1687,35 @@ type TcpProfile struct {
 	VerifiedAccept           string `json:"verifiedAccept,omitempty"`
 }
 
-// UdpProfiles contains a list of every tcp profile on the BIG-IP system.
+// FtpProfile is an FTP profile on the BIG-IP system.
+type FtpProfile struct {
+	Name                 string `json:"name,omitempty"`
+	Partition            string `json:"partition,omitempty"`
+	FullPath             string `json:"fullPath,omitempty"`
+	AppService           string `json:"appService,omitempty"`
+	DefaultsFrom         string `json:"defaultsFrom,omitempty"`
+	Description          string `json:"description,omitempty"`
+	InheritParentProfile string `json:"inheritParentProfile,omitempty"`
+	// Port is the data port of active mode FTP.
+	Port              int    `json:"port,omitempty"`
+	TranslateExtended string `json:"translateExtended,omitempty"`
+}
+
+// DnsProfile is a DNS profile on the BIG-IP system. Its switches take yes or
+// no rather than enabled or disabled.
+type DnsProfile struct {
+	Name                 string `json:"name,omitempty"`
+	Partition            string `json:"partition,omitempty"`
+	FullPath             string `json:"fullPath,omitempty"`
+	AppService           string `json:"appService,omitempty"`
+	DefaultsFrom         string `json:"defaultsFrom,omitempty"`
+	Description          string `json:"description,omitempty"`
+	EnableDnsExpress     string `json:"enableDnsExpress,omitempty"`
+	ProcessRd            string `json:"processRd,omitempty"`
+	UnhandledQueryAction string `json:"unhandledQueryAction,omitempty"`
+}
+
+// UdpProfiles contains a list of every udp profile on the BIG-IP system.
 type UdpProfiles struct {
 	UdpProfiles []UdpProfile `json:"items"`
 }
@@ -1550,6 +1737,7 @@ type UdpProfile struct {
 	LinkQosToClient       string `json:"linkQosToClient,omitempty"`
 	Name                  string `json:"name,omitempty"`
 	NoChecksum            string `json:"noChecksum,omitempty"`
+	Partition             string `json:"partition,omitempty"`
 	TmPartition           string `json:"tmPartition,omitempty"`
 	ProxyMss              string `json:"proxyMss,omitempty"`
 }
@@ -1652,6 +1840,7 @@ const (
 	uriIRule          = "rule"
 	uriDatagroup      = "data-group"
 	uriInternal       = "internal"
+	uriExternal       = "external"
 	uriPolicy         = "policy"
 	uriOneconnect     = "one-connect"
 	uriPersistence    = "persistence"
@@ -1661,6 +1850,9 @@ const (
 	CONTEXT_CLIENT    = "clientside"
 	CONTEXT_ALL       = "all"
 	uriTcp            = "tcp"
+	uriUdp            = "udp"
+	uriFtp            = "ftp"
+	uriDns            = "dns"
 	uriFasthttp       = "fasthttp"
 	uriFastl4         = "fastl4"
 	uriHttpcompress   = "http-compression"
@@ -1744,13 +1936,13 @@ func (b *BigIP) AddSnatPool(config *SnatPool) error {
 // GetSnatPool retrieves a SnatPool by name. Returns nil if the snatpool does not exist
 func (b *BigIP) GetSnatPool(name string) (*SnatPool, error) {
 	var snatPool SnatPool
-	err, _ := b.getForEntity(&snatPool, uriLtm, uriSnatPool, name)
+	err, ok := b.getForEntity(&snatPool, uriLtm, uriSnatPool, name)
 	if err != nil {
 		return nil, err
 	}
-	//if !ok {
-	//	return nil, nil
-	//}
+	if !ok {
+		return nil, nil
+	}
 
 	return &snatPool, nil
 }
@@ -1879,6 +2071,20 @@ func (b *BigIP) Nodes() (*Nodes, error) {
 	return &nodes, nil
 }
 
+// PartitionNodes returns a page of the nodes of a partition, at most top nodes
+// after skipping the first skip ones. NextLink of the page is set when more
+// nodes follow.
+func (b *BigIP) PartitionNodes(partition string, top, skip int) (*Nodes, error) {
+	query := fmt.Sprintf("?$filter=partition%%20eq%%20%s&$top=%d&$skip=%d", url.QueryEscape(partition), top, skip)
+	var nodes Nodes
+	err, _ := b.getForEntity(&nodes, uriLtm, uriNode+query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &nodes, nil
+}
+
 // AddNode adds a new node to the BIG-IP system using the Node Spec
 func (b *BigIP) AddNode(config *Node) error {
 	return b.post(config, uriLtm, uriNode)
@@ -1890,7 +2096,7 @@ func (b *BigIP) CreateNode(name, address, rate_limit string, connection_limit, d
 		Name:            name,
 		Address:         address,
 		RateLimit:       rate_limit,
-		ConnectionLimit: connection_limit,
+		ConnectionLimit: &connection_limit,
 		DynamicRatio:    dynamic_ratio,
 		Monitor:         monitor,
 		State:           state,
@@ -1904,7 +2110,7 @@ func (b *BigIP) CreateFQDNNode(name, address, rate_limit string, connection_limi
 	config := &Node{
 		Name:            name,
 		RateLimit:       rate_limit,
-		ConnectionLimit: connection_limit,
+		ConnectionLimit: &connection_limit,
 		DynamicRatio:    dynamic_ratio,
 		Monitor:         monitor,
 		State:           state,
@@ -1932,6 +2138,37 @@ func (b *BigIP) GetNode(name string) (*Node, error) {
 	return &node, nil
 }
 
+// GetNodeStats returns the statistics of a node. Returns nil if the node
+// does not exist.
+func (b *BigIP) GetNodeStats(name string) (*NodeStats, error) {
+	var stats statsEntries
+	err, ok := b.getForEntity(&stats, uriLtm, uriNode, name, "stats")
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	nodeStats := &NodeStats{}
+	for _, entry := range stats.Entries {
+		e := entry.NestedStats.Entries
+		nodeStats.CurConns = e["serverside.curConns"].Value
+		nodeStats.MaxConns = e["serverside.maxConns"].Value
+		nodeStats.TotConns = e["serverside.totConns"].Value
+		nodeStats.TotRequests = e["totRequests"].Value
+		nodeStats.BitsIn = e["serverside.bitsIn"].Value
+		nodeStats.BitsOut = e["serverside.bitsOut"].Value
+		nodeStats.PktsIn = e["serverside.pktsIn"].Value
+		nodeStats.PktsOut = e["serverside.pktsOut"].Value
+		nodeStats.CurSessions = e["curSessions"].Value
+		nodeStats.AvailabilityState = e["status.availabilityState"].Description
+		nodeStats.EnabledState = e["status.enabledState"].Description
+	}
+
+	return nodeStats, nil
+}
+
 // DeleteNode removes a node.
 func (b *BigIP) DeleteNode(name string) error {
 	return b.delete(uriLtm, uriNode, name)
@@ -1943,6 +2180,12 @@ func (b *BigIP) ModifyNode(name string, config *Node) error {
 	return b.put(config, uriLtm, uriNode, name)
 }
 
+// PatchNode changes only the attributes of a node set in config, leaving the
+// others as they are on the BIG-IP.
+func (b *BigIP) PatchNode(name string, config *Node) error {
+	return b.patch(config, uriLtm, uriNode, name)
+}
+
 // NodeStatus changes the status of a node. <state> can be either
 // "enable" or "disable".
 func (b *BigIP) NodeStatus(name, state string) error {
@@ -2015,6 +2258,33 @@ func (b *BigIP) GetInternalDataGroupRecords(name string) (*[]DataGroupRecord, er
 	return &dataGroup.Records, nil
 }
 
+func (b *BigIP) AddExternalDataGroup(config *ExternalDataGroup) error {
+	return b.post(config, uriLtm, uriDatagroup, uriExternal)
+}
+
+func (b *BigIP) DeleteExternalDataGroup(name string) error {
+	return b.delete(uriLtm, uriDatagroup, uriExternal, name)
+}
+
+// Modify a named external data group, e.g. to read its records from another file
+func (b *BigIP) ModifyExternalDataGroup(name string, config *ExternalDataGroup) error {
+	return b.put(config, uriLtm, uriDatagroup, uriExternal, name)
+}
+
+// Get an external data group by name, returns nil if the data group does not exist
+func (b *BigIP) GetExternalDataGroup(name string) (*ExternalDataGroup, error) {
+	var datagroup ExternalDataGroup
+	err, ok := b.getForEntity(&datagroup, uriLtm, uriDatagroup, uriExternal, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &datagroup, nil
+}
+
 // Pools returns a list of pools.
 func (b *BigIP) Pools() (*Pools, error) {
 	var pools Pools
@@ -2081,6 +2351,18 @@ func (b *BigIP) ModifyPoolMember(pool string, config *PoolMember) error {
 	return b.put(config, uriLtm, uriPool, pool, uriPoolMember, member)
 }
 
+// PatchPoolMember updates only the fields of a pool member that are set in
+// config, the other settings of the member are kept.
+func (b *BigIP) PatchPoolMember(pool string, config *PoolMember) error {
+	member := config.FullPath
+	config.Name = ""
+	config.Partition = ""
+	config.FullPath = ""
+	config.Address = ""
+
+	return b.patch(config, uriLtm, uriPool, pool, uriPoolMember, member)
+}
+
 // UpdatePoolMembers does a replace-all-with for the members of a pool.
 func (b *BigIP) UpdatePoolMembers(pool string, pm *[]PoolMember) error {
 	config := &poolMembers{
@@ -2182,9 +2464,15 @@ func (b *BigIP) CreateVirtualServer(name, destination, mask, pool string, vlans_
 		subnetMask = mask
 	}
 
+	// IPv6 destinations separate the port with a dot
+	separator := ":"
+	if strings.Contains(destination, ":") {
+		separator = "."
+	}
+
 	config := &VirtualServer{
 		Name:             name,
-		Destination:      fmt.Sprintf("%s:%d", destination, port),
+		Destination:      fmt.Sprintf("%s%s%d", destination, separator, port),
 		Mask:             subnetMask,
 		Pool:             pool,
 		TranslateAddress: translate_address,
@@ -2250,7 +2538,7 @@ func (b *BigIP) VirtualServerProfiles(vs string) (*Profiles, error) {
 	return &p, nil
 }
 
-//Get the names of policies associated with a particular virtual server
+// Get the names of policies associated with a particular virtual server
 func (b *BigIP) VirtualServerPolicyNames(vs string) ([]string, error) {
 	var policies VirtualServerPolicies
 	err, _ := b.getForEntity(&policies, uriLtm, uriVirtual, vs, "policies")
@@ -2277,10 +2565,13 @@ func (b *BigIP) VirtualAddresses() (*VirtualAddresses, error) {
 // GetVirtualAddress retrieves a VirtualAddress by name. Returns nil if the VirtualAddress does not exist
 func (b *BigIP) GetVirtualAddress(vaddr string) (*VirtualAddress, error) {
 	var virtualAddress VirtualAddress
-	err, _ := b.getForEntity(&virtualAddress, uriLtm, uriVirtualAddress, vaddr)
+	err, ok := b.getForEntity(&virtualAddress, uriLtm, uriVirtualAddress, vaddr)
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		return nil, nil
+	}
 	return &virtualAddress, nil
 }
 
@@ -2307,25 +2598,57 @@ func (b *BigIP) DeleteVirtualAddress(vaddr string) error {
 	return b.delete(uriLtm, uriVirtualAddress, vaddr)
 }
 
-// Monitors returns a list of all HTTP, HTTPS, Gateway ICMP, ICMP, and TCP monitors.
+// Monitors returns a list of all HTTP, HTTPS, Gateway ICMP, ICMP, TCP, external, LDAP and SMTP monitors.
 func (b *BigIP) Monitors() ([]Monitor, error) {
 	var monitors []Monitor
-	monitorUris := []string{"http", "https", "icmp", "gateway-icmp", "tcp", "tcp-half-open"}
+	monitorUris := []string{"http", "https", "icmp", "gateway-icmp", "tcp", "tcp-half-open", "external", "ldap", "smtp"}
 
 	for _, name := range monitorUris {
-		var m Monitors
-		err, _ := b.getForEntity(&m, uriLtm, uriMonitor, name)
+		m, err := b.MonitorsOfType(name)
 		if err != nil {
 			return nil, err
 		}
-		for _, monitor := range m.Monitors {
-			monitors = append(monitors, monitor)
-		}
+		monitors = append(monitors, m...)
 	}
 
 	return monitors, nil
 }
 
+// MonitorsOfType returns a list of the monitors of one type, e.g. "dns" or "udp".
+func (b *BigIP) MonitorsOfType(monitorType string) ([]Monitor, error) {
+	var m Monitors
+	err, _ := b.getForEntity(&m, uriLtm, uriMonitor, monitorType)
+	if err != nil {
+		return nil, err
+	}
+	return m.Monitors, nil
+}
+
+// MonitorTypes returns the monitor types of the BIG-IP system, e.g. "http" or "dns",
+// taken from the references of the monitor collection.
+func (b *BigIP) MonitorTypes() ([]string, error) {
+	var collection struct {
+		Items []struct {
+			Reference struct {
+				Link string `json:"link"`
+			} `json:"reference"`
+		} `json:"items"`
+	}
+	err, _ := b.getForEntity(&collection, uriLtm, uriMonitor)
+	if err != nil {
+		return nil, err
+	}
+	var types []string
+	for _, item := range collection.Items {
+		link, err := url.Parse(item.Reference.Link)
+		if err != nil || link.Path == "" {
+			continue
+		}
+		types = append(types, link.Path[strings.LastIndex(link.Path, "/")+1:])
+	}
+	return types, nil
+}
+
 // CreateMonitor adds a new monitor to the BIG-IP system. <parent> must be one of "http", "https",
 // "icmp", "gateway icmp", or "tcp".
 func (b *BigIP) CreateMonitor(name, parent, defaults_from string, interval, timeout int, send, receive, receive_disable string) error {
@@ -2452,15 +2775,35 @@ func (b *BigIP) Policies() (*Policies, error) {
 	return &p, nil
 }
 
-//Load a fully policy definition. Policies seem to be best dealt with as one big entity.
+// Load a fully policy definition. Policies seem to be best dealt with as one big entity.
+// policyPath returns the partition and name of a policy given either as a
+// full path or as a name in the Common partition.
+func policyPath(name string) (string, string) {
+	if strings.HasPrefix(name, "/") {
+		parts := strings.SplitN(strings.TrimPrefix(name, "/"), "/", 2)
+		if len(parts) == 2 {
+			return parts[0], parts[1]
+		}
+	}
+	return "Common", name
+}
+
+// PolicyDraftName returns the full path of the draft of a policy, e.g.
+// /Common/Drafts/my-policy.
+func PolicyDraftName(name string) string {
+	partition, policy := policyPath(name)
+	return fmt.Sprintf("/%s/Drafts/%s", partition, policy)
+}
+
+// GetPolicy returns the published policy with its rules sorted by ordinal,
+// and their actions and conditions in order. Returns nil if the policy does
+// not exist.
 func (b *BigIP) GetPolicy(name string) (*Policy, error) {
+	partition, policy := policyPath(name)
+	path := fmt.Sprintf("/%s/%s", partition, policy)
+
 	var p Policy
-	values := []string{}
-	values = append(values, "Drafts/")
-	values = append(values, name)
-	// Join three strings into one.
-	result := strings.Join(values, "")
-	err, ok := b.getForEntity(&p, uriLtm, uriPolicy, result)
+	err, ok := b.getForEntity(&p, uriLtm, uriPolicy, path)
 	if err != nil {
 		return nil, err
 	}
@@ -2469,24 +2812,28 @@ func (b *BigIP) GetPolicy(name string) (*Policy, error) {
 	}
 
 	var rules PolicyRules
-	err, _ = b.getForEntity(&rules, uriLtm, uriPolicy, name, "rules")
+	err, _ = b.getForEntity(&rules, uriLtm, uriPolicy, path, "rules")
 	if err != nil {
 		return nil, err
 	}
 	p.Rules = rules.Items
+	sort.SliceStable(p.Rules, func(i, j int) bool { return p.Rules[i].Ordinal < p.Rules[j].Ordinal })
 
-	for i, _ := range p.Rules {
+	for i := range p.Rules {
 		var a PolicyRuleActions
 		var c PolicyRuleConditions
 
-		err, _ = b.getForEntity(&a, uriLtm, uriPolicy, name, "rules", p.Rules[i].Name, "actions")
+		err, _ = b.getForEntity(&a, uriLtm, uriPolicy, path, "rules", p.Rules[i].Name, "actions")
 		if err != nil {
 			return nil, err
 		}
-		err, _ = b.getForEntity(&c, uriLtm, uriPolicy, name, "rules", p.Rules[i].Name, "conditions")
+		err, _ = b.getForEntity(&c, uriLtm, uriPolicy, path, "rules", p.Rules[i].Name, "conditions")
 		if err != nil {
 			return nil, err
 		}
+		// Actions and conditions are named after their position
+		sort.SliceStable(a.Items, func(i, j int) bool { return policyItemIndex(a.Items[i].Name) < policyItemIndex(a.Items[j].Name) })
+		sort.SliceStable(c.Items, func(i, j int) bool { return policyItemIndex(c.Items[i].Name) < policyItemIndex(c.Items[j].Name) })
 		p.Rules[i].Actions = a.Items
 		p.Rules[i].Conditions = c.Items
 	}
@@ -2494,6 +2841,14 @@ func (b *BigIP) GetPolicy(name string) (*Policy, error) {
 	return &p, nil
 }
 
+func policyItemIndex(name string) int {
+	i, err := strconv.Atoi(name)
+	if err != nil {
+		return -1
+	}
+	return i
+}
+
 func normalizePolicy(p *Policy) {
 	//f5 doesn't seem to automatically handle setting the ordinal
 	for ri, _ := range p.Rules {
@@ -2507,46 +2862,47 @@ func normalizePolicy(p *Policy) {
 	}
 }
 
-//Create a new policy. It is not necessary to set the Ordinal fields on subcollections.
+// CreatePolicy creates a new policy, on BIG-IP 12.1 and later policies are
+// created as drafts, see PolicyDraftName. It is not necessary to set the
+// Ordinal fields on subcollections.
 func (b *BigIP) CreatePolicy(p *Policy) error {
 	normalizePolicy(p)
 	return b.post(p, uriLtm, uriPolicy)
 }
 
-func (b *BigIP) PublishPolicy(name, publish string) error {
-	config := &Policy{
-		PublishCopy: publish,
-	}
-	values := []string{}
-	values = append(values, "~Common~Drafts~")
-	values = append(values, name)
-	// Join three strings into one.
-	result := strings.Join(values, "")
+// CreatePolicyDraft creates a draft of a published policy, so it can be
+// modified with UpdatePolicy.
+func (b *BigIP) CreatePolicyDraft(name string) error {
+	partition, policy := policyPath(name)
+	return b.patch(struct{}{}, uriLtm, uriPolicy, fmt.Sprintf("/%s/%s?options=create-draft", partition, policy))
+}
 
-	log.Println("  ================== here in publish ", result, publish)
+// PublishPolicy publishes the draft of a policy, replacing the published
+// policy. The draft is removed.
+func (b *BigIP) PublishPolicy(name string) error {
+	config := struct {
+		Command string `json:"command"`
+		Name    string `json:"name"`
+	}{"publish", PolicyDraftName(name)}
 
-	return b.patch(config, uriLtm, uriPolicy, result)
+	return b.post(config, uriLtm, uriPolicy)
 }
 
-//Update an existing policy.
+// UpdatePolicy replaces the draft of a policy.
 func (b *BigIP) UpdatePolicy(name string, p *Policy) error {
 	normalizePolicy(p)
-	values := []string{}
-	values = append(values, "Drafts/")
-	values = append(values, name)
-	// Join three strings into one.
-	result := strings.Join(values, "")
-	return b.put(p, uriLtm, uriPolicy, result)
+	return b.put(p, uriLtm, uriPolicy, PolicyDraftName(name))
 }
 
-//Delete a policy by name.
+// DeletePolicy removes a published policy.
 func (b *BigIP) DeletePolicy(name string) error {
-	values := []string{}
-	values = append(values, "Drafts/")
-	values = append(values, name)
-	// Join three strings into one.
-	result := strings.Join(values, "")
-	return b.delete(uriLtm, uriPolicy, result)
+	partition, policy := policyPath(name)
+	return b.delete(uriLtm, uriPolicy, fmt.Sprintf("/%s/%s", partition, policy))
+}
+
+// DeletePolicyDraft removes the draft of a policy.
+func (b *BigIP) DeletePolicyDraft(name string) error {
+	return b.delete(uriLtm, uriPolicy, PolicyDraftName(name))
 }
 
 // Oneconnect profile creation
@@ -2565,6 +2921,12 @@ func (b *BigIP) CreateOneconnect(name, idleTimeoutOverride, partition, defaultsF
 	return b.post(oneconnect, uriLtm, uriProfile, uriOneconnect)
 }
 
+// AddOneconnect creates a OneConnect profile, values left empty are
+// inherited from the parent profile.
+func (b *BigIP) AddOneconnect(config *Oneconnect) error {
+	return b.post(config, uriLtm, uriProfile, uriOneconnect)
+}
+
 func (b *BigIP) GetOneconnect(name string) (*Oneconnect, error) {
 	var oneconnect Oneconnect
 	err, ok := b.getForEntity(&oneconnect, uriLtm, uriProfile, uriOneconnect, name)
@@ -2596,10 +2958,10 @@ func (b *BigIP) CreateTcp(name, partition, defaultsFrom string, idleTimeout, clo
 		Name:              name,
 		Partition:         partition,
 		DefaultsFrom:      defaultsFrom,
-		IdleTimeout:       idleTimeout,
-		CloseWaitTimeout:  closeWaitTimeout,
-		FinWait_2Timeout:  finWait_2Timeout,
-		FinWaitTimeout:    finWaitTimeout,
+		IdleTimeout:       strconv.Itoa(idleTimeout),
+		CloseWaitTimeout:  strconv.Itoa(closeWaitTimeout),
+		FinWait_2Timeout:  strconv.Itoa(finWait_2Timeout),
+		FinWaitTimeout:    strconv.Itoa(finWaitTimeout),
 		KeepAliveInterval: keepAliveInterval,
 		DeferredAccept:    deferredAccept,
 		FastOpen:          fastOpen,
@@ -2607,6 +2969,11 @@ func (b *BigIP) CreateTcp(name, partition, defaultsFrom string, idleTimeout, clo
 	return b.post(tcp, uriLtm, uriProfile, uriTcp)
 }
 
+// AddTcp creates a TCP profile.
+func (b *BigIP) AddTcp(config *Tcp) error {
+	return b.post(config, uriLtm, uriProfile, uriTcp)
+}
+
 // DeleteOneconnect removes an OneConnect profile from the system.
 func (b *BigIP) DeleteTcp(name string) error {
 	return b.delete(uriLtm, uriProfile, uriTcp, name)
@@ -2631,6 +2998,96 @@ func (b *BigIP) GetTcp(name string) (*Tcp, error) {
 	return &tcp, nil
 }
 
+// AddUdpProfile creates a UDP profile.
+func (b *BigIP) AddUdpProfile(config *UdpProfile) error {
+	return b.post(config, uriLtm, uriProfile, uriUdp)
+}
+
+// ModifyUdpProfile updates the given UDP profile with any changed values.
+func (b *BigIP) ModifyUdpProfile(name string, config *UdpProfile) error {
+	config.Name = name
+	return b.put(config, uriLtm, uriProfile, uriUdp, name)
+}
+
+// DeleteUdpProfile removes a UDP profile from the system.
+func (b *BigIP) DeleteUdpProfile(name string) error {
+	return b.delete(uriLtm, uriProfile, uriUdp, name)
+}
+
+// GetUdpProfile returns the named UDP profile, or nil if it does not exist.
+func (b *BigIP) GetUdpProfile(name string) (*UdpProfile, error) {
+	var udp UdpProfile
+	err, ok := b.getForEntity(&udp, uriLtm, uriProfile, uriUdp, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &udp, nil
+}
+
+// AddFtpProfile creates an FTP profile.
+func (b *BigIP) AddFtpProfile(config *FtpProfile) error {
+	return b.post(config, uriLtm, uriProfile, uriFtp)
+}
+
+// ModifyFtpProfile updates the given FTP profile with any changed values.
+func (b *BigIP) ModifyFtpProfile(name string, config *FtpProfile) error {
+	config.Name = name
+	return b.put(config, uriLtm, uriProfile, uriFtp, name)
+}
+
+// DeleteFtpProfile removes an FTP profile from the system.
+func (b *BigIP) DeleteFtpProfile(name string) error {
+	return b.delete(uriLtm, uriProfile, uriFtp, name)
+}
+
+// GetFtpProfile returns the named FTP profile, or nil if it does not exist.
+func (b *BigIP) GetFtpProfile(name string) (*FtpProfile, error) {
+	var ftp FtpProfile
+	err, ok := b.getForEntity(&ftp, uriLtm, uriProfile, uriFtp, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &ftp, nil
+}
+
+// AddDnsProfile creates a DNS profile.
+func (b *BigIP) AddDnsProfile(config *DnsProfile) error {
+	return b.post(config, uriLtm, uriProfile, uriDns)
+}
+
+// ModifyDnsProfile updates the given DNS profile with any changed values.
+func (b *BigIP) ModifyDnsProfile(name string, config *DnsProfile) error {
+	config.Name = name
+	return b.put(config, uriLtm, uriProfile, uriDns, name)
+}
+
+// DeleteDnsProfile removes a DNS profile from the system.
+func (b *BigIP) DeleteDnsProfile(name string) error {
+	return b.delete(uriLtm, uriProfile, uriDns, name)
+}
+
+// GetDnsProfile returns the named DNS profile, or nil if it does not exist.
+func (b *BigIP) GetDnsProfile(name string) (*DnsProfile, error) {
+	var dns DnsProfile
+	err, ok := b.getForEntity(&dns, uriLtm, uriProfile, uriDns, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+
+	return &dns, nil
+}
+
 func (b *BigIP) CreateFasthttp(name, defaultsFrom string, idleTimeout, connpoolIdleTimeoutOverride, connpoolMaxReuse, connpoolMaxSize, connpoolMinSize int, connpoolReplenish string, connpoolStep int, forceHttp_10Response string, maxHeaderSize int) error {
 	fasthttp := &Fasthttp{
 		Name:                        name,
@@ -2686,6 +3143,11 @@ func (b *BigIP) CreateFastl4(name, partition, defaultsFrom string, clientTimeout
 	return b.post(fastl4, uriLtm, uriProfile, uriFastl4)
 }
 
+// AddFastl4 adds a new Fastl4 profile on the BIG-IP system.
+func (b *BigIP) AddFastl4(config *Fastl4) error {
+	return b.post(config, uriLtm, uriProfile, uriFastl4)
+}
+
 // Delete Fast http removes an Fasthttp profile from the system.
 func (b *BigIP) DeleteFastl4(name string) error {
 	return b.delete(uriLtm, uriProfile, uriFastl4, name)
@@ -2722,6 +3184,11 @@ func (b *BigIP) CreateHttpcompress(name, defaultsFrom string, uriExclude, uriInc
 	return b.post(httpcompress, uriLtm, uriProfile, uriHttpcompress)
 }
 
+// AddHttpcompress creates a new HTTP compression profile on the BIG-IP system.
+func (b *BigIP) AddHttpcompress(httpcompress *Httpcompress) error {
+	return b.post(httpcompress, uriLtm, uriProfile, uriHttpcompress)
+}
+
 // Delete Fast http removes an Fasthttp profile from the system.
 func (b *BigIP) DeleteHttpcompress(name string) error {
 	return b.delete(uriLtm, uriProfile, uriHttpcompress, name)
@@ -2841,23 +3308,24 @@ func (b *BigIP) AddRecords(name, rname, data string) error {
 	return &snats, nil
 }*/
 
-/*func (b *BigIP) CreateSnat(name, partition, autoLastHop, sourcePort, translation, snatpool, mirror string, vlansDisabled bool, origins []string) error {
-	snat := &Snat{
-		Name:          name,
-		Partition:     partition,
-		AutoLasthop:   autoLastHop,
-		SourcePort:    sourcePort,
-		Translation:   translation,
-		Snatpool:      snatpool,
-		Mirror:        mirror,
-		VlansDisabled: vlansDisabled,
-		Origins:       origins,
-	}
-	log.Println("[INFO] Creating snat  ", snat)
-	return b.post(snat, uriLtm, uriSnat)
-} */
+/*
+	func (b *BigIP) CreateSnat(name, partition, autoLastHop, sourcePort, translation, snatpool, mirror string, vlansDisabled bool, origins []string) error {
+		snat := &Snat{
+			Name:          name,
+			Partition:     partition,
+			AutoLasthop:   autoLastHop,
+			SourcePort:    sourcePort,
+			Translation:   translation,
+			Snatpool:      snatpool,
+			Mirror:        mirror,
+			VlansDisabled: vlansDisabled,
+			Origins:       origins,
+		}
+		log.Println("[INFO] Creating snat  ", snat)
+		return b.post(snat, uriLtm, uriSnat)
+	}
+*/
 func (b *BigIP) CreateSnat(p *Snat) error {
-	log.Println(" what is the complete payload    ", p)
 	return b.post(p, uriLtm, uriSnat)
 }
 
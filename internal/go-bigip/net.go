@@ -115,6 +115,9 @@ type Vlan struct {
 	} `json:"sflow,omitempty"`
 	SourceChecking string `json:"sourceChecking,omitempty"`
 	Tag            int    `json:"tag,omitempty"`
+	// Interfaces replaces the interfaces of the VLAN when set, a pointer to
+	// an empty list removes all of them.
+	Interfaces *[]VlanInterface `json:"interfaces,omitempty"`
 }
 
 // VlanInterfaces contains a list of Interface(s) attached to a VLAN.
@@ -144,6 +147,9 @@ type Route struct {
 	Gateway    string `json:"gw,omitempty"`
 	MTU        int    `json:"mtu,omitempty"`
 	Network    string `json:"network,omitempty"`
+	// Pool is the full path of an LTM pool whose members are the gateways
+	// of the route, set instead of Gateway.
+	Pool string `json:"pool,omitempty"`
 }
 
 // RouteDomains contains a list of every route domain on the BIG-IP system.
@@ -222,13 +228,16 @@ func (b *BigIP) SelfIPs() (*SelfIPs, error) {
 	return &self, nil
 }
 
-// SelfIP returns a named Self IP.
+// SelfIP returns a named Self IP. Returns nil if the self IP does not exist.
 func (b *BigIP) SelfIP(selfip string) (*SelfIP, error) {
 	var self SelfIP
-	err, _ := b.getForEntity(&self, uriNet, uriSelf, selfip)
+	err, ok := b.getForEntity(&self, uriNet, uriSelf, selfip)
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		return nil, nil
+	}
 
 	return &self, nil
 }
@@ -312,14 +321,17 @@ func (b *BigIP) Vlans() (*Vlans, error) {
 	return &vlans, nil
 }
 
-// Vlan returns a named vlan.
+// Vlan returns a named vlan. Returns nil if the vlan does not exist.
 func (b *BigIP) Vlan(name string) (*Vlan, error) {
 	var vlan Vlan
-	err, _ := b.getForEntity(&vlan, uriNet, uriVlan, name)
+	err, ok := b.getForEntity(&vlan, uriNet, uriVlan, name)
 
 	if err != nil {
 		return nil, err
 	}
+	if !ok {
+		return nil, nil
+	}
 
 	return &vlan, nil
 }
@@ -390,6 +402,11 @@ func (b *BigIP) CreateRoute(name, dest, gateway string) error {
 	return b.post(config, uriNet, uriRoute)
 }
 
+// AddRoute adds a static route with a gateway or a gateway pool.
+func (b *BigIP) AddRoute(config *Route) error {
+	return b.post(config, uriNet, uriRoute)
+}
+
 // DeleteRoute removes a static route.
 func (b *BigIP) DeleteRoute(name string) error {
 	return b.delete(uriNet, uriRoute, name)
@@ -2,16 +2,19 @@ package bigip
 
 import (
 	"encoding/json"
-	"log"
+	"fmt"
+	"strings"
 )
 
 type NTPs struct {
 	NTPs []NTP `json:"items"`
 }
 
+// NTP is the NTP configuration of the system. Servers is always sent, an
+// empty list removes all servers.
 type NTP struct {
 	Description string   `json:"description,omitempty"`
-	Servers     []string `json:"servers,omitempty"`
+	Servers     []string `json:"servers"`
 	Timezone    string   `json:"timezone,omitempty"`
 }
 
@@ -19,11 +22,14 @@ type DNSs struct {
 	DNSs []DNS `json:"items"`
 }
 
+// DNS is the DNS resolver configuration of the system. NameServers and
+// Search are always sent, an empty list removes all entries.
 type DNS struct {
 	Description  string   `json:"description,omitempty"`
-	NameServers  []string `json:"nameServers,omitempty"`
+	NameServers  []string `json:"nameServers"`
 	NumberOfDots int      `json:"numberOfDots,omitempty"`
-	Search       []string `json:"search,omitempty"`
+	Search       []string `json:"search"`
+	Include      string   `json:"include"`
 }
 
 type Provisions struct {
@@ -147,11 +153,20 @@ type Bigiplicense struct {
 	Command          string `json:"command,omitempty"`
 }
 
+// BashCommand runs a command through the bash utility of the BIG-IP, the
+// output of the command is returned in CommandResult.
+type BashCommand struct {
+	Command       string `json:"command"`
+	UtilCmdArgs   string `json:"utilCmdArgs"`
+	CommandResult string `json:"commandResult,omitempty"`
+}
+
 const (
 	uriSys       = "sys"
 	uriNtp       = "ntp"
 	uriDNS       = "dns"
 	uriProvision = "provision"
+	uriReady     = "ready"
 	uriAfm       = "afm"
 	uriAsm       = "asm"
 	uriApm       = "apm"
@@ -161,6 +176,8 @@ const (
 	uriSnmp      = "snmp"
 	uriTraps     = "traps"
 	uriLicense   = "license"
+	uriUtil      = "util"
+	uriBash      = "bash"
 )
 
 func (b *BigIP) CreateNTP(description string, servers []string, timezone string) error {
@@ -213,41 +230,24 @@ func (b *BigIP) DNSs() (*DNS, error) {
 	return &dns, nil
 }
 
+// CreateProvision sets the provisioning level of the module of fullPath, e.g.
+// /Common/asm. Modules cannot be created, only provisioned.
 func (b *BigIP) CreateProvision(name string, fullPath string, cpuRatio int, diskRatio int, level string, memoryRatio int) error {
 	config := &Provision{
-		Name:        name,
-		FullPath:    fullPath,
+		Name:        strings.TrimPrefix(fullPath, "/Common/"),
 		CpuRatio:    cpuRatio,
 		DiskRatio:   diskRatio,
 		Level:       level,
 		MemoryRatio: memoryRatio,
 	}
-	if fullPath == "/Common/asm" {
-		return b.put(config, uriSys, uriProvision, uriAsm)
-	}
-	if fullPath == "/Common/afm" {
-		return b.put(config, uriSys, uriProvision, uriAfm)
-
-	}
-	if fullPath == "/Common/gtm" {
-		return b.put(config, uriSys, uriProvision, uriGtm)
-	}
-
-	if fullPath == "/Common/apm" {
-		return b.put(config, uriSys, uriProvision, uriApm)
-	}
-
-	if fullPath == "/Common/avr" {
-		return b.put(config, uriSys, uriProvision, uriAvr)
-	}
-	if fullPath == "/Common/ilx" {
-		return b.put(config, uriSys, uriProvision, uriIlx)
-	}
-	return nil
+	return b.ModifyProvision(config)
 }
 
+// ModifyProvision sets the provisioning level and resource ratios of the
+// module named by config, e.g. ltm or asm. Changing the level of a module may
+// restart services or reboot the BIG-IP.
 func (b *BigIP) ModifyProvision(config *Provision) error {
-	return b.put(config, uriSys, uriProvision, uriAfm)
+	return b.put(config, uriSys, uriProvision, config.Name)
 }
 
 func (b *BigIP) DeleteProvision(name string) error {
@@ -255,55 +255,56 @@ func (b *BigIP) DeleteProvision(name string) error {
 	return b.delete(uriSys, uriProvision, uriIlx, name)
 }
 
-func (b *BigIP) Provisions(name string) (*Provision, error) {
+// GetProvision returns the provisioning of a module, e.g. ltm or asm. Returns
+// nil if the module does not exist on the BIG-IP.
+func (b *BigIP) GetProvision(module string) (*Provision, error) {
 	var provision Provision
-	if name == "afm" {
-		err, _ := b.getForEntity(&provision, uriSys, uriProvision, uriAfm)
-
-		if err != nil {
-			return nil, err
-		}
+	err, ok := b.getForEntity(&provision, uriSys, uriProvision, module)
+	if err != nil {
+		return nil, err
 	}
-	if name == "asm" {
-		err, _ := b.getForEntity(&provision, uriSys, uriProvision, uriAsm)
-
-		if err != nil {
-			return nil, err
-		}
+	if !ok {
+		return nil, nil
 	}
-	if name == "gtm" {
-		err, _ := b.getForEntity(&provision, uriSys, uriProvision, uriGtm)
 
-		if err != nil {
-			return nil, err
-		}
-	}
-	if name == "apm" {
-		err, _ := b.getForEntity(&provision, uriSys, uriProvision, uriApm)
+	return &provision, nil
+}
 
-		if err != nil {
-			return nil, err
-		}
-	}
-	if name == "avr" {
-		err, _ := b.getForEntity(&provision, uriSys, uriProvision, uriAvr)
+// Provisions returns the provisioning of a module, see GetProvision.
+func (b *BigIP) Provisions(name string) (*Provision, error) {
+	return b.GetProvision(name)
+}
 
-		if err != nil {
-			return nil, err
-		}
+// SysReady tells whether the configuration, license and provisioning of the
+// BIG-IP are ready, they are not while modules are being provisioned.
+type SysReady struct {
+	ConfigReady    bool
+	LicenseReady   bool
+	ProvisionReady bool
+}
 
-	}
-	if name == "ilx" {
-		err, _ := b.getForEntity(&provision, uriSys, uriProvision, uriIlx)
+// Ready tells whether the BIG-IP is ready to be configured.
+func (r *SysReady) Ready() bool {
+	return r.ConfigReady && r.LicenseReady && r.ProvisionReady
+}
 
-		if err != nil {
-			return nil, err
-		}
+// GetSysReady returns the readiness of the BIG-IP, available from BIG-IP 13.1.
+func (b *BigIP) GetSysReady() (*SysReady, error) {
+	var stats statsEntries
+	err, _ := b.getForEntity(&stats, uriSys, uriReady)
+	if err != nil {
+		return nil, err
+	}
 
+	ready := &SysReady{}
+	for _, entry := range stats.Entries {
+		e := entry.NestedStats.Entries
+		ready.ConfigReady = e["configReady"].Description == "yes"
+		ready.LicenseReady = e["licenseReady"].Description == "yes"
+		ready.ProvisionReady = e["provisionReady"].Description == "yes"
 	}
 
-	log.Println("Display ****************** provision  ", provision)
-	return &provision, nil
+	return ready, nil
 }
 
 func (b *BigIP) Syslogs() (*Syslog, error) {
@@ -352,7 +353,7 @@ func (b *BigIP) SNMPs() (*SNMP, error) {
 
 func (b *BigIP) CreateTRAP(name string, authPasswordEncrypted string, authProtocol string, community string, description string, engineId string, host string, port int, privacyPassword string, privacyPasswordEncrypted string, privacyProtocol string, securityLevel string, securityName string, version string) error {
 	config := &TRAP{
-		Name: name,
+		Name:                     name,
 		AuthPasswordEncrypted:    authPasswordEncrypted,
 		AuthProtocol:             authProtocol,
 		Community:                community,
@@ -413,3 +414,165 @@ func (b *BigIP) CreateBigiplicense(command, registration_key string) error {
 func (b *BigIP) ModifyBigiplicense(config *Bigiplicense) error {
 	return b.put(config, uriSys, uriLicense)
 }
+
+// RunBashCommand runs a command with bash -c on the BIG-IP and returns its
+// output. The command is not retried on server errors, it may have run anyway.
+func (b *BigIP) RunBashCommand(command string) (string, error) {
+	config := &BashCommand{
+		Command:     "run",
+		UtilCmdArgs: fmt.Sprintf("-c '%s'", strings.Replace(command, "'", `'\''`, -1)),
+	}
+	marshalJSON, err := jsonMarshal(config)
+	if err != nil {
+		return "", err
+	}
+
+	req := &APIRequest{
+		Method:        "post",
+		URL:           b.iControlPath([]string{uriUtil, uriBash}),
+		Body:          strings.TrimRight(string(marshalJSON), "\n"),
+		ContentType:   "application/json",
+		ReadsResponse: true,
+		NoRetry:       true,
+	}
+
+	resp, err := b.APICall(req)
+	if err != nil {
+		return "", err
+	}
+
+	var result BashCommand
+	if err := json.Unmarshal(resp, &result); err != nil {
+		return "", err
+	}
+	return result.CommandResult, nil
+}
+
+// Certificate is an SSL certificate or key file installed on the BIG-IP
+// system. Checksum changes whenever the file is replaced.
+type Certificate struct {
+	Name      string `json:"name,omitempty"`
+	Partition string `json:"partition,omitempty"`
+	FullPath  string `json:"fullPath,omitempty"`
+	Checksum  string `json:"checksum,omitempty"`
+}
+
+type cryptoInstall struct {
+	Command       string `json:"command"`
+	Name          string `json:"name"`
+	FromLocalFile string `json:"from-local-file"`
+	Passphrase    string `json:"passphrase,omitempty"`
+}
+
+const (
+	uriShared       = "shared"
+	uriFileTransfer = "file-transfer"
+	uriUploads      = "uploads"
+	uriCrypto       = "crypto"
+	uriCert         = "cert"
+	uriKey          = "key"
+	uriFile         = "file"
+	uriSslCert      = "ssl-cert"
+	uriSslKey       = "ssl-key"
+	uriUnixRm       = "unix-rm"
+
+	// uploadChunkSize is the largest part of a file sent by one upload
+	// request.
+	uploadChunkSize = 512 * 1024
+	// uploadDirectory is the directory uploaded files are saved in.
+	uploadDirectory = "/var/config/rest/downloads"
+)
+
+// UploadBytes uploads data to the BIG-IP system as filename, in parts of at
+// most 512 KB, and returns the path of the uploaded file.
+func (b *BigIP) UploadBytes(data []byte, filename string) (string, error) {
+	if len(data) == 0 {
+		return "", fmt.Errorf("Cannot upload the empty file %s", filename)
+	}
+	for start := 0; start < len(data); start += uploadChunkSize {
+		end := start + uploadChunkSize
+		if end > len(data) {
+			end = len(data)
+		}
+		req := &APIRequest{
+			Method:       "post",
+			URL:          b.iControlPath([]string{uriMgmt, uriShared, uriFileTransfer, uriUploads, filename}),
+			Body:         string(data[start:end]),
+			ContentType:  "application/octet-stream",
+			ContentRange: fmt.Sprintf("%d-%d/%d", start, end-1, len(data)),
+		}
+		if _, err := b.APICall(req); err != nil {
+			return "", err
+		}
+	}
+	return uploadDirectory + "/" + filename, nil
+}
+
+// RemoveUploadedFile deletes a file uploaded with UploadBytes.
+func (b *BigIP) RemoveUploadedFile(path string) error {
+	config := &BashCommand{
+		Command:     "run",
+		UtilCmdArgs: path,
+	}
+	return b.post(config, uriUtil, uriUnixRm)
+}
+
+// InstallCertificate installs the certificate file at path, uploaded with
+// UploadBytes, as name, replacing the certificate of that name.
+func (b *BigIP) InstallCertificate(name, path string) error {
+	config := &cryptoInstall{
+		Command:       "install",
+		Name:          name,
+		FromLocalFile: path,
+	}
+	return b.post(config, uriSys, uriCrypto, uriCert)
+}
+
+// InstallKey installs the key file at path, uploaded with UploadBytes, as
+// name, replacing the key of that name. The passphrase of unencrypted keys
+// is empty.
+func (b *BigIP) InstallKey(name, path, passphrase string) error {
+	config := &cryptoInstall{
+		Command:       "install",
+		Name:          name,
+		FromLocalFile: path,
+		Passphrase:    passphrase,
+	}
+	return b.post(config, uriSys, uriCrypto, uriKey)
+}
+
+// GetCertificate returns the named certificate, or nil if it does not exist.
+func (b *BigIP) GetCertificate(name string) (*Certificate, error) {
+	var cert Certificate
+	err, ok := b.getForEntity(&cert, uriSys, uriFile, uriSslCert, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &cert, nil
+}
+
+// GetKey returns the named key, or nil if it does not exist.
+func (b *BigIP) GetKey(name string) (*Certificate, error) {
+	var key Certificate
+	err, ok := b.getForEntity(&key, uriSys, uriFile, uriSslKey, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, nil
+	}
+	return &key, nil
+}
+
+// DeleteCertificate removes the named certificate.
+func (b *BigIP) DeleteCertificate(name string) error {
+	return b.delete(uriSys, uriFile, uriSslCert, name)
+}
+
+// DeleteKey removes the named key.
+func (b *BigIP) DeleteKey(name string) error {
+	return b.delete(uriSys, uriFile, uriSslKey, name)
+}